@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"kira/internal/config"
+	"kira/internal/fsutil"
 )
 
 var abandonCmd = &cobra.Command{
@@ -20,13 +21,9 @@ var abandonCmd = &cobra.Command{
 Updates work item status to "abandoned" before archival.`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: func(_ *cobra.Command, args []string) error {
-		if err := checkWorkDir(); err != nil {
-			return err
-		}
-
-		cfg, err := config.LoadConfig()
+		cfg, err := loadWorkspaceConfig()
 		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+			return err
 		}
 
 		target := args[0]
@@ -46,24 +43,30 @@ func abandonWorkItems(cfg *config.Config, target, reasonOrSubfolder string) erro
 	}
 
 	if len(workItems) == 0 {
-		fmt.Println("No work items found to abandon.")
+		infof("No work items found to abandon.")
 		return nil
 	}
 
+	for _, workItem := range workItems {
+		if err := checkTransitionAllowed(cfg, workItem, "abandoned"); err != nil {
+			return err
+		}
+	}
+
 	if err := markWorkItemsAbandoned(workItems, reasonOrSubfolder); err != nil {
 		return err
 	}
 
-	archivePath, err := archiveWorkItems(workItems, sourcePath)
+	archivePath, err := archiveWorkItems(cfg, workItems, sourcePath)
 	if err != nil {
 		return fmt.Errorf("failed to archive work items: %w", err)
 	}
 
-	if err := removeAbandonedFiles(workItems); err != nil {
+	if err := removeAbandonedFiles(cfg, workItems); err != nil {
 		return err
 	}
 
-	fmt.Printf("Abandoned %d work items to %s\n", len(workItems), archivePath)
+	infof("Abandoned %d work items to %s", len(workItems), archivePath)
 	return nil
 }
 
@@ -100,9 +103,9 @@ func buildSourcePath(cfg *config.Config, target, reasonOrSubfolder string) (stri
 	if strings.Contains(target, "/") {
 		sourcePath = filepath.Join(".work", target)
 	} else {
-		statusFolder, exists := cfg.StatusFolders[target]
-		if !exists {
-			return "", fmt.Errorf("invalid status: %s", target)
+		statusFolder, err := config.FolderForStatus(cfg, target)
+		if err != nil {
+			return "", err
 		}
 		sourcePath = filepath.Join(".work", statusFolder)
 	}
@@ -128,10 +131,10 @@ func markWorkItemsAbandoned(workItems []string, reasonOrSubfolder string) error
 	return nil
 }
 
-func removeAbandonedFiles(workItems []string) error {
+func removeAbandonedFiles(cfg *config.Config, workItems []string) error {
 	for _, workItem := range workItems {
-		if err := os.Remove(workItem); err != nil {
-			fmt.Printf("Warning: failed to remove %s: %v\n", workItem, err)
+		if err := removeWorkItemFile(cfg, workItem); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove %s: %v\n", workItem, err)
 		}
 	}
 	return nil
@@ -152,5 +155,5 @@ func addAbandonmentReason(filePath, reason string) error {
 	abandonmentNote := fmt.Sprintf("\n\n## Abandonment\n\n**Reason:** %s\n**Date:** %s\n", reason, time.Now().Format("2006-01-02 15:04:05"))
 	newContent := string(content) + abandonmentNote
 
-	return os.WriteFile(filePath, []byte(newContent), 0o600)
+	return fsutil.WriteFile(filePath, []byte(newContent), 0o600)
 }
@@ -0,0 +1,61 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+	"kira/internal/validation"
+)
+
+var advanceCmd = &cobra.Command{
+	Use:   "advance <work-item-id>...",
+	Short: "Move work items along their configured transitions",
+	Long: `Moves each work item to the status configured for its current status under
+transitions in kira.yml, e.g. todo -> doing -> done. Unlike bump, which follows
+the workflow order, only explicitly configured transitions are used; an item in a
+status without one is an error. Glob patterns such as '00*' are expanded.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		return advanceWorkItems(cfg, args, moveOptions{ToDefault: true})
+	},
+}
+
+// advanceWorkItems moves every work item named by args, expanding patterns,
+// along its default transition.
+func advanceWorkItems(cfg *config.Config, args []string, opts moveOptions) error {
+	opts.ToDefault = true
+	if len(args) == 1 && !isIDPattern(args[0]) {
+		return moveWorkItem(cfg, args[0], "", opts)
+	}
+
+	workItemIDs, err := expandWorkItemIDs(args)
+	if err != nil {
+		return err
+	}
+	return forEachWorkItem(workItemIDs, "advance", func(workItemID string) error {
+		return moveWorkItem(cfg, workItemID, "", opts)
+	})
+}
+
+// defaultTransition returns the status configured under transitions for the
+// current status of the work item at workItemPath.
+func defaultTransition(cfg *config.Config, workItemPath string) (string, error) {
+	item, err := validation.ParseWorkItemFile(workItemPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse work item: %w", err)
+	}
+
+	next, exists := cfg.Transitions[item.Status]
+	if !exists || next == "" {
+		return "", fmt.Errorf("no transition is configured for status '%s'", item.Status)
+	}
+	return next, nil
+}
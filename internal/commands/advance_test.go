@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/config"
+	"kira/internal/validation"
+)
+
+func TestAdvanceWorkItems(t *testing.T) {
+	newTransitionConfig := func() *config.Config {
+		cfg := newTestConfig()
+		cfg.Transitions = map[string]string{"todo": "doing", "doing": "done"}
+		return cfg
+	}
+
+	t.Run("follows the configured transition chain", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := newTransitionConfig()
+		require.NoError(t, config.EnsureStatusFolders(cfg))
+		writeTestWorkItem(t, "1_todo", "001", "Item", "todo", "task")
+
+		require.NoError(t, advanceWorkItems(cfg, []string{"001"}, moveOptions{}))
+		assert.FileExists(t, ".work/2_doing/001-item.task.md")
+
+		require.NoError(t, advanceWorkItems(cfg, []string{"001"}, moveOptions{}))
+		item, err := validation.ParseWorkItemFile(".work/4_done/001-item.task.md")
+		require.NoError(t, err)
+		assert.Equal(t, "done", item.Status)
+	})
+
+	t.Run("errors when no transition is configured", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "3_review", "001", "Item", "review", "task")
+
+		err := advanceWorkItems(newTransitionConfig(), []string{"001"}, moveOptions{})
+		assert.EqualError(t, err, "cannot advance work item 001: no transition is configured for status 'review'")
+		assert.FileExists(t, ".work/3_review/001-item.task.md")
+	})
+}
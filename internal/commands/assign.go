@@ -0,0 +1,65 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var assignCmd = &cobra.Command{
+	Use:   "assign <work-item-id> [person]",
+	Short: "Assign a work item to a person",
+	Long: `Sets the assigned field of a work item, the same field the default templates
+ask for. Use --clear to remove it. A glob pattern such as '00*' assigns every
+work item whose ID matches.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := loadWorkspaceConfig(); err != nil {
+			return err
+		}
+
+		clearAssignee, _ := cmd.Flags().GetBool("clear")
+		switch {
+		case clearAssignee && len(args) == 2:
+			return fmt.Errorf("cannot combine a person with --clear")
+		case !clearAssignee && len(args) < 2:
+			return fmt.Errorf("person is required (or use --clear to unassign)")
+		}
+
+		person := ""
+		if len(args) == 2 {
+			person = args[1]
+		}
+		return forEachWorkItemArg(args[0], "assign", func(workItemID string) error {
+			return assignWorkItem(workItemID, person)
+		})
+	},
+}
+
+func init() {
+	assignCmd.Flags().Bool("clear", false, "Remove the current assignee")
+}
+
+// assignWorkItem sets the assigned field of a work item; an empty person
+// removes the field.
+func assignWorkItem(workItemID, person string) error {
+	workItemPath, err := findWorkItemFile(workItemID)
+	if err != nil {
+		return err
+	}
+
+	if person == "" {
+		if err := removeFrontMatterField(workItemPath, assignedField); err != nil {
+			return fmt.Errorf("failed to clear assignee: %w", err)
+		}
+		infof("Unassigned work item %s", workItemID)
+		return nil
+	}
+
+	if err := setFrontMatterField(workItemPath, assignedField, person); err != nil {
+		return fmt.Errorf("failed to set assignee: %w", err)
+	}
+	infof("Assigned work item %s to %s", workItemID, person)
+	return nil
+}
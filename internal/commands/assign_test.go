@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/validation"
+)
+
+func TestAssignWorkItem(t *testing.T) {
+	t.Run("assigns and clears an assignee", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := writeTestWorkItem(t, "1_todo", "001", "Pair Up", "todo", "task")
+
+		require.NoError(t, assignWorkItem("001", "alex"))
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "alex", workItemAssignee(item))
+
+		require.NoError(t, assignWorkItem("001", ""))
+		item, err = validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "", workItemAssignee(item))
+	})
+
+	t.Run("uses the assigned field from the templates", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := writeTestWorkItem(t, "1_todo", "001", "Pair Up", "todo", "task")
+		require.NoError(t, setFrontMatterField(path, "assigned", "sam@example.com"))
+
+		require.NoError(t, assignWorkItem("001", "alex"))
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "alex", item.Fields["assigned"])
+		assert.NotContains(t, item.Fields, "assignee")
+
+		require.NoError(t, assignWorkItem("001", ""))
+		item, err = validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.NotContains(t, item.Fields, "assigned")
+	})
+
+	t.Run("lists only items for an assignee", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Mine", "todo", "task")
+		writeTestWorkItem(t, "1_todo", "002", "Theirs", "todo", "task")
+		writeTestWorkItem(t, "1_todo", "003", "Nobody", "todo", "task")
+		require.NoError(t, assignWorkItem("001", "alex"))
+		require.NoError(t, assignWorkItem("002", "sam"))
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Filter: workItemFilter{Assignee: "alex"}}))
+
+		assert.Equal(t, []string{"001"}, listedIDs(buf.String()))
+	})
+}
@@ -0,0 +1,81 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+	"kira/internal/validation"
+)
+
+var bumpCmd = &cobra.Command{
+	Use:   "bump <work-item-id>",
+	Short: "Advance a work item to the next status",
+	Long: `Moves a work item one status forward in the workflow order (status_order in
+kira.yml, or folder order when unset). Use --back to move it one status back.
+A glob pattern such as '00*' bumps every work item whose ID matches.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		back, _ := cmd.Flags().GetBool("back")
+		return forEachWorkItemArg(args[0], "bump", func(workItemID string) error {
+			return bumpWorkItem(cfg, workItemID, back)
+		})
+	},
+}
+
+func init() {
+	bumpCmd.Flags().Bool("back", false, "Move the work item to the previous status instead")
+}
+
+func bumpWorkItem(cfg *config.Config, workItemID string, back bool) error {
+	workItemPath, err := findWorkItemFile(workItemID)
+	if err != nil {
+		return err
+	}
+
+	item, err := validation.ParseWorkItemFile(workItemPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse work item: %w", err)
+	}
+
+	targetStatus, err := adjacentStatus(cfg, item.Status, back)
+	if err != nil {
+		return fmt.Errorf("cannot bump work item %s: %w", workItemID, err)
+	}
+
+	if _, err := relocateWorkItem(cfg, workItemPath, targetStatus, ""); err != nil {
+		return err
+	}
+
+	infof("Moved work item %s from %s to %s", workItemID, item.Status, targetStatus)
+	return nil
+}
+
+// adjacentStatus returns the status after status in workflow order, or the one
+// before it when back is set.
+func adjacentStatus(cfg *config.Config, status string, back bool) (string, error) {
+	order := config.OrderedStatuses(cfg)
+	for i, candidate := range order {
+		if candidate != status {
+			continue
+		}
+		switch {
+		case back && i == 0:
+			return "", fmt.Errorf("already in the first status (%s)", status)
+		case back:
+			return order[i-1], nil
+		case i == len(order)-1:
+			return "", fmt.Errorf("already in the last status (%s)", status)
+		default:
+			return order[i+1], nil
+		}
+	}
+	return "", fmt.Errorf("status '%s' is not in the workflow order", status)
+}
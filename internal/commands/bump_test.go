@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpWorkItem(t *testing.T) {
+	t.Run("moves forward to the next status", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Item", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+
+		require.NoError(t, bumpWorkItem(newTestConfig(), "001", false))
+
+		content, err := os.ReadFile(".work/2_doing/001-item.task.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "status: doing")
+	})
+
+	t.Run("moves back to the previous status", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Item", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/0_backlog", 0o700))
+
+		require.NoError(t, bumpWorkItem(newTestConfig(), "001", true))
+		assert.FileExists(t, ".work/0_backlog/001-item.task.md")
+	})
+
+	t.Run("follows status_order when configured", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Item", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/3_review", 0o700))
+
+		cfg := newTestConfig()
+		cfg.StatusOrder = []string{"backlog", "todo", "review", "done"}
+		require.NoError(t, bumpWorkItem(cfg, "001", false))
+		assert.FileExists(t, ".work/3_review/001-item.task.md")
+	})
+
+	t.Run("errors at either end of the workflow", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "0_backlog", "001", "First", "backlog", "task")
+		writeTestWorkItem(t, "z_archive", "002", "Last", "archived", "task")
+
+		err := bumpWorkItem(newTestConfig(), "001", true)
+		require.EqualError(t, err, "cannot bump work item 001: already in the first status (backlog)")
+
+		err = bumpWorkItem(newTestConfig(), "002", false)
+		require.EqualError(t, err, "cannot bump work item 002: already in the last status (archived)")
+
+		assert.FileExists(t, ".work/0_backlog/001-first.task.md")
+		assert.FileExists(t, ".work/z_archive/002-last.task.md")
+	})
+}
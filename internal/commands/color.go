@@ -0,0 +1,56 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"io"
+	"os"
+)
+
+// noColor is set by the --no-color persistent flag.
+var noColor bool
+
+// statusColors maps the default statuses to ANSI foreground colors.
+var statusColors = map[string]string{
+	"backlog":   "\x1b[90m",
+	"todo":      "\x1b[34m",
+	"doing":     "\x1b[33m",
+	"review":    "\x1b[35m",
+	"done":      "\x1b[32m",
+	"released":  "\x1b[36m",
+	"abandoned": "\x1b[31m",
+	"archived":  "\x1b[90m",
+}
+
+const (
+	defaultColor = "\x1b[39m"
+	resetColor   = "\x1b[0m"
+)
+
+// useColor reports whether output to w should be colorized: color is off when
+// --no-color or NO_COLOR is set, or when w is not a terminal.
+func useColor(w io.Writer) bool {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorStatus wraps text in the color for status when enabled.
+func colorStatus(status, text string, enabled bool) string {
+	if !enabled {
+		return text
+	}
+	code, exists := statusColors[status]
+	if !exists {
+		code = defaultColor
+	}
+	return code + text + resetColor
+}
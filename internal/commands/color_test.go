@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/validation"
+)
+
+func TestColorOutput(t *testing.T) {
+	t.Run("colors a status when enabled", func(t *testing.T) {
+		assert.Equal(t, "\x1b[33mdoing\x1b[0m", colorStatus("doing", "doing", true))
+		assert.Equal(t, "\x1b[39mcustom\x1b[0m", colorStatus("custom", "custom", true))
+		assert.Equal(t, "doing", colorStatus("doing", "doing", false))
+	})
+
+	t.Run("disables color with --no-color", func(t *testing.T) {
+		prev := noColor
+		noColor = true
+		defer func() { noColor = prev }()
+
+		assert.False(t, useColor(os.Stdout))
+	})
+
+	t.Run("disables color with NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		assert.False(t, useColor(os.Stdout))
+	})
+
+	t.Run("list emits no escape codes when not writing to a terminal", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "2_doing", "001", "Busy", "doing", "task")
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{SortBy: sortByID}))
+
+		assert.NotContains(t, buf.String(), "\x1b[")
+		assert.Contains(t, buf.String(), "doing")
+	})
+	t.Run("colored status cells stay aligned with the header", func(t *testing.T) {
+		items := []*validation.WorkItem{
+			{ID: "001", Title: "Busy", Status: "doing"},
+			{ID: "002", Title: "Waiting", Status: "backlog"},
+		}
+
+		plain := formatWorkItemTable(items, listOptions{}, false)
+		colored := formatWorkItemTable(items, listOptions{}, true)
+
+		assert.Contains(t, colored, "\x1b[33mdoing\x1b[0m")
+		assert.Equal(t, plain, regexp.MustCompile("\x1b\\[[0-9]+m").ReplaceAllString(colored, ""))
+	})
+}
@@ -0,0 +1,103 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+
+	"kira/internal/config"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and update kira.yml settings",
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a single config value",
+	Long: `Updates one key in kira.yml and writes it back, keeping other settings and comments.
+Nested keys use dots (validation.id_format, status_folders.blocked) and lists use
+brackets ([high, low]). The updated config is validated before it is written.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if err := checkWorkDir(); err != nil {
+			return err
+		}
+
+		if err := config.SetValue(args[0], args[1]); err != nil {
+			return err
+		}
+		infof("Set %s to %s in %s", args[0], args[1], config.FilePath())
+		return nil
+	},
+}
+
+const formatYAML = "yaml"
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Print a config value, or the whole resolved config",
+	Long: `Prints the current value of one config key, using the same dotted keys as
+config set. Without a key, prints the whole config as kira resolves it, with
+defaults filled in for anything kira.yml leaves out.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		key := ""
+		if len(args) > 0 {
+			key = args[0]
+		}
+		return printConfigValue(os.Stdout, cfg, key, format)
+	},
+}
+
+func init() {
+	configGetCmd.Flags().String("format", formatYAML, "Output format: yaml or json")
+
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+}
+
+// printConfigValue writes key's value (or the whole config when key is empty)
+// to w. Scalars in yaml format are printed bare so they are easy to script.
+func printConfigValue(w io.Writer, cfg *config.Config, key, format string) error {
+	value, err := config.GetValue(cfg, key)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case formatYAML:
+		switch v := value.(type) {
+		case string, bool, int, float64, nil:
+			_, err := fmt.Fprintln(w, v)
+			return err
+		}
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	case formatJSON:
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	default:
+		return fmt.Errorf("invalid format '%s' (valid: %s, %s)", format, formatYAML, formatJSON)
+	}
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/config"
+)
+
+func TestConfigSetCommand(t *testing.T) {
+	t.Run("sets a value in kira.yml", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work", 0o700))
+		require.NoError(t, os.WriteFile("kira.yml", []byte("version: \"1.0\"\n"), 0o600))
+
+		require.NoError(t, configSetCmd.RunE(configSetCmd, []string{"default_status", "todo"}))
+
+		cfg, err := config.LoadConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "todo", cfg.DefaultStatus)
+	})
+
+	t.Run("rejects a status that is not configured", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work", 0o700))
+		require.NoError(t, os.WriteFile("kira.yml", []byte("version: \"1.0\"\n"), 0o600))
+
+		err := configSetCmd.RunE(configSetCmd, []string{"default_status", "blocked"})
+		require.Error(t, err)
+
+		content, err := os.ReadFile("kira.yml")
+		require.NoError(t, err)
+		assert.Equal(t, "version: \"1.0\"\n", string(content))
+	})
+}
+
+func TestPrintConfigValue(t *testing.T) {
+	cfg := config.DefaultConfig
+
+	t.Run("prints a scalar bare", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printConfigValue(&buf, &cfg, "validation.id_format", formatYAML))
+		assert.Equal(t, "^\\d{3}$\n", buf.String())
+	})
+
+	t.Run("dumps the whole config as yaml", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printConfigValue(&buf, &cfg, "", formatYAML))
+		assert.Contains(t, buf.String(), "default_status: backlog\n")
+		assert.Contains(t, buf.String(), "status_folders:\n")
+	})
+
+	t.Run("dumps the whole config as json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printConfigValue(&buf, &cfg, "", formatJSON))
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, "done", decoded["done_status"])
+		assert.Equal(t, "2_doing", decoded["status_folders"].(map[string]interface{})["doing"])
+	})
+
+	t.Run("rejects unknown keys and formats", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.EqualError(t, printConfigValue(&buf, &cfg, "nope", formatYAML), "unknown config key: nope")
+		assert.Error(t, printConfigValue(&buf, &cfg, "", "toml"))
+	})
+}
@@ -0,0 +1,43 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var countCmd = &cobra.Command{
+	Use:   "count",
+	Short: "Print the number of work items",
+	Long: `Prints the number of work items as a bare integer, optionally filtered by
+status and kind, for use in scripts: n=$(kira count --status todo)`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if _, err := loadWorkspaceConfig(); err != nil {
+			return err
+		}
+
+		status, _ := cmd.Flags().GetString("status")
+		kind, _ := cmd.Flags().GetString("kind")
+
+		return countWorkItems(os.Stdout, workItemFilter{Status: status, Kind: kind})
+	},
+}
+
+func init() {
+	countCmd.Flags().String("status", "", "Only count work items with this status")
+	countCmd.Flags().String("kind", "", "Only count work items of this kind")
+}
+
+func countWorkItems(w io.Writer, filter workItemFilter) error {
+	items, err := loadWorkItems(filter)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, len(items))
+	return err
+}
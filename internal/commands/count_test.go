@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountWorkItems(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	writeTestWorkItem(t, "0_backlog", "001", "Idea", "backlog", "prd")
+	writeTestWorkItem(t, "1_todo", "002", "Fix", "todo", "issue")
+	writeTestWorkItem(t, "1_todo", "003", "Build", "todo", "task")
+
+	t.Run("counts all work items", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, countWorkItems(&buf, workItemFilter{}))
+		assert.Equal(t, "3\n", buf.String())
+	})
+
+	t.Run("counts by status", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, countWorkItems(&buf, workItemFilter{Status: "todo"}))
+		assert.Equal(t, "2\n", buf.String())
+	})
+
+	t.Run("counts by status and kind", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, countWorkItems(&buf, workItemFilter{Status: "todo", Kind: "task"}))
+		assert.Equal(t, "1\n", buf.String())
+	})
+}
@@ -0,0 +1,53 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/diffutil"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <work-item-id> <work-item-id>",
+	Short: "Show a unified diff of two work items",
+	Long: `Prints a unified diff turning the first work item's file into the second's,
+covering both the front matter and the body. Useful for spotting duplicates.
+The diff is colored on a terminal; nothing is printed when the files match.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if _, err := loadWorkspaceConfig(); err != nil {
+			return err
+		}
+		return diffWorkItems(os.Stdout, args[0], args[1], useColor(os.Stdout))
+	},
+}
+
+// diffWorkItems writes a unified diff of two work items' files to w,
+// colorized when color is set.
+func diffWorkItems(w io.Writer, fromID, toID string, color bool) error {
+	var paths, contents [2]string
+	for i, id := range []string{fromID, toID} {
+		path, err := findWorkItemFileOrTitle(id)
+		if err != nil {
+			return err
+		}
+		content, err := safeReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read work item: %w", err)
+		}
+		paths[i], contents[i] = filepath.ToSlash(path), string(content)
+	}
+
+	diff := diffutil.Unified(paths[0], paths[1], contents[0], contents[1])
+	if diff == "" {
+		infof("Work items %s and %s are identical", fromID, toID)
+		return nil
+	}
+	_, err := io.WriteString(w, colorDiff(diff, color))
+	return err
+}
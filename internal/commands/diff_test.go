@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffWorkItems(t *testing.T) {
+	setup := func(t *testing.T) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+	}
+
+	t.Run("shows changed fields and body lines", func(t *testing.T) {
+		setup(t)
+		writeTestWorkItem(t, "1_todo", "001", "Fix login", "todo", "bug")
+		path := writeTestWorkItem(t, "2_doing", "002", "Fix login", "doing", "bug")
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		content = []byte(strings.Replace(string(content), "# Fix login", "# Fix the login page", 1))
+		require.NoError(t, os.WriteFile(path, content, 0o600))
+
+		var buf bytes.Buffer
+		require.NoError(t, diffWorkItems(&buf, "001", "002", false))
+
+		diff := buf.String()
+		assert.Contains(t, diff, "--- .work/1_todo/001-fix-login.bug.md\n")
+		assert.Contains(t, diff, "+++ .work/2_doing/002-fix-login.bug.md\n")
+		assert.Contains(t, diff, "\n-status: todo\n+status: doing\n")
+		assert.Contains(t, diff, "\n-# Fix login\n+# Fix the login page\n")
+		assert.Contains(t, diff, "\n title: Fix login\n")
+	})
+
+	t.Run("colors the diff when enabled", func(t *testing.T) {
+		setup(t)
+		writeTestWorkItem(t, "1_todo", "001", "One", "todo", "task")
+		writeTestWorkItem(t, "1_todo", "002", "Two", "todo", "task")
+
+		var buf bytes.Buffer
+		require.NoError(t, diffWorkItems(&buf, "001", "002", true))
+		assert.Contains(t, buf.String(), "\x1b[31m-title: One"+resetColor)
+		assert.Contains(t, buf.String(), "\x1b[32m+title: Two"+resetColor)
+	})
+
+	t.Run("prints nothing for identical files", func(t *testing.T) {
+		setup(t)
+		writeTestWorkItem(t, "1_todo", "001", "Same", "todo", "task")
+
+		var buf bytes.Buffer
+		require.NoError(t, diffWorkItems(&buf, "001", "001", false))
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("fails for an unknown item", func(t *testing.T) {
+		setup(t)
+		writeTestWorkItem(t, "1_todo", "001", "Only", "todo", "task")
+
+		assert.Error(t, diffWorkItems(&bytes.Buffer{}, "001", "999", false))
+	})
+}
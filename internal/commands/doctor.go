@@ -2,46 +2,201 @@
 package commands
 
 import (
+	"bufio"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"kira/internal/config"
+	"kira/internal/templates"
 	"kira/internal/validation"
 )
 
 var doctorCmd = &cobra.Command{
 	Use:   "doctor",
-	Short: "Check for and fix duplicate work item IDs",
-	Long:  `Checks for and fixes duplicate work item IDs by updating the latest one with a new ID.`,
-	RunE: func(_ *cobra.Command, _ []string) error {
-		if err := checkWorkDir(); err != nil {
-			return err
+	Short: "Check workspace health and fix duplicate work item IDs",
+	Long: `Runs environment and configuration health checks: the .work directory exists,
+the config loads and validates, every status folder and template file is present,
+and work item IDs are unique. Duplicate IDs are fixed by updating the latest one
+with a new ID, and missing status folders can be created on request.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		fix, _ := cmd.Flags().GetBool("fix")
+		return runDoctor(fix)
+	},
+}
+
+func init() {
+	doctorCmd.Flags().Bool("fix", false, "Create missing status folders without prompting")
+}
+
+// doctorCheck is a single line in the doctor checklist.
+type doctorCheck struct {
+	Name   string
+	Passed bool
+	Detail string
+}
+
+func runDoctor(fix bool) error {
+	checks := runDoctorChecks(fix)
+
+	failed := 0
+	for _, check := range checks {
+		mark := "PASS"
+		if !check.Passed {
+			mark = "FAIL"
+			failed++
 		}
+		fmt.Printf("[%s] %s\n", mark, check.Name)
+		if check.Detail != "" {
+			for _, line := range strings.Split(check.Detail, "\n") {
+				fmt.Printf("       %s\n", line)
+			}
+		}
+	}
+
+	fmt.Printf("\n%d checks, %d passed, %d failed\n", len(checks), len(checks)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("doctor found %d failing check(s)", failed)
+	}
+	return nil
+}
+
+func runDoctorChecks(fix bool) []doctorCheck {
+	var checks []doctorCheck
 
-		cfg, err := config.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+	if err := checkWorkDir(); err != nil {
+		return append(checks, doctorCheck{Name: ".work directory exists", Detail: err.Error()})
+	}
+	checks = append(checks, doctorCheck{Name: ".work directory exists", Passed: true})
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return append(checks, doctorCheck{Name: "config loads and validates", Detail: err.Error()})
+	}
+	if err := config.ValidateConfig(cfg); err != nil {
+		checks = append(checks, doctorCheck{Name: "config loads and validates", Detail: err.Error()})
+	} else {
+		checks = append(checks, doctorCheck{Name: "config loads and validates", Passed: true})
+	}
+
+	checks = append(checks, checkStatusFolders(cfg, fix))
+	checks = append(checks, checkTemplateFiles(cfg))
+	checks = append(checks, checkDuplicateIDs(cfg))
+
+	return checks
+}
+
+func checkStatusFolders(cfg *config.Config, fix bool) doctorCheck {
+	check := doctorCheck{Name: "status folders exist"}
+
+	var missing []string
+	for _, folder := range cfg.StatusFolders {
+		if _, err := os.Stat(filepath.Join(".work", folder)); os.IsNotExist(err) {
+			missing = append(missing, folder)
 		}
+	}
+	sort.Strings(missing)
 
-		return fixDuplicateIDs(cfg)
-	},
+	if len(missing) == 0 {
+		check.Passed = true
+		return check
+	}
+
+	if !fix {
+		fix = confirmCreateFolders(missing)
+	}
+	if !fix {
+		check.Detail = fmt.Sprintf("missing: %s (run 'kira doctor --fix' to create them)", strings.Join(missing, ", "))
+		return check
+	}
+
+	for _, folder := range missing {
+		if err := os.MkdirAll(filepath.Join(".work", folder), cfg.DirPerm()); err != nil {
+			check.Detail = fmt.Sprintf("failed to create %s: %v", folder, err)
+			return check
+		}
+	}
+	check.Passed = true
+	check.Detail = fmt.Sprintf("created: %s", strings.Join(missing, ", "))
+	return check
 }
 
-func fixDuplicateIDs(_ *config.Config) error {
-	result, err := validation.FixDuplicateIDs()
+func confirmCreateFolders(missing []string) bool {
+	fmt.Printf("Missing status folders: %s\n", strings.Join(missing, ", "))
+	fmt.Print("Create them now? (y/N): ")
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
 	if err != nil {
-		return fmt.Errorf("failed to fix duplicate IDs: %w", err)
+		fmt.Println()
+		return false
 	}
+	choice := strings.ToLower(strings.TrimSpace(input))
+	return choice == "y" || choice == "yes"
+}
 
-	if result.HasErrors() {
-		fmt.Println("Issues found and fixed:")
-		for _, err := range result.Errors {
-			fmt.Printf("  %s\n", err.Error())
+func checkTemplateFiles(cfg *config.Config) doctorCheck {
+	check := doctorCheck{Name: "template files exist"}
+
+	var missing, builtin []string
+	for name, path := range cfg.Templates {
+		filePath := templateFilePath(cfg, name)
+		if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+			continue
 		}
-	} else {
-		fmt.Println("No duplicate IDs found. All work items have unique IDs.")
+		if _, ok := templates.BuiltinForPath(filePath); ok {
+			builtin = append(builtin, name)
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("%s (%s)", name, path))
 	}
+	sort.Strings(missing)
+	sort.Strings(builtin)
 
-	return nil
+	if len(missing) > 0 {
+		check.Detail = fmt.Sprintf("missing: %s", strings.Join(missing, ", "))
+		return check
+	}
+	check.Passed = true
+	if len(builtin) > 0 {
+		check.Detail = fmt.Sprintf("using built-in: %s", strings.Join(builtin, ", "))
+	}
+	return check
+}
+
+func checkDuplicateIDs(_ *config.Config) doctorCheck {
+	check := doctorCheck{Name: "work item IDs are unique"}
+
+	duplicates, err := validation.FindDuplicateIDs()
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	if len(duplicates) == 0 {
+		check.Passed = true
+		check.Detail = "No duplicate IDs found. All work items have unique IDs."
+		return check
+	}
+
+	result, err := validation.FixDuplicateIDs()
+	if err != nil {
+		check.Detail = fmt.Sprintf("failed to fix duplicate IDs: %v", err)
+		return check
+	}
+	if result.HasErrors() {
+		check.Detail = result.Error()
+		return check
+	}
+
+	ids := make([]string, 0, len(duplicates))
+	for id := range duplicates {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return compareIDs(ids[i], ids[j]) < 0 })
+	check.Passed = true
+	check.Detail = fmt.Sprintf("fixed duplicate IDs: %s", strings.Join(ids, ", "))
+	return check
 }
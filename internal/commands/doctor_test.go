@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/config"
+)
+
+func findDoctorCheck(t *testing.T, checks []doctorCheck, name string) doctorCheck {
+	t.Helper()
+	for _, check := range checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	t.Fatalf("doctor check %q not found", name)
+	return doctorCheck{}
+}
+
+func TestRunDoctorChecks(t *testing.T) {
+	t.Run("passes for a freshly initialized workspace", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, initializeWorkspace("."))
+
+		for _, check := range runDoctorChecks(false) {
+			assert.True(t, check.Passed, "check %q failed: %s", check.Name, check.Detail)
+		}
+		require.NoError(t, runDoctor(false))
+	})
+
+	t.Run("reports a missing status folder and creates it with fix", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, initializeWorkspace("."))
+		require.NoError(t, os.RemoveAll(".work/3_review"))
+
+		check := findDoctorCheck(t, runDoctorChecks(false), "status folders exist")
+		assert.False(t, check.Passed)
+		assert.Contains(t, check.Detail, "3_review")
+		assert.Error(t, runDoctor(false))
+
+		check = findDoctorCheck(t, runDoctorChecks(true), "status folders exist")
+		assert.True(t, check.Passed)
+		assert.Contains(t, check.Detail, "created: 3_review")
+		assert.DirExists(t, ".work/3_review")
+	})
+
+	t.Run("reports a missing template file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, initializeWorkspace("."))
+		require.NoError(t, config.SetValue("templates.custom", "templates/template.custom.md"))
+
+		check := findDoctorCheck(t, runDoctorChecks(false), "template files exist")
+		assert.False(t, check.Passed)
+		assert.Contains(t, check.Detail, "custom (templates/template.custom.md)")
+	})
+
+	t.Run("accepts a missing template with a built-in fallback", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, initializeWorkspace("."))
+		require.NoError(t, os.Remove(".work/templates/template.spike.md"))
+
+		check := findDoctorCheck(t, runDoctorChecks(false), "template files exist")
+		assert.True(t, check.Passed)
+		assert.Equal(t, "using built-in: spike", check.Detail)
+	})
+
+	t.Run("stops early when .work is missing", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		checks := runDoctorChecks(false)
+		require.Len(t, checks, 1)
+		assert.False(t, checks[0].Passed)
+	})
+}
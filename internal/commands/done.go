@@ -0,0 +1,55 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+)
+
+var doneCmd = &cobra.Command{
+	Use:   "done <work-item-id>",
+	Short: "Move a work item to the done status",
+	Long: `Moves the work item to the configured done status (done_status in kira.yml)
+and records the completion date in its completed field. A glob pattern such as
+'00*' marks every work item whose ID matches as done. --reason records why, as
+it does for kira move.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		reason, _ := cmd.Flags().GetString("reason")
+		return forEachWorkItemArg(args[0], "complete", func(workItemID string) error {
+			return markWorkItemDone(cfg, workItemID, reason)
+		})
+	},
+}
+
+func init() {
+	doneCmd.Flags().String("reason", "", "Record why the work item is done")
+}
+
+func markWorkItemDone(cfg *config.Config, workItemID, reason string) error {
+	workItemPath, err := findWorkItemFile(workItemID)
+	if err != nil {
+		return err
+	}
+
+	targetPath, err := relocateWorkItem(cfg, workItemPath, cfg.DoneStatus, reason)
+	if err != nil {
+		return err
+	}
+
+	if err := setFrontMatterField(targetPath, "completed", time.Now().Format("2006-01-02")); err != nil {
+		return fmt.Errorf("failed to set completed date: %w", err)
+	}
+
+	infof("Moved work item %s to %s", workItemID, cfg.DoneStatus)
+	return nil
+}
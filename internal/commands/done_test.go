@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/validation"
+)
+
+func TestMarkWorkItemDone(t *testing.T) {
+	t.Run("moves the item to done and records completion", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "2_doing", "001", "Ship It", "doing", "task")
+		require.NoError(t, os.MkdirAll(".work/4_done", 0o700))
+
+		require.NoError(t, markWorkItemDone(newTestConfig(), "001", ""))
+
+		path := ".work/4_done/001-ship-it.task.md"
+		assert.NoFileExists(t, ".work/2_doing/001-ship-it.task.md")
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "done", item.Status)
+		assert.Equal(t, time.Now().Format("2006-01-02"), item.Field("completed"))
+	})
+
+	t.Run("uses a custom done status", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := newTestConfig()
+		cfg.StatusFolders["erledigt"] = "9_erledigt"
+		cfg.DoneStatus = "erledigt"
+		writeTestWorkItem(t, "1_todo", "001", "Aufgabe", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/9_erledigt", 0o700))
+
+		require.NoError(t, markWorkItemDone(cfg, "001", ""))
+
+		item, err := validation.ParseWorkItemFile(".work/9_erledigt/001-aufgabe.task.md")
+		require.NoError(t, err)
+		assert.Equal(t, "erledigt", item.Status)
+	})
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultEditor is used when neither $VISUAL nor $EDITOR is set.
+const defaultEditor = "vi"
+
+// editorCommand returns the editor to run and its leading arguments, from
+// $VISUAL, then $EDITOR, then defaultEditor. Values such as "code --wait" are
+// split on whitespace.
+func editorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{defaultEditor}
+}
+
+// openInEditor runs the editor on path attached to the terminal and waits for
+// it to exit.
+func openInEditor(path string) error {
+	editor := editorCommand()
+	debugf("Opening %s with %s", path, strings.Join(editor, " "))
+
+	// #nosec G204 - the editor comes from the user's own environment
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run editor %s: %w", editor[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/fsutil"
+	"kira/internal/validation"
+)
+
+const (
+	formatMarkdown = "markdown"
+	formatJSON     = "json"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Bundle work items into a single markdown or JSON document",
+	Long: `Concatenates all work items (or those matching --status/--kind) into a single
+document written to stdout or to the file given by --out.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		status, _ := cmd.Flags().GetString("status")
+		kind, _ := cmd.Flags().GetString("kind")
+		format, _ := cmd.Flags().GetString("format")
+		out, _ := cmd.Flags().GetString("out")
+
+		filter := workItemFilter{Status: status, Kind: kind}
+		if out == "" {
+			return exportWorkItems(os.Stdout, filter, format)
+		}
+
+		var sb strings.Builder
+		if err := exportWorkItems(&sb, filter, format); err != nil {
+			return err
+		}
+		if err := fsutil.WriteFile(filepath.Clean(out), []byte(sb.String()), cfg.FilePerm()); err != nil {
+			return fmt.Errorf("failed to write export file: %w", err)
+		}
+		infof("Exported work items to %s", out)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("status", "", "Only export work items with this status")
+	exportCmd.Flags().String("kind", "", "Only export work items of this kind")
+	exportCmd.Flags().String("format", formatMarkdown, "Output format: markdown or json")
+	exportCmd.Flags().StringP("out", "o", "", "Write the export to a file instead of stdout")
+	_ = exportCmd.MarkFlagFilename("out")
+}
+
+// exportedWorkItem is the JSON representation of a work item in an export.
+type exportedWorkItem struct {
+	ID      string                 `json:"id"`
+	Title   string                 `json:"title"`
+	Status  string                 `json:"status"`
+	Kind    string                 `json:"kind"`
+	Created string                 `json:"created"`
+	Path    string                 `json:"path,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Body    string                 `json:"body"`
+}
+
+func exportWorkItems(w io.Writer, filter workItemFilter, format string) error {
+	items, err := loadWorkItems(filter)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case formatMarkdown:
+		return exportMarkdown(w, items)
+	case formatJSON:
+		return exportJSON(w, items)
+	default:
+		return fmt.Errorf("invalid format '%s' (valid: %s, %s)", format, formatMarkdown, formatJSON)
+	}
+}
+
+func exportMarkdown(w io.Writer, items []*validation.WorkItem) error {
+	for i, item := range items {
+		content, err := safeReadFile(item.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read work item: %w", err)
+		}
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "<!-- kira:item id=%q path=%q -->\n", item.ID, filepath.ToSlash(item.Path))
+		fmt.Fprint(w, strings.TrimRight(string(content), "\n")+"\n")
+	}
+	return nil
+}
+
+func exportJSON(w io.Writer, items []*validation.WorkItem) error {
+	exported := make([]exportedWorkItem, 0, len(items))
+	for _, item := range items {
+		exported = append(exported, toExportedWorkItem(item))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(exported)
+}
+
+func toExportedWorkItem(item *validation.WorkItem) exportedWorkItem {
+	fields := make(map[string]interface{}, len(item.Fields))
+	for key, value := range item.Fields {
+		fields[key] = jsonValue(value)
+	}
+	return exportedWorkItem{
+		ID:      item.ID,
+		Title:   item.Title,
+		Status:  item.Status,
+		Kind:    item.Kind,
+		Created: item.Created,
+		Path:    filepath.ToSlash(item.Path),
+		Fields:  fields,
+		Body:    item.Body,
+	}
+}
+
+// jsonValue converts YAML-decoded values into their JSON-friendly form,
+// rendering dates the way they were written in the front matter.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return validation.FieldString(v)
+	case []interface{}:
+		converted := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			converted = append(converted, jsonValue(item))
+		}
+		return converted
+	default:
+		return v
+	}
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportWorkItems(t *testing.T) {
+	setup := func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		writeTestWorkItem(t, "1_todo", "001", "First Feature", "todo", "prd")
+		writeTestWorkItem(t, "1_todo", "002", "Login Bug", "todo", "issue")
+		writeTestWorkItem(t, "2_doing", "003", "Refactor", "doing", "task")
+	}
+
+	t.Run("markdown export contains every item", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, exportWorkItems(&buf, workItemFilter{}, formatMarkdown))
+
+		out := buf.String()
+		assert.Contains(t, out, `<!-- kira:item id="001" path=".work/1_todo/001-first-feature.prd.md" -->`)
+		assert.Contains(t, out, `<!-- kira:item id="002"`)
+		assert.Contains(t, out, `<!-- kira:item id="003"`)
+		assert.Contains(t, out, "# Refactor")
+	})
+
+	t.Run("json export respects status and kind filters", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, exportWorkItems(&buf, workItemFilter{Status: "todo"}, formatJSON))
+
+		var items []exportedWorkItem
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &items))
+		require.Len(t, items, 2)
+		assert.Equal(t, "001", items[0].ID)
+		assert.Equal(t, "002", items[1].ID)
+		assert.Contains(t, items[0].Body, "# First Feature")
+
+		buf.Reset()
+		require.NoError(t, exportWorkItems(&buf, workItemFilter{Status: "todo", Kind: "issue"}, formatJSON))
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &items))
+		require.Len(t, items, 1)
+		assert.Equal(t, "Login Bug", items[0].Title)
+	})
+
+	t.Run("rejects an unknown format", func(t *testing.T) {
+		setup(t)
+
+		err := exportWorkItems(&bytes.Buffer{}, workItemFilter{}, "xml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid format")
+	})
+}
@@ -0,0 +1,143 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+
+	"kira/internal/fsutil"
+	"kira/internal/validation"
+)
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [work-item-id]",
+	Short: "Normalize work item front matter",
+	Long: `Rewrites front matter in canonical form: id, title, status, kind, and created first,
+then the remaining fields alphabetically, with values unquoted where YAML allows and
+lists written inline. The body is left untouched. Without an ID, every work item is
+formatted.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if _, err := loadWorkspaceConfig(); err != nil {
+			return err
+		}
+
+		var files []string
+		if len(args) > 0 {
+			workItemPath, err := findWorkItemFile(args[0])
+			if err != nil {
+				return err
+			}
+			files = []string{workItemPath}
+		} else {
+			var err error
+			files, err = validation.WorkItemFiles()
+			if err != nil {
+				return fmt.Errorf("failed to list work items: %w", err)
+			}
+		}
+
+		changed, err := formatWorkItemFiles(files)
+		if err != nil {
+			return err
+		}
+		infof("Formatted %d of %d work items", changed, len(files))
+		return nil
+	},
+}
+
+// formatWorkItemFiles rewrites each file whose front matter is not already
+// canonical and returns how many files changed.
+func formatWorkItemFiles(files []string) (int, error) {
+	changed := 0
+	for _, file := range files {
+		content, err := safeReadFile(file)
+		if err != nil {
+			return changed, err
+		}
+
+		formatted, err := formatFrontMatter(string(content))
+		if err != nil {
+			return changed, fmt.Errorf("failed to format %s: %w", file, err)
+		}
+		if formatted == string(content) {
+			continue
+		}
+
+		if err := fsutil.WriteFile(file, []byte(formatted), 0o600); err != nil {
+			return changed, fmt.Errorf("failed to write %s: %w", file, err)
+		}
+		debugf("Formatted %s", file)
+		changed++
+	}
+	return changed, nil
+}
+
+// formatFrontMatter re-renders the front matter of content in canonical field
+// order and quoting, keeping everything from the closing --- onwards as is.
+// Values are reordered as YAML nodes rather than decoded so IDs such as 001
+// keep their original text.
+func formatFrontMatter(content string) (string, error) {
+	lines := strings.Split(content, "\n")
+	end := frontMatterEnd(lines)
+	if end < 0 {
+		return "", fmt.Errorf("no front matter found")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "\n")), &doc); err != nil {
+		return "", fmt.Errorf("failed to parse front matter: %w", err)
+	}
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	if len(doc.Content) > 0 {
+		mapping = doc.Content[0]
+	}
+	if mapping.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("front matter is not a mapping")
+	}
+
+	values := make(map[string]*yaml.Node, len(mapping.Content)/2)
+	keys := make(map[string]interface{}, len(mapping.Content)/2)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		values[mapping.Content[i].Value] = mapping.Content[i+1]
+		keys[mapping.Content[i].Value] = nil
+	}
+
+	canonical := &yaml.Node{Kind: yaml.MappingNode}
+	for _, name := range orderedFieldNames(keys) {
+		value := values[name]
+		normalizeNode(value)
+		canonical.Content = append(canonical.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, value)
+	}
+
+	var sb strings.Builder
+	encoder := yaml.NewEncoder(&sb)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(canonical); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	return "---\n" + sb.String() + strings.Join(lines[end:], "\n"), nil
+}
+
+// normalizeNode drops explicit quoting so scalars are written plain where YAML
+// allows, and renders lists inline, matching renderWorkItem. Each scalar keeps
+// its resolved tag, so quotes stay wherever the plain form would read as a
+// different type, as with "001", "true", or "2024-01-01".
+func normalizeNode(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		node.Style = 0
+	case yaml.SequenceNode:
+		node.Style = yaml.FlowStyle
+	}
+	for _, child := range node.Content {
+		normalizeNode(child)
+	}
+}
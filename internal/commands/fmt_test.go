@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestFormatFrontMatter(t *testing.T) {
+	input := `---
+priority: 'high'
+title: "Fix the login page"
+tags:
+  - auth
+  - "ui"
+id: 001
+assigned:
+kind: issue
+depends_on: ["002", 010]
+status: todo
+created: 2024-01-01
+---
+
+# Fix the login page
+
+status: not front matter
+`
+
+	want := `---
+id: 001
+title: Fix the login page
+status: todo
+kind: issue
+created: 2024-01-01
+assigned:
+depends_on: ["002", 010]
+priority: high
+tags: [auth, ui]
+---
+
+# Fix the login page
+
+status: not front matter
+`
+
+	formatted, err := formatFrontMatter(input)
+	require.NoError(t, err)
+	assert.Equal(t, want, formatted)
+
+	again, err := formatFrontMatter(formatted)
+	require.NoError(t, err)
+	assert.Equal(t, formatted, again, "formatting should be idempotent")
+
+	_, err = formatFrontMatter("# No front matter\n")
+	assert.Error(t, err)
+}
+
+func TestFormatFrontMatterKeepsQuotedTypes(t *testing.T) {
+	input := "---\nid: \"001\"\ntitle: 'Plain'\nflag: \"true\"\ndue: \"2024-01-01\"\nestimate: '3'\nnote: \"a: b\"\n---\n"
+
+	formatted, err := formatFrontMatter(input)
+	require.NoError(t, err)
+	assert.Equal(t, "---\nid: \"001\"\ntitle: Plain\ndue: \"2024-01-01\"\nestimate: \"3\"\nflag: \"true\"\nnote: 'a: b'\n---\n", formatted)
+
+	var before, after map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(strings.Trim(input, "-\n")), &before))
+	require.NoError(t, yaml.Unmarshal([]byte(strings.Trim(formatted, "-\n")), &after))
+	assert.Equal(t, before, after)
+}
+
+func TestFormatWorkItemFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	canonical := writeTestWorkItem(t, "1_todo", "001", "Tidy", "todo", "task")
+	messy := ".work/1_todo/002-messy.task.md"
+	require.NoError(t, os.WriteFile(messy, []byte("---\ntitle: \"Messy\"\nid: 002\nstatus: todo\nkind: task\ncreated: 2024-01-01\n---\nBody\n"), 0o600))
+
+	changed, err := formatWorkItemFiles([]string{canonical, messy})
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+
+	content, err := os.ReadFile(messy)
+	require.NoError(t, err)
+	assert.Equal(t, "---\nid: 002\ntitle: Messy\nstatus: todo\nkind: task\ncreated: 2024-01-01\n---\nBody\n", string(content))
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"kira/internal/validation"
+)
+
+// frontMatterOrder lists the fields that lead a work item's front matter, in order.
+// Any other fields follow alphabetically.
+var frontMatterOrder = []string{"id", "title", "status", "kind", "created"}
+
+// workItemFields returns all front matter values of a work item keyed by name.
+func workItemFields(item *validation.WorkItem) map[string]interface{} {
+	fields := make(map[string]interface{}, len(item.Fields)+len(frontMatterOrder))
+	for key, value := range item.Fields {
+		fields[key] = value
+	}
+	fields["id"] = item.ID
+	fields["title"] = item.Title
+	fields["status"] = item.Status
+	fields["kind"] = item.Kind
+	fields["created"] = item.Created
+	return fields
+}
+
+// orderedFieldNames returns field names in canonical front matter order.
+func orderedFieldNames(fields map[string]interface{}) []string {
+	var names []string
+	seen := make(map[string]bool, len(frontMatterOrder))
+	for _, name := range frontMatterOrder {
+		seen[name] = true
+		if _, exists := fields[name]; exists {
+			names = append(names, name)
+		}
+	}
+
+	var rest []string
+	for name := range fields {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// renderWorkItem renders front matter fields in canonical order followed by the body.
+func renderWorkItem(fields map[string]interface{}, body string) (string, error) {
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	for _, name := range orderedFieldNames(fields) {
+		valueNode, err := frontMatterNode(fields[name])
+		if err != nil {
+			return "", fmt.Errorf("failed to render field %s: %w", name, err)
+		}
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, valueNode)
+	}
+
+	var sb strings.Builder
+	encoder := yaml.NewEncoder(&sb)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(mapping); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	return "---\n" + sb.String() + "---\n" + body, nil
+}
+
+// frontMatterNode converts a decoded front matter value into a YAML node,
+// keeping scalars unquoted where YAML allows and rendering lists inline.
+func frontMatterNode(value interface{}) (*yaml.Node, error) {
+	switch v := value.(type) {
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: ""}, nil
+	case string, time.Time:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: validation.FieldString(v)}, nil
+	case []interface{}:
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for _, item := range v {
+			itemNode, err := frontMatterNode(item)
+			if err != nil {
+				return nil, err
+			}
+			seq.Content = append(seq.Content, itemNode)
+		}
+		return seq, nil
+	case map[string]interface{}:
+		node := &yaml.Node{}
+		if err := node.Encode(v); err != nil {
+			return nil, err
+		}
+		return node, nil
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(v)}, nil
+	}
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// gitTimeout bounds each git command kira runs on the user's behalf.
+const gitTimeout = 10 * time.Second
+
+// inGitRepo reports whether the working directory is inside a git work tree.
+// It is false when git is not installed.
+func inGitRepo() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+	return exec.CommandContext(ctx, "git", "rev-parse", "--is-inside-work-tree").Run() == nil
+}
+
+// gitAdd stages path, skipping silently outside a git repository.
+func gitAdd(path string) error {
+	if !inGitRepo() {
+		debugf("Not in a git repository; not staging %s", path)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+
+	// #nosec G204 - the path is one kira wrote and is passed after --
+	output, err := exec.CommandContext(ctx, "git", "add", "--", path).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git add %s failed: %s", path, strings.TrimSpace(string(output)))
+	}
+	debugf("Staged %s", path)
+	return nil
+}
+
+// gitTracked reports whether path is tracked in the current git repository.
+func gitTracked(path string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+
+	// #nosec G204 - the path is a work item file and is passed after --
+	return exec.CommandContext(ctx, "git", "ls-files", "--error-unmatch", "--", path).Run() == nil
+}
+
+// gitMove renames src to dst with git mv when src is tracked in a git
+// repository, and with os.Rename otherwise.
+func gitMove(src, dst string) error {
+	if !inGitRepo() || !gitTracked(src) {
+		debugf("%s is not tracked by git; renaming it directly", src)
+		return os.Rename(src, dst)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+
+	// #nosec G204 - both paths are work item files and are passed after --
+	output, err := exec.CommandContext(ctx, "git", "mv", "--", src, dst).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git mv failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// gitChangedFiles returns the files under .work that differ between ref and
+// HEAD, as paths relative to the working directory.
+func gitChangedFiles(ref string) ([]string, error) {
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid git ref '%s'", ref)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+
+	// #nosec G204 - ref cannot be an option, and git resolves it as a revision
+	output, err := exec.CommandContext(ctx, "git", "diff", "--name-only", "--relative", ref, "HEAD", "--", ".work").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git diff against %s failed: %s", ref, strings.TrimSpace(string(output)))
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,102 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/validation"
+)
+
+var grepCmd = &cobra.Command{
+	Use:   "grep <field=value|field!=value>...",
+	Short: "Find work items by front matter values",
+	Long: `Prints the paths of work items whose front matter matches every condition.
+Conditions are field=value or field!=value; list fields such as tags match when
+any element equals the value.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if _, err := loadWorkspaceConfig(); err != nil {
+			return err
+		}
+
+		conditions, err := parseFieldConditions(args)
+		if err != nil {
+			return err
+		}
+
+		return grepWorkItems(os.Stdout, conditions)
+	},
+}
+
+// fieldCondition is a single field=value or field!=value query term.
+type fieldCondition struct {
+	Field  string
+	Value  string
+	Negate bool
+}
+
+func parseFieldConditions(args []string) ([]fieldCondition, error) {
+	conditions := make([]fieldCondition, 0, len(args))
+	for _, arg := range args {
+		var condition fieldCondition
+		if field, value, found := strings.Cut(arg, "!="); found {
+			condition = fieldCondition{Field: field, Value: value, Negate: true}
+		} else if field, value, found := strings.Cut(arg, "="); found {
+			condition = fieldCondition{Field: field, Value: value}
+		} else {
+			return nil, fmt.Errorf("invalid condition '%s': expected field=value or field!=value", arg)
+		}
+
+		condition.Field = strings.TrimSpace(condition.Field)
+		if condition.Field == "" {
+			return nil, fmt.Errorf("invalid condition '%s': field name is empty", arg)
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// matches reports whether item satisfies the condition.
+func (c fieldCondition) matches(item *validation.WorkItem) bool {
+	return fieldHasValue(item, c.Field, c.Value) != c.Negate
+}
+
+// fieldHasValue reports whether the field equals value, or for list fields
+// whether any element equals value.
+func fieldHasValue(item *validation.WorkItem, field, value string) bool {
+	if list, ok := item.Fields[field].([]interface{}); ok {
+		for _, element := range list {
+			if validation.FieldString(element) == value {
+				return true
+			}
+		}
+		return false
+	}
+	return item.Field(field) == value
+}
+
+func grepWorkItems(w io.Writer, conditions []fieldCondition) error {
+	items, err := loadWorkItems(workItemFilter{})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		matched := true
+		for _, condition := range conditions {
+			if !condition.matches(item) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			fmt.Fprintln(w, item.Path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrepWorkItems(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	writeTestWorkItem(t, "1_todo", "001", "Login", "todo", "prd")
+	writeTestWorkItem(t, "1_todo", "002", "Crash", "todo", "issue")
+	tagged := writeTestWorkItem(t, "2_doing", "003", "Signup", "doing", "prd")
+	setRawFrontMatterField(t, tagged, "tags", "[ui, auth]")
+
+	grep := func(t *testing.T, args ...string) []string {
+		t.Helper()
+		conditions, err := parseFieldConditions(args)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, grepWorkItems(&buf, conditions))
+		return strings.Fields(buf.String())
+	}
+
+	t.Run("matches a single field", func(t *testing.T) {
+		assert.Equal(t, []string{
+			".work/1_todo/001-login.prd.md",
+			".work/1_todo/002-crash.issue.md",
+		}, grep(t, "status=todo"))
+	})
+
+	t.Run("requires every condition to match", func(t *testing.T) {
+		assert.Equal(t, []string{".work/1_todo/001-login.prd.md"}, grep(t, "status=todo", "kind=prd"))
+	})
+
+	t.Run("supports negation", func(t *testing.T) {
+		assert.Equal(t, []string{".work/2_doing/003-signup.prd.md"}, grep(t, "kind=prd", "status!=todo"))
+	})
+
+	t.Run("matches an element of a list field", func(t *testing.T) {
+		assert.Equal(t, []string{".work/2_doing/003-signup.prd.md"}, grep(t, "tags=auth"))
+	})
+
+	t.Run("rejects a malformed condition", func(t *testing.T) {
+		_, err := parseFieldConditions([]string{"status"})
+		assert.Error(t, err)
+		_, err = parseFieldConditions([]string{"=todo"})
+		assert.Error(t, err)
+	})
+}
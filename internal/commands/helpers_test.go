@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/config"
+)
+
+// writeTestWorkItem writes a minimal work item into the given status folder and returns its path.
+func writeTestWorkItem(t *testing.T, folder, id, title, status, kind string) string {
+	t.Helper()
+	content := fmt.Sprintf(`---
+id: %s
+title: %s
+status: %s
+kind: %s
+created: 2024-01-01
+---
+
+# %s
+`, id, title, status, kind, title)
+	dir := filepath.Join(".work", folder)
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.%s.md", id, kebabCase(title), kind))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// newTestConfig returns a copy of the default config that can be modified
+// without affecting config.DefaultConfig.
+func newTestConfig() *config.Config {
+	return config.DefaultConfig.Clone()
+}
+
+// setRawFrontMatterField sets key in the front matter of the file at path to
+// raw, which is written as given, for fixtures such as block lists.
+func setRawFrontMatterField(t *testing.T, path, key, raw string) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	updated, err := setFrontMatterYAML(string(content), key, raw)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(updated), 0o600))
+}
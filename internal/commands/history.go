@@ -0,0 +1,52 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"kira/internal/fsutil"
+)
+
+const historyHeading = "## History"
+
+// appendHistoryEntry adds a dated "- YYYY-MM-DD: entry" line to the end of the
+// work item's ## History section, creating the section at the end of the file
+// if it does not exist.
+func appendHistoryEntry(filePath, entry string, now time.Time) error {
+	content, err := safeReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	line := fmt.Sprintf("- %s: %s", now.Format("2006-01-02"), entry)
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+
+	start := -1
+	for i, l := range lines {
+		if strings.TrimSpace(l) == historyHeading {
+			start = i
+			break
+		}
+	}
+
+	if start < 0 {
+		lines = append(lines, "", historyHeading, line)
+		return fsutil.WriteFile(filePath, []byte(strings.Join(lines, "\n")+"\n"), 0o600)
+	}
+
+	// Insert after the last non-blank line of the section.
+	insertAt := start + 1
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "#") {
+			break
+		}
+		if strings.TrimSpace(lines[i]) != "" {
+			insertAt = i + 1
+		}
+	}
+
+	lines = append(lines[:insertAt], append([]string{line}, lines[insertAt:]...)...)
+	return fsutil.WriteFile(filePath, []byte(strings.Join(lines, "\n")+"\n"), 0o600)
+}
@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/validation"
+)
+
+func TestAppendHistoryEntry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("creates the section at the end of the file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := writeTestWorkItem(t, "1_todo", "001", "Track Me", "todo", "task")
+
+		require.NoError(t, appendHistoryEntry(path, "moved todo -> doing", now))
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(string(content), "# Track Me\n\n## History\n- 2025-01-01: moved todo -> doing\n"))
+	})
+
+	t.Run("appends to an existing section followed by another heading", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+		path := ".work/1_todo/001-item.task.md"
+		content := "---\nid: 001\n---\n\n## History\n- 2024-12-31: moved backlog -> todo\n\n## Release Notes\nNotes\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		require.NoError(t, appendHistoryEntry(path, "moved todo -> doing", now))
+
+		updated, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "---\nid: 001\n---\n\n## History\n- 2024-12-31: moved backlog -> todo\n- 2025-01-01: moved todo -> doing\n\n## Release Notes\nNotes\n", string(updated))
+	})
+}
+
+func TestStatusHistoryTracking(t *testing.T) {
+	t.Run("accumulates a line per transition when enabled", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := newTestConfig()
+		cfg.TrackHistory = true
+		writeTestWorkItem(t, "1_todo", "001", "Track Me", "todo", "task")
+		for _, folder := range []string{"2_doing", "4_done"} {
+			require.NoError(t, os.MkdirAll(".work/"+folder, 0o700))
+		}
+
+		require.NoError(t, moveWorkItem(cfg, "001", "doing", moveOptions{}))
+		require.NoError(t, markWorkItemDone(cfg, "001", ""))
+		require.NoError(t, reopenWorkItem(cfg, "001", "doing", ""))
+
+		content, err := os.ReadFile(".work/2_doing/001-track-me.task.md")
+		require.NoError(t, err)
+		today := time.Now().Format("2006-01-02")
+		assert.Contains(t, string(content), "## History\n"+
+			"- "+today+": moved todo -> doing\n"+
+			"- "+today+": moved doing -> done\n"+
+			"- "+today+": moved done -> doing\n")
+	})
+
+	t.Run("leaves the body untouched when disabled", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Quiet", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+
+		require.NoError(t, moveWorkItem(newTestConfig(), "001", "doing", moveOptions{}))
+
+		content, err := os.ReadFile(".work/2_doing/001-quiet.task.md")
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), historyHeading)
+	})
+}
+
+func TestTransitionReason(t *testing.T) {
+	t.Run("adds the reason to the history entry when tracking history", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := newTestConfig()
+		cfg.TrackHistory = true
+		writeTestWorkItem(t, "1_todo", "001", "Blocked", "todo", "task")
+		for _, folder := range []string{"2_doing", "4_done"} {
+			require.NoError(t, os.MkdirAll(".work/"+folder, 0o700))
+		}
+
+		require.NoError(t, moveWorkItem(cfg, "001", "doing", moveOptions{Reason: "picked up in sprint 4"}))
+		require.NoError(t, markWorkItemDone(cfg, "001", "shipped"))
+
+		content, err := os.ReadFile(".work/4_done/001-blocked.task.md")
+		require.NoError(t, err)
+		today := time.Now().Format("2006-01-02")
+		assert.Contains(t, string(content), "## History\n"+
+			"- "+today+": moved todo -> doing (reason: picked up in sprint 4)\n"+
+			"- "+today+": moved doing -> done (reason: shipped)\n")
+		assert.NotContains(t, string(content), "last_transition_reason")
+	})
+
+	t.Run("stores the reason in front matter without history", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := newTestConfig()
+		writeTestWorkItem(t, "1_todo", "001", "Blocked", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/4_done", 0o700))
+
+		require.NoError(t, markWorkItemDone(cfg, "001", "won't fix: duplicate of 002"))
+		require.NoError(t, reopenWorkItem(cfg, "001", "todo", "not a duplicate after all"))
+
+		item, err := validation.ParseWorkItemFile(".work/1_todo/001-blocked.task.md")
+		require.NoError(t, err)
+		assert.Equal(t, "not a duplicate after all", item.Field("last_transition_reason"))
+	})
+
+	t.Run("records nothing without a reason", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Plain", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+
+		require.NoError(t, moveWorkItem(newTestConfig(), "001", "doing", moveOptions{}))
+
+		content, err := os.ReadFile(".work/2_doing/001-plain.task.md")
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "last_transition_reason")
+	})
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+)
+
+const (
+	hookPreCreate  = "pre_create"
+	hookPostCreate = "post_create"
+
+	hookTimeout = time.Minute
+)
+
+// workItemHookEnv describes the work item a hook runs for.
+type workItemHookEnv struct {
+	ID     string
+	Title  string
+	Status string
+	Kind   string
+	Path   string
+}
+
+// runHook runs a configured hook command with sh -c, passing the work item's
+// details as KIRA_* environment variables. An empty command is a no-op.
+func runHook(name, command string, item workItemHookEnv) error {
+	if command == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	debugf("Running %s hook: %s", name, command)
+	// #nosec G204 - hook commands come from the workspace's own kira.yml
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(),
+		"KIRA_HOOK="+name,
+		"KIRA_ID="+item.ID,
+		"KIRA_TITLE="+item.Title,
+		"KIRA_STATUS="+item.Status,
+		"KIRA_KIND="+item.Kind,
+		"KIRA_PATH="+item.Path,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s hook failed: %w", name, err)
+	}
+	return nil
+}
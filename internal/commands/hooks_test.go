@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWorkItemHooks(t *testing.T) {
+	templateContent := "---\ntitle: <!--input-string:title:\"Title\"-->\n---\n"
+
+	t.Run("runs pre and post create hooks with item details", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		script := "#!/bin/sh\n" +
+			`echo "$KIRA_HOOK $KIRA_ID $KIRA_STATUS $KIRA_KIND $KIRA_PATH $KIRA_TITLE" >> hooks.log` + "\n" +
+			`if [ -f "$KIRA_PATH" ]; then echo exists >> hooks.log; fi` + "\n"
+		require.NoError(t, os.WriteFile("hook.sh", []byte(script), 0o600))
+
+		cfg := setupCustomTemplate(t, templateContent)
+		cfg.Hooks.PreCreate = "sh hook.sh"
+		cfg.Hooks.PostCreate = "sh hook.sh"
+
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "Hooked Item"}, false, map[string]string{}, false))
+
+		log, err := os.ReadFile("hooks.log")
+		require.NoError(t, err)
+		assert.Equal(t,
+			"pre_create 001 todo custom .work/1_todo/001-hooked-item.custom.md Hooked Item\n"+
+				"post_create 001 todo custom .work/1_todo/001-hooked-item.custom.md Hooked Item\n"+
+				"exists\n",
+			string(log))
+	})
+
+	t.Run("a failing pre create hook aborts creation", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		cfg.Hooks.PreCreate = "exit 3"
+		cfg.Hooks.PostCreate = "touch post-ran"
+
+		err := createWorkItem(cfg, []string{"custom", "Rejected"}, false, map[string]string{}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pre_create hook failed")
+		assert.NoFileExists(t, ".work/1_todo/001-rejected.custom.md")
+		assert.NoFileExists(t, "post-ran")
+	})
+
+	t.Run("a failing post create hook keeps the item", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		cfg.Hooks.PostCreate = "exit 1"
+
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "Kept"}, false, map[string]string{}, false))
+		assert.FileExists(t, ".work/1_todo/001-kept.custom.md")
+	})
+}
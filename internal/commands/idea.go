@@ -3,11 +3,12 @@ package commands
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"kira/internal/fsutil"
 )
 
 var ideaCmd = &cobra.Command{
@@ -42,10 +43,10 @@ func addIdea(description string) error {
 	newContent := string(content) + newIdea
 
 	// Write back to file
-	if err := os.WriteFile(ideasPath, []byte(newContent), 0o600); err != nil {
+	if err := fsutil.WriteFile(ideasPath, []byte(newContent), 0o600); err != nil {
 		return fmt.Errorf("failed to write IDEAS.md: %w", err)
 	}
 
-	fmt.Printf("Added idea: %s\n", description)
+	infof("Added idea: %s", description)
 	return nil
 }
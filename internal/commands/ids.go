@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// isIDPattern reports whether arg is a glob pattern (e.g. 00*) rather than a
+// literal work item ID.
+func isIDPattern(arg string) bool {
+	return strings.ContainsAny(arg, "*?[")
+}
+
+// expandWorkItemIDs replaces each glob pattern in args with the IDs of every
+// work item it matches, in ID order. Literal IDs are passed through untouched
+// so they keep their existing not-found handling. A pattern matching nothing
+// is an error.
+func expandWorkItemIDs(args []string) ([]string, error) {
+	var ids []string
+	var allIDs []string
+	for _, arg := range args {
+		if !isIDPattern(arg) {
+			ids = append(ids, arg)
+			continue
+		}
+
+		if allIDs == nil {
+			items, err := loadWorkItems(workItemFilter{})
+			if err != nil {
+				return nil, err
+			}
+			allIDs = make([]string, 0, len(items))
+			for _, item := range items {
+				allIDs = append(allIDs, item.ID)
+			}
+		}
+
+		matched := 0
+		for _, id := range allIDs {
+			ok, err := path.Match(arg, id)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ID pattern '%s': %w", arg, err)
+			}
+			if ok {
+				ids = append(ids, id)
+				matched++
+			}
+		}
+		if matched == 0 {
+			return nil, fmt.Errorf("no work items match '%s'", arg)
+		}
+		infof("Pattern '%s' matched %d work items", arg, matched)
+	}
+	return ids, nil
+}
+
+// forEachWorkItemArg calls fn for arg, or for every work item it matches when
+// arg is a glob pattern. verb names the action in messages, as for
+// forEachWorkItem.
+func forEachWorkItemArg(arg, verb string, fn func(workItemID string) error) error {
+	if !isIDPattern(arg) {
+		return fn(arg)
+	}
+
+	workItemIDs, err := expandWorkItemIDs([]string{arg})
+	if err != nil {
+		return err
+	}
+	return forEachWorkItem(workItemIDs, verb, fn)
+}
+
+// forEachWorkItem calls fn for every ID, reporting each failure and carrying on
+// with the rest. verb names the action in messages, e.g. "move".
+func forEachWorkItem(workItemIDs []string, verb string, fn func(workItemID string) error) error {
+	var failed []string
+	for _, workItemID := range workItemIDs {
+		if err := fn(workItemID); err != nil {
+			fmt.Printf("Failed to %s work item %s: %v\n", verb, workItemID, err)
+			failed = append(failed, workItemID)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to %s %d of %d work items: %s", verb, len(failed), len(workItemIDs), strings.Join(failed, ", "))
+	}
+	return nil
+}
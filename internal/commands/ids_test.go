@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/validation"
+)
+
+func TestExpandWorkItemIDs(t *testing.T) {
+	t.Run("expands a glob to every matching ID", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+		writeTestWorkItem(t, "2_doing", "002", "Second", "doing", "task")
+		writeTestWorkItem(t, "1_todo", "010", "Tenth", "todo", "task")
+
+		ids, err := expandWorkItemIDs([]string{"00*"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"001", "002"}, ids)
+
+		ids, err = expandWorkItemIDs([]string{"010", "00?"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"010", "001", "002"}, ids)
+	})
+
+	t.Run("errors when a glob matches nothing", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+
+		_, err := expandWorkItemIDs([]string{"9*"})
+		require.EqualError(t, err, "no work items match '9*'")
+	})
+
+	t.Run("passes literal IDs through unchanged", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		ids, err := expandWorkItemIDs([]string{"999"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"999"}, ids)
+	})
+}
+
+func TestMoveWorkItemsByPattern(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+	writeTestWorkItem(t, "1_todo", "002", "Second", "todo", "task")
+	writeTestWorkItem(t, "1_todo", "010", "Tenth", "todo", "task")
+	require.NoError(t, os.WriteFile("kira.yml", []byte("version: \"1.0\"\n"), 0o600))
+
+	require.NoError(t, moveCmd.RunE(moveCmd, []string{"00*", "doing"}))
+
+	assert.FileExists(t, ".work/2_doing/001-first.task.md")
+	assert.FileExists(t, ".work/2_doing/002-second.task.md")
+	assert.FileExists(t, ".work/1_todo/010-tenth.task.md")
+}
+
+func TestSingleIDCommandsByPattern(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	first := writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+	second := writeTestWorkItem(t, "1_todo", "002", "Second", "todo", "task")
+	tenth := writeTestWorkItem(t, "1_todo", "010", "Tenth", "todo", "task")
+	require.NoError(t, os.WriteFile("kira.yml", []byte("version: \"1.0\"\n"), 0o600))
+
+	require.NoError(t, priorityCmd.RunE(priorityCmd, []string{"00*", "high"}))
+	require.NoError(t, assignCmd.RunE(assignCmd, []string{"00?", "alice"}))
+
+	for _, path := range []string{first, second} {
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "high", item.Fields["priority"], path)
+		assert.Equal(t, "alice", item.Fields["assigned"], path)
+	}
+	item, err := validation.ParseWorkItemFile(tenth)
+	require.NoError(t, err)
+	assert.Nil(t, item.Fields["priority"])
+	assert.Nil(t, item.Fields["assigned"])
+
+	require.EqualError(t, priorityCmd.RunE(priorityCmd, []string{"9*", "high"}), "no work items match '9*'")
+}
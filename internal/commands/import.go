@@ -0,0 +1,249 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+	"kira/internal/fsutil"
+	"kira/internal/validation"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file.json|directory>",
+	Short: "Import work items from a JSON export or a directory of markdown files",
+	Long: `Imports work items from a JSON array (as produced by 'kira export --format json')
+or from a directory of markdown files with front matter. Each item is validated,
+assigned a fresh ID, and written into the folder for its status.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{pathArgsAnnotation: "true"},
+	RunE: func(_ *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		result, err := importWorkItems(cfg, args[0])
+		if err != nil {
+			return err
+		}
+
+		for _, line := range result.Imported {
+			infof("Imported %s", line)
+		}
+		for _, line := range result.Skipped {
+			fmt.Printf("Skipped %s\n", line)
+		}
+		infof("Imported %d work items, skipped %d", len(result.Imported), len(result.Skipped))
+		return nil
+	},
+}
+
+// importRecord is a single work item read from an import source.
+type importRecord struct {
+	Source string
+	Fields map[string]interface{}
+	Body   string
+}
+
+// importResult reports which records were imported and which were skipped.
+type importResult struct {
+	Imported []string
+	Skipped  []string
+}
+
+func importWorkItems(cfg *config.Config, source string) (*importResult, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import source: %w", err)
+	}
+
+	var records []importRecord
+	if info.IsDir() {
+		records, err = readMarkdownImports(source)
+	} else {
+		records, err = readJSONImports(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &importResult{}
+	for _, record := range records {
+		path, err := importRecordFile(cfg, record)
+		if err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: %v", record.Source, err))
+			continue
+		}
+		result.Imported = append(result.Imported, fmt.Sprintf("%s -> %s", record.Source, path))
+	}
+	return result, nil
+}
+
+func readJSONImports(source string) ([]importRecord, error) {
+	// #nosec G304 - import source is an explicit user-provided path that is only read
+	data, err := os.ReadFile(filepath.Clean(source))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	var items []exportedWorkItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse import file: %w", err)
+	}
+
+	records := make([]importRecord, 0, len(items))
+	for i, item := range items {
+		fields := make(map[string]interface{}, len(item.Fields)+len(frontMatterOrder))
+		for key, value := range item.Fields {
+			fields[key] = value
+		}
+		fields["title"] = item.Title
+		fields["status"] = item.Status
+		fields["kind"] = item.Kind
+		fields["created"] = item.Created
+		records = append(records, importRecord{
+			Source: fmt.Sprintf("%s[%d]", source, i),
+			Fields: fields,
+			Body:   item.Body,
+		})
+	}
+	return records, nil
+}
+
+func readMarkdownImports(source string) ([]importRecord, error) {
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	records := make([]importRecord, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(source, name)
+		// #nosec G304 - path is a markdown file inside the user-provided import directory
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		item, err := validation.ParseWorkItemContent(content)
+		if err != nil {
+			records = append(records, importRecord{Source: path})
+			continue
+		}
+		records = append(records, importRecord{Source: path, Fields: workItemFields(item), Body: item.Body})
+	}
+	return records, nil
+}
+
+// importRecordFile validates a record, assigns it a fresh ID, and writes it
+// into its status folder, returning the new file's path.
+func importRecordFile(cfg *config.Config, record importRecord) (string, error) {
+	if record.Fields == nil {
+		return "", fmt.Errorf("no parseable front matter")
+	}
+
+	title := validation.FieldString(record.Fields["title"])
+	kind := validation.FieldString(record.Fields["kind"])
+	status := validation.FieldString(record.Fields["status"])
+	if title == "" {
+		return "", fmt.Errorf("missing required field: title")
+	}
+	if kind == "" {
+		return "", fmt.Errorf("missing required field: kind")
+	}
+	if _, exists := cfg.Templates[kind]; !exists {
+		return "", fmt.Errorf("unknown kind '%s': not a configured template", kind)
+	}
+	if status == "" {
+		status = cfg.DefaultStatus
+	}
+	statusFolder, err := config.FolderForStatus(cfg, status)
+	if err != nil {
+		return "", fmt.Errorf("invalid status '%s'", status)
+	}
+
+	record.Fields["status"] = status
+	if validation.FieldString(record.Fields["created"]) == "" {
+		record.Fields["created"] = cfg.FormatTimestamp(time.Now())
+	}
+
+	folderPath := filepath.Join(".work", statusFolder)
+	if err := os.MkdirAll(folderPath, cfg.DirPerm()); err != nil {
+		return "", fmt.Errorf("failed to create status folder: %w", err)
+	}
+
+	// As for kira new, the lock keeps a concurrent kira from taking the same
+	// ID, and a file that appears anyway moves the record on to the next ID.
+	unlock, err := lockWorkspace()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	for attempt := 1; ; attempt++ {
+		nextID, err := nextWorkItemID(cfg.IDPrefixPerKind[kind])
+		if err != nil {
+			return "", fmt.Errorf("failed to get next ID: %w", err)
+		}
+
+		path, err := writeImportedWorkItem(cfg, record, folderPath, nextID, title, kind, status)
+		if !errors.Is(err, errWorkItemExists) {
+			return path, err
+		}
+		if attempt == maxIDAttempts {
+			return "", fmt.Errorf("no free ID after %d attempts: %w", maxIDAttempts, err)
+		}
+		debugf("ID %s was taken while importing %s; retrying", nextID, record.Source)
+	}
+}
+
+// writeImportedWorkItem renders record with id, checks it as kira lint would,
+// and writes it into folderPath, returning the new file's path.
+func writeImportedWorkItem(cfg *config.Config, record importRecord, folderPath, id, title, kind, status string) (string, error) {
+	record.Fields["id"] = id
+	content, err := renderWorkItem(record.Fields, record.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if result := validation.ValidateWorkItemContent(cfg, record.Source, []byte(content)); result.HasErrors() {
+		messages := make([]string, len(result.Errors))
+		for i, verr := range result.Errors {
+			messages[i] = verr.Message
+		}
+		return "", errors.New(strings.Join(messages, "; "))
+	}
+
+	filename, err := workItemFilename(cfg.FilenameFormat, id, title, kind, status, createdDate(validation.FieldString(record.Fields["created"])))
+	if err != nil {
+		return "", err
+	}
+	filePath := filepath.Join(folderPath, filename)
+	if filepath.Dir(filePath) != filepath.Clean(folderPath) {
+		return "", fmt.Errorf("filename '%s' would be written outside %s", filename, folderPath)
+	}
+	if err := reserveWorkItemPath(filePath, cfg.FilePerm()); err != nil {
+		return "", err
+	}
+	if err := fsutil.WriteFile(filePath, []byte(content), cfg.FilePerm()); err != nil {
+		return "", fmt.Errorf("failed to write work item file: %w", err)
+	}
+	return filePath, nil
+}
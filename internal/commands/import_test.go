@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/config"
+	"kira/internal/validation"
+)
+
+func TestImportWorkItems(t *testing.T) {
+	t.Run("imports a JSON batch and skips invalid items", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+		writeTestWorkItem(t, "1_todo", "001", "Existing", "todo", "task")
+
+		batch := `[
+  {"id": "042", "title": "Imported Feature", "status": "todo", "kind": "prd", "created": "2023-05-01",
+   "fields": {"tags": ["api", "ui"], "due": "2023-06-01"}, "body": "\n# Imported Feature\n"},
+  {"id": "043", "title": "", "status": "todo", "kind": "prd", "body": ""},
+  {"id": "044", "title": "Bad Status", "status": "nowhere", "kind": "task", "body": ""}
+]`
+		require.NoError(t, os.WriteFile("batch.json", []byte(batch), 0o600))
+
+		result, err := importWorkItems(&config.DefaultConfig, "batch.json")
+		require.NoError(t, err)
+		assert.Len(t, result.Imported, 1)
+		require.Len(t, result.Skipped, 2)
+		assert.Contains(t, result.Skipped[0], "missing required field: title")
+		assert.Contains(t, result.Skipped[1], "invalid status 'nowhere'")
+
+		path := ".work/1_todo/002-imported-feature.prd.md"
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "002", item.ID)
+		assert.Equal(t, "2023-05-01", item.Created)
+		assert.Equal(t, "2023-06-01", item.Field("due"))
+		assert.Equal(t, "api, ui", item.Field("tags"))
+		assert.Contains(t, item.Body, "# Imported Feature")
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "tags: [api, ui]")
+	})
+
+	t.Run("imports a directory of markdown files", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/0_backlog", 0o700))
+		require.NoError(t, os.MkdirAll("external", 0o700))
+		valid := `---
+id: ABC-9
+title: From Elsewhere
+kind: issue
+---
+
+Body text
+`
+		require.NoError(t, os.WriteFile(filepath.Join("external", "a.md"), []byte(valid), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join("external", "b.md"), []byte("no front matter here\n"), 0o600))
+
+		result, err := importWorkItems(&config.DefaultConfig, "external")
+		require.NoError(t, err)
+		assert.Len(t, result.Imported, 1)
+		assert.Len(t, result.Skipped, 1)
+
+		item, err := validation.ParseWorkItemFile(".work/0_backlog/001-from-elsewhere.issue.md")
+		require.NoError(t, err)
+		assert.Equal(t, "001", item.ID)
+		assert.Equal(t, "backlog", item.Status)
+		assert.NotEmpty(t, item.Created)
+		assert.Contains(t, item.Body, "Body text")
+	})
+
+	t.Run("rejects unknown kinds and follows filename_format", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+		batch := `[
+  {"title": "Escape", "status": "todo", "kind": "../../x", "body": ""},
+  {"title": "Not A Kind", "status": "todo", "kind": "epic", "body": ""},
+  {"title": "Formatted", "status": "todo", "kind": "task", "created": "2023-05-01", "body": ""}
+]`
+		require.NoError(t, os.WriteFile("batch.json", []byte(batch), 0o600))
+
+		cfg := newTestConfig()
+		cfg.FilenameFormat = "{date}-{id}-{slug}.md"
+		result, err := importWorkItems(cfg, "batch.json")
+		require.NoError(t, err)
+		assert.Len(t, result.Imported, 1)
+		require.Len(t, result.Skipped, 2)
+		assert.Contains(t, result.Skipped[0], "unknown kind '../../x'")
+		assert.Contains(t, result.Skipped[1], "unknown kind 'epic'")
+
+		assert.FileExists(t, ".work/1_todo/2023-05-01-001-formatted.md")
+		assert.NoFileExists(t, filepath.Join(tmpDir, "x.md"))
+	})
+	t.Run("skips records that fail lint checks", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+		batch := `[
+  {"title": "Bad Due", "status": "todo", "kind": "task", "fields": {"due": "next week"}, "body": ""},
+  {"title": "Bad Created", "status": "todo", "kind": "task", "created": "yesterday", "body": ""}
+]`
+		require.NoError(t, os.WriteFile("batch.json", []byte(batch), 0o600))
+
+		result, err := importWorkItems(newTestConfig(), "batch.json")
+		require.NoError(t, err)
+		assert.Empty(t, result.Imported)
+		require.Len(t, result.Skipped, 2)
+		assert.Contains(t, result.Skipped[0], "invalid due date format: next week")
+		assert.Contains(t, result.Skipped[1], "invalid created date format: yesterday")
+
+		entries, err := os.ReadDir(".work/1_todo")
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("takes the next ID when the file appears concurrently", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+		require.NoError(t, os.WriteFile(".work/1_todo/001-racy.task.md", []byte("taken"), 0o600))
+		ids := []string{"001", "002"}
+		calls := 0
+		original := nextWorkItemID
+		nextWorkItemID = func(string) (string, error) {
+			id := ids[min(calls, len(ids)-1)]
+			calls++
+			return id, nil
+		}
+		t.Cleanup(func() { nextWorkItemID = original })
+
+		batch := `[{"title": "Racy", "status": "todo", "kind": "task", "created": "2023-05-01", "body": ""}]`
+		require.NoError(t, os.WriteFile("batch.json", []byte(batch), 0o600))
+
+		result, err := importWorkItems(newTestConfig(), "batch.json")
+		require.NoError(t, err)
+		require.Len(t, result.Imported, 1)
+		item, err := validation.ParseWorkItemFile(".work/1_todo/002-racy.task.md")
+		require.NoError(t, err)
+		assert.Equal(t, "002", item.ID)
+		assert.NoFileExists(t, workspaceLockPath)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"kira/internal/config"
+	"kira/internal/fsutil"
 	"kira/internal/templates"
 
 	"github.com/spf13/cobra"
@@ -41,29 +42,32 @@ func init() {
 }
 
 func initializeWorkspace(targetDir string) error {
+	// init runs before a kira.yml exists, so it creates everything with the
+	// default modes.
+	cfg := &config.DefaultConfig
+
 	// Create .work directory
 	workDir := filepath.Join(targetDir, ".work")
-	if err := os.MkdirAll(workDir, 0o700); err != nil {
+	if err := os.MkdirAll(workDir, cfg.DirPerm()); err != nil {
 		return fmt.Errorf("failed to create .work directory: %w", err)
 	}
 
 	// Create status folders and .gitkeep files
-	cfg := &config.DefaultConfig
 	for _, folder := range cfg.StatusFolders {
 		folderPath := filepath.Join(workDir, folder)
-		if err := os.MkdirAll(folderPath, 0o700); err != nil {
+		if err := os.MkdirAll(folderPath, cfg.DirPerm()); err != nil {
 			return fmt.Errorf("failed to create folder %s: %w", folder, err)
 		}
-		if err := os.WriteFile(filepath.Join(folderPath, ".gitkeep"), []byte(""), 0o600); err != nil {
+		if err := os.WriteFile(filepath.Join(folderPath, ".gitkeep"), []byte(""), cfg.FilePerm()); err != nil {
 			return fmt.Errorf("failed to create .gitkeep in %s: %w", folder, err)
 		}
 	}
 
 	// Create templates directory and default templates and .gitkeep
-	if err := templates.CreateDefaultTemplates(workDir); err != nil {
+	if err := templates.CreateDefaultTemplates(workDir, cfg.FilePerm(), cfg.DirPerm()); err != nil {
 		return fmt.Errorf("failed to create default templates: %w", err)
 	}
-	if err := os.WriteFile(filepath.Join(workDir, "templates", ".gitkeep"), []byte(""), 0o600); err != nil {
+	if err := os.WriteFile(filepath.Join(workDir, "templates", ".gitkeep"), []byte(""), cfg.FilePerm()); err != nil {
 		return fmt.Errorf("failed to create .gitkeep in templates: %w", err)
 	}
 
@@ -81,7 +85,7 @@ This file is for capturing quick ideas and thoughts that don't fit into formal w
 
 `
 	if _, err := os.Stat(ideasPath); os.IsNotExist(err) {
-		if err := os.WriteFile(ideasPath, []byte(header), 0o600); err != nil {
+		if err := fsutil.WriteFile(ideasPath, []byte(header), cfg.FilePerm()); err != nil {
 			return fmt.Errorf("failed to create IDEAS.md: %w", err)
 		}
 	} else {
@@ -91,7 +95,7 @@ This file is for capturing quick ideas and thoughts that don't fit into formal w
 		}
 		if !strings.HasPrefix(string(content), "# Ideas") {
 			newContent := header + string(content)
-			if err := os.WriteFile(ideasPath, []byte(newContent), 0o600); err != nil {
+			if err := fsutil.WriteFile(ideasPath, []byte(newContent), cfg.FilePerm()); err != nil {
 				return fmt.Errorf("failed to update IDEAS.md: %w", err)
 			}
 		}
@@ -102,7 +106,7 @@ This file is for capturing quick ideas and thoughts that don't fit into formal w
 		return fmt.Errorf("failed to create kira.yml: %w", err)
 	}
 
-	fmt.Printf("Initialized kira workspace in %s\n", targetDir)
+	infof("Initialized kira workspace in %s", targetDir)
 	return nil
 }
 
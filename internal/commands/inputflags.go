@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// inputKeyStart matches the start of another key=value pair after a comma.
+var inputKeyStart = regexp.MustCompile(`^\s*[\w.-]+=`)
+
+// parseInputFlags turns --input values into input values. Each value holds one
+// or more comma-separated key=value pairs. A value runs from the first '=' up to
+// a comma followed by another key=, so values may contain '=' and commas that
+// do not start a new pair. A value wrapped in double quotes, or a character
+// after a backslash, is taken literally.
+func parseInputFlags(flags []string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, flag := range flags {
+		if err := parseInputFlag(flag, values); err != nil {
+			return nil, fmt.Errorf("invalid --input '%s': %w", flag, err)
+		}
+	}
+	return values, nil
+}
+
+func parseInputFlag(flag string, values map[string]string) error {
+	rest := flag
+	for {
+		key, after, found := strings.Cut(rest, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return fmt.Errorf("expected key=value")
+		}
+
+		value, remaining, err := scanInputValue(after)
+		if err != nil {
+			return fmt.Errorf("value for %s: %w", key, err)
+		}
+		values[key] = value
+
+		if remaining == "" {
+			return nil
+		}
+		rest = remaining
+	}
+}
+
+// scanInputValue reads one value from the start of s and returns it with the
+// text of any following pairs.
+func scanInputValue(s string) (value, remaining string, err error) {
+	if strings.HasPrefix(s, `"`) {
+		return scanQuotedInputValue(s[1:])
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			} else {
+				b.WriteByte(c)
+			}
+		case ',':
+			if inputKeyStart.MatchString(s[i+1:]) {
+				return b.String(), s[i+1:], nil
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), "", nil
+}
+
+// scanQuotedInputValue reads a value up to its closing quote, which must end
+// the flag or be followed by a comma and another pair.
+func scanQuotedInputValue(s string) (value, remaining string, err error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			} else {
+				b.WriteByte(c)
+			}
+		case '"':
+			rest := s[i+1:]
+			switch {
+			case rest == "":
+				return b.String(), "", nil
+			case strings.HasPrefix(rest, ","):
+				return b.String(), rest[1:], nil
+			default:
+				return "", "", fmt.Errorf("unexpected text after closing quote")
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", "", fmt.Errorf("missing closing quote")
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"kira/internal/config"
+	"kira/internal/fsutil"
+)
+
+// inputHistoryPath stores the last value entered for each template input when
+// remember_inputs is enabled.
+var inputHistoryPath = filepath.Join(".work", ".kira-history")
+
+// loadInputHistory returns the remembered input values, or an empty map when
+// nothing has been remembered yet.
+func loadInputHistory() (map[string]string, error) {
+	history := make(map[string]string)
+
+	content, err := safeReadFile(inputHistoryPath)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input history: %w", err)
+	}
+
+	if err := yaml.Unmarshal(content, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse input history %s: %w", inputHistoryPath, err)
+	}
+	if history == nil {
+		history = make(map[string]string)
+	}
+	return history, nil
+}
+
+// saveInputHistory records values as the latest for their inputs, keeping
+// remembered values for other inputs.
+func saveInputHistory(cfg *config.Config, values map[string]string) error {
+	history, err := loadInputHistory()
+	if err != nil {
+		return err
+	}
+	for name, value := range values {
+		history[name] = value
+	}
+
+	data, err := yaml.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to encode input history: %w", err)
+	}
+	if err := fsutil.WriteFile(inputHistoryPath, data, cfg.FilePerm()); err != nil {
+		return fmt.Errorf("failed to write input history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"kira/internal/config"
+	"kira/internal/templates"
+	"kira/internal/validation"
+)
+
+// workItemFilter narrows a set of work items by front matter values.
+// Empty fields match everything.
+type workItemFilter struct {
+	Status   string
+	Kind     string
+	Assignee string
+	// Due and Created bound the due and created dates, exclusive at both
+	// ends. Items without a parseable date do not match a set bound.
+	Due     dateRange
+	Created dateRange
+	// ExcludeStatuses leaves out items in any of these statuses.
+	ExcludeStatuses []string
+	// Paths, when not nil, keeps only the items whose file is in the set.
+	Paths map[string]bool
+	// Where is a --where expression the item must satisfy.
+	Where whereExpr
+}
+
+func (f workItemFilter) matches(item *validation.WorkItem) bool {
+	if f.Status != "" && item.Status != f.Status {
+		return false
+	}
+	if f.Kind != "" && item.Kind != f.Kind {
+		return false
+	}
+	if f.Assignee != "" && !strings.EqualFold(workItemAssignee(item), f.Assignee) {
+		return false
+	}
+	for _, status := range f.ExcludeStatuses {
+		if item.Status == status {
+			return false
+		}
+	}
+	if f.Paths != nil && !f.Paths[filepath.Clean(item.Path)] {
+		return false
+	}
+	if !f.Where.matches(item) {
+		return false
+	}
+	return f.Due.contains(item.Field("due")) && f.Created.contains(item.Created)
+}
+
+// dateRange is an open interval of dates; a zero bound is unbounded.
+type dateRange struct {
+	After  time.Time
+	Before time.Time
+}
+
+// contains reports whether the date in value falls within the range. Any
+// value matches an unbounded range.
+func (r dateRange) contains(value string) bool {
+	if r.After.IsZero() && r.Before.IsZero() {
+		return true
+	}
+	date, err := parseLenientDate(value)
+	if err != nil {
+		return false
+	}
+	if !r.After.IsZero() && !date.After(r.After) {
+		return false
+	}
+	if !r.Before.IsZero() && !date.Before(r.Before) {
+		return false
+	}
+	return true
+}
+
+// lenientDateLayouts are the absolute date forms parseLenientDate accepts.
+var lenientDateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"2006-1-2",
+	"2006/1/2",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+}
+
+// parseLenientDate reads a date written in any common form, or a relative
+// date such as today or +7d, returning midnight UTC on that day.
+func parseLenientDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if date, ok := templates.ParseRelativeDate(value, time.Now()); ok {
+		return dateOnly(date), nil
+	}
+	for _, layout := range lenientDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return dateOnly(date), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date '%s'", value)
+}
+
+// dateOnly drops the time of day from t.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// createdTime parses an item's created field, written as a date or as a full
+// timestamp. An unparseable or missing value gives the zero time, which sorts
+// first.
+func createdTime(item *validation.WorkItem) time.Time {
+	created, err := validation.ParseTimestamp(item.Created)
+	if err != nil {
+		return time.Time{}
+	}
+	return created
+}
+
+// assignedField is the front matter field holding a work item's owner. The
+// default templates prompt for it, and assign and list --assignee use it.
+const assignedField = "assigned"
+
+// workItemAssignee returns the item's assignee.
+func workItemAssignee(item *validation.WorkItem) string {
+	return item.Field(assignedField)
+}
+
+// loadWorkItems returns the workspace's work items that match filter, ordered by ID.
+func loadWorkItems(filter workItemFilter) ([]*validation.WorkItem, error) {
+	items, err := validation.LoadWorkItems()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*validation.WorkItem
+	for _, item := range items {
+		if filter.matches(item) {
+			matched = append(matched, item)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return compareIDs(matched[i].ID, matched[j].ID) < 0
+	})
+	return matched, nil
+}
+
+// compareIDs orders work item IDs by prefix, then by the value of their
+// trailing number, so 2 sorts before 10 and PRD-2 before PRD-10. Unprefixed
+// IDs come before prefixed ones. IDs equal in both are ordered as text, which
+// puts 001 before 1.
+func compareIDs(a, b string) int {
+	prefixA, numberA := splitID(a)
+	prefixB, numberB := splitID(b)
+	if c := strings.Compare(prefixA, prefixB); c != 0 {
+		return c
+	}
+	if c := compareDigits(numberA, numberB); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// splitID splits an ID into everything before its trailing digits and the
+// digits themselves.
+func splitID(id string) (prefix, number string) {
+	end := len(id)
+	for end > 0 && id[end-1] >= '0' && id[end-1] <= '9' {
+		end--
+	}
+	return id[:end], id[end:]
+}
+
+// compareDigits compares two strings of decimal digits by value, without
+// limiting their length. An empty string sorts before any number.
+func compareDigits(a, b string) int {
+	if a == "" || b == "" {
+		return strings.Compare(a, b)
+	}
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// finishedStatuses returns the done status and every status after it in the
+// workflow order. Items in these statuses are no longer overdue.
+func finishedStatuses(cfg *config.Config) []string {
+	ordered := config.OrderedStatuses(cfg)
+	for i, status := range ordered {
+		if status == cfg.DoneStatus {
+			return ordered[i:]
+		}
+	}
+	return []string{cfg.DoneStatus}
+}
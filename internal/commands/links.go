@@ -0,0 +1,215 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/validation"
+)
+
+var checkLinksCmd = &cobra.Command{
+	Use:   "check-links",
+	Short: "Report dead links in work items",
+	Long: `Finds the http and https links in work item bodies, written as [text](url) or
+<url>, and requests each one, reporting those that fail or answer with a
+non-2xx status under the items that contain them. Links in fenced code blocks
+are skipped. With --offline the links are counted but not requested.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if _, err := loadWorkspaceConfig(); err != nil {
+			return err
+		}
+
+		var opts linkCheckOptions
+		opts.Timeout, _ = cmd.Flags().GetDuration("timeout")
+		opts.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+		opts.Offline, _ = cmd.Flags().GetBool("offline")
+		return checkLinks(os.Stdout, opts)
+	},
+}
+
+func init() {
+	checkLinksCmd.Flags().Duration("timeout", 10*time.Second, "Time to wait for each link to respond")
+	checkLinksCmd.Flags().Int("concurrency", 8, "Number of links to check at once")
+	checkLinksCmd.Flags().Bool("offline", false, "Only count the links found; do not request them")
+}
+
+// linkCheckOptions controls how check-links requests links.
+type linkCheckOptions struct {
+	Timeout     time.Duration
+	Concurrency int
+	Offline     bool
+}
+
+var (
+	// externalLinkPattern matches [text](url) and [text](url "title") links.
+	externalLinkPattern = regexp.MustCompile(`\[[^\]]*\]\((https?://[^)\s]+)(?:\s+"[^"]*")?\)`)
+	// autolinkPattern matches <url> autolinks.
+	autolinkPattern = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+)
+
+// extractLinks returns the distinct http and https links in a markdown body,
+// in order of first appearance, skipping fenced code blocks.
+func extractLinks(body string) []string {
+	var links []string
+	seen := make(map[string]bool)
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		for _, pattern := range []*regexp.Regexp{externalLinkPattern, autolinkPattern} {
+			for _, match := range pattern.FindAllStringSubmatch(line, -1) {
+				if !seen[match[1]] {
+					seen[match[1]] = true
+					links = append(links, match[1])
+				}
+			}
+		}
+	}
+	return links
+}
+
+// checkLinks checks every link in every work item and writes the broken ones
+// to w, returning an error when any are found.
+func checkLinks(w io.Writer, opts linkCheckOptions) error {
+	if opts.Concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	items, err := loadWorkItems(workItemFilter{})
+	if err != nil {
+		return err
+	}
+
+	itemLinks := make(map[string][]string, len(items))
+	var urls []string
+	seen := make(map[string]bool)
+	for _, item := range items {
+		itemLinks[item.Path] = extractLinks(item.Body)
+		for _, link := range itemLinks[item.Path] {
+			if !seen[link] {
+				seen[link] = true
+				urls = append(urls, link)
+			}
+		}
+	}
+
+	if opts.Offline {
+		infof("Offline: found %d links; not checking them", len(urls))
+		return nil
+	}
+
+	failures := checkURLs(urls, opts)
+	broken := 0
+	for _, item := range items {
+		if err := writeBrokenLinks(w, item, itemLinks[item.Path], failures, &broken); err != nil {
+			return err
+		}
+	}
+
+	if broken > 0 {
+		return fmt.Errorf("found %d broken links", broken)
+	}
+	infof("Checked %d links; all are reachable", len(urls))
+	return nil
+}
+
+// writeBrokenLinks writes an item's failing links under a header line,
+// counting each one in broken.
+func writeBrokenLinks(w io.Writer, item *validation.WorkItem, links []string, failures map[string]string, broken *int) error {
+	headerWritten := false
+	for _, link := range links {
+		reason, failed := failures[link]
+		if !failed {
+			continue
+		}
+		if !headerWritten {
+			if _, err := fmt.Fprintf(w, "%s %s\n", item.ID, item.Title); err != nil {
+				return err
+			}
+			headerWritten = true
+		}
+		if _, err := fmt.Fprintf(w, "  %s  %s\n", link, reason); err != nil {
+			return err
+		}
+		*broken++
+	}
+	return nil
+}
+
+// checkURLs requests each URL with up to opts.Concurrency requests at a time,
+// returning the reason each failing URL failed.
+func checkURLs(urls []string, opts linkCheckOptions) map[string]string {
+	client := &http.Client{Timeout: opts.Timeout}
+	failures := make(map[string]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	queue := make(chan string)
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range queue {
+				if reason := checkURL(client, url); reason != "" {
+					mu.Lock()
+					failures[url] = reason
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, url := range urls {
+		queue <- url
+	}
+	close(queue)
+	wg.Wait()
+	return failures
+}
+
+// checkURL sends a HEAD request to url, retrying with GET for servers that do
+// not support HEAD. It returns why the link is broken, or "" when it is not.
+func checkURL(client *http.Client, url string) string {
+	status, err := requestStatus(client, http.MethodHead, url)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = requestStatus(client, http.MethodGet, url)
+	}
+	if err != nil {
+		return fmt.Sprintf("unreachable: %v", err)
+	}
+	if status < 200 || status > 299 {
+		return fmt.Sprintf("%d %s", status, http.StatusText(status))
+	}
+	debugf("%s: %d", url, status)
+	return ""
+}
+
+func requestStatus(client *http.Client, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(context.Background(), method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "kira check-links")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
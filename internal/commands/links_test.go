@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractLinks(t *testing.T) {
+	body := "See [docs](https://example.com/docs \"Docs\") and <http://example.com/a>.\n" +
+		"Again [docs](https://example.com/docs), a [relative](./notes.md) link, and [mail](mailto:a@b.c).\n" +
+		"```\n[skipped](https://example.com/code)\n```\n"
+	assert.Equal(t, []string{"https://example.com/docs", "http://example.com/a"}, extractLinks(body))
+}
+
+func TestCheckLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/get-only":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closedURL := closed.URL
+	closed.Close()
+
+	setup := func(t *testing.T, bodies map[string]string) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+		for id, body := range bodies {
+			path := writeTestWorkItem(t, "1_todo", id, "Item "+id, "todo", "task")
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(path, append(content, []byte(body)...), 0o600))
+		}
+	}
+	opts := linkCheckOptions{Timeout: 5 * time.Second, Concurrency: 2}
+
+	t.Run("reports broken links per item", func(t *testing.T) {
+		setup(t, map[string]string{
+			"001": "[ok](" + server.URL + "/ok) [head](" + server.URL + "/get-only)\n",
+			"002": "[gone](" + server.URL + "/gone) <" + closedURL + "/down>\n",
+		})
+
+		var buf bytes.Buffer
+		err := checkLinks(&buf, opts)
+		assert.EqualError(t, err, "found 2 broken links")
+
+		output := buf.String()
+		assert.Contains(t, output, "002 Item 002\n  "+server.URL+"/gone  404 Not Found\n  "+closedURL+"/down  unreachable: ")
+		assert.NotContains(t, output, "001")
+	})
+
+	t.Run("passes when every link is reachable", func(t *testing.T) {
+		setup(t, map[string]string{"001": "[ok](" + server.URL + "/ok)\n"})
+
+		var buf bytes.Buffer
+		require.NoError(t, checkLinks(&buf, opts))
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("offline skips the requests", func(t *testing.T) {
+		setup(t, map[string]string{"001": "[gone](" + server.URL + "/gone)\n"})
+
+		var buf bytes.Buffer
+		require.NoError(t, checkLinks(&buf, linkCheckOptions{Concurrency: 1, Offline: true}))
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("rejects a concurrency below one", func(t *testing.T) {
+		setup(t, nil)
+
+		assert.EqualError(t, checkLinks(&bytes.Buffer{}, linkCheckOptions{}), "--concurrency must be at least 1")
+	})
+}
@@ -3,35 +3,76 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"kira/internal/config"
+	"kira/internal/templates"
 	"kira/internal/validation"
 )
 
 var lintCmd = &cobra.Command{
 	Use:   "lint",
 	Short: "Check for issues in work items",
-	Long:  `Scans folders and files to check for issues and reports any found.`,
-	RunE: func(_ *cobra.Command, _ []string) error {
-		if err := checkWorkDir(); err != nil {
-			return err
-		}
+	Long: `Scans folders and files to check for issues and reports any found.
 
-		cfg, err := config.LoadConfig()
+With --fix, issues that can be corrected mechanically are fixed in place first:
+status is synced to the item's folder, id to its filename, and a missing created
+date is set from the file's modification time. Anything else is still reported.
+--diff shows those changes as a unified diff; without --fix nothing is written.
+--count-only prints just "errors: N, warnings: M" for dashboards and scripts.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := loadWorkspaceConfig()
 		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+			return err
 		}
 
-		return lintWorkItems(cfg)
+		opts := lintOptions{}
+		opts.Fix, _ = cmd.Flags().GetBool("fix")
+		opts.Diff, _ = cmd.Flags().GetBool("diff")
+		opts.CountOnly, _ = cmd.Flags().GetBool("count-only")
+		return lintWorkItems(cfg, opts)
 	},
 }
 
-func lintWorkItems(cfg *config.Config) error {
-	result, err := validation.ValidateWorkItems(cfg)
+func init() {
+	lintCmd.Flags().Bool("fix", false, "Fix status/folder and id/filename mismatches and missing created dates in place")
+	lintCmd.Flags().Bool("diff", false, "Show the changes --fix would make as a unified diff; combine with --fix to apply them")
+	lintCmd.Flags().Bool("count-only", false, "Print only \"errors: N, warnings: M\" instead of each issue; still exits non-zero on errors")
+}
+
+// lintOptions controls how lint handles the issues it can fix.
+type lintOptions struct {
+	// Fix writes the safe fixes before validating.
+	Fix bool
+	// Diff prints the safe fixes as a unified diff.
+	Diff bool
+	// CountOnly prints only the number of issues instead of each one.
+	CountOnly bool
+}
+
+func lintWorkItems(cfg *config.Config, opts lintOptions) error {
+	if opts.Fix || opts.Diff {
+		if err := fixWorkItems(cfg, opts); err != nil {
+			return err
+		}
+	}
+
+	result, err := collectLintResult(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to validate work items: %w", err)
+		return err
+	}
+
+	if opts.CountOnly {
+		if err := writeLintCounts(os.Stdout, result); err != nil {
+			return err
+		}
+		if result.HasErrors() {
+			return fmt.Errorf("validation failed")
+		}
+		return nil
 	}
 
 	if result.HasErrors() {
@@ -42,6 +83,94 @@ func lintWorkItems(cfg *config.Config) error {
 		return fmt.Errorf("validation failed")
 	}
 
-	fmt.Println("No issues found. All work items are valid.")
+	infof("No issues found. All work items are valid.")
+	return nil
+}
+
+// collectLintResult runs every lint check and gathers the issues found.
+func collectLintResult(cfg *config.Config) (*validation.ValidationResult, error) {
+	result, err := validation.ValidateWorkItems(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate work items: %w", err)
+	}
+	if err := validateTemplateSchemas(cfg, result); err != nil {
+		return nil, err
+	}
+	if err := validateWorkItemLocations(cfg, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// writeLintCounts writes the number of issues found as a single summary line.
+// Lint reports every issue as an error, so the warning count is always zero.
+func writeLintCounts(w io.Writer, result *validation.ValidationResult) error {
+	_, err := fmt.Fprintf(w, "errors: %d, warnings: %d\n", len(result.Errors), 0)
+	return err
+}
+
+// validateTemplateSchemas checks each work item against the schema declared by
+// the template for its kind. Kinds without a template file or schema are skipped.
+func validateTemplateSchemas(cfg *config.Config, result *validation.ValidationResult) error {
+	items, err := validation.LoadWorkItems()
+	if err != nil {
+		return fmt.Errorf("failed to load work items: %w", err)
+	}
+
+	schemas := make(map[string]templates.Schema)
+	for _, item := range items {
+		schema, cached := schemas[item.Kind]
+		if !cached {
+			schema = loadTemplateSchema(cfg, item.Kind, result)
+			schemas[item.Kind] = schema
+		}
+		for _, err := range schema.Validate(workItemFields(item)) {
+			result.AddError(item.Path, fmt.Sprintf("%s (%s schema)", err.Error(), item.Kind))
+		}
+	}
+	return nil
+}
+
+// loadTemplateSchema returns the schema for a kind, recording an error when
+// the template declares an invalid one.
+func loadTemplateSchema(cfg *config.Config, kind string, result *validation.ValidationResult) templates.Schema {
+	if _, exists := cfg.Templates[kind]; !exists {
+		return nil
+	}
+	path := templateFilePath(cfg, kind)
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	schema, err := templates.GetTemplateSchemaWithOptions(path, templateOptions(cfg))
+	if err != nil {
+		result.AddError(path, err.Error())
+		return nil
+	}
+	return schema
+}
+
+// fixWorkItems shows and applies the safe fixes as requested by opts.
+func fixWorkItems(cfg *config.Config, opts lintOptions) error {
+	fixes, err := planWorkItemFixes(cfg)
+	if err != nil {
+		return err
+	}
+
+	if opts.Diff {
+		if err := writeFixDiffs(os.Stdout, fixes, useColor(os.Stdout)); err != nil {
+			return err
+		}
+	}
+	if !opts.Fix {
+		return nil
+	}
+	for _, fix := range fixes {
+		if err := applyWorkItemFix(fix); err != nil {
+			return err
+		}
+		for _, change := range fix.Changes {
+			fmt.Printf("Fixed %s: %s\n", fix.Path, change)
+		}
+	}
 	return nil
 }
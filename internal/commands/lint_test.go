@@ -1,13 +1,17 @@
 package commands
 
 import (
+	"bytes"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"kira/internal/config"
+	"kira/internal/validation"
 )
 
 func TestLintWorkItems(t *testing.T) {
@@ -36,7 +40,7 @@ This is a test feature.
 		require.NoError(t, os.WriteFile(".work/1_todo/001-test-feature.prd.md", []byte(workItemContent), 0o600))
 
 		cfg := &config.DefaultConfig
-		err := lintWorkItems(cfg)
+		err := lintWorkItems(cfg, lintOptions{})
 		require.NoError(t, err)
 	})
 
@@ -62,8 +66,243 @@ created: 2024-01-01
 		require.NoError(t, os.WriteFile(".work/1_todo/001-test-feature.prd.md", []byte(workItemContent), 0o600))
 
 		cfg := &config.DefaultConfig
-		err := lintWorkItems(cfg)
+		err := lintWorkItems(cfg, lintOptions{})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 	})
 }
+
+func TestLintTemplateSchema(t *testing.T) {
+	templateContent := `---
+title: <!--input-string:title:"Title"-->
+---
+<!--schema
+created: date
+estimate: number
+-->
+`
+	writeItem := func(t *testing.T, frontMatter string) {
+		t.Helper()
+		content := "---\nid: 001\ntitle: Schema Item\nstatus: todo\nkind: custom\n" + frontMatter + "---\n\n# Schema Item\n"
+		require.NoError(t, os.WriteFile(".work/1_todo/001-schema-item.custom.md", []byte(content), 0o600))
+	}
+	setup := func(t *testing.T) *config.Config {
+		t.Helper()
+		cfg := setupCustomTemplate(t, templateContent)
+		cfg.Validation = config.DefaultConfig.Validation
+		cfg.StatusFolders = config.DefaultConfig.StatusFolders
+		return cfg
+	}
+
+	t.Run("accepts an item that conforms to its template schema", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setup(t)
+		writeItem(t, "created: 2024-01-01\nestimate: 3\n")
+
+		require.NoError(t, lintWorkItems(cfg, lintOptions{}))
+	})
+
+	t.Run("reports fields that violate the schema", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setup(t)
+		writeItem(t, "created: 2024-01-01\nestimate: soon\n")
+
+		result, err := validation.ValidateWorkItems(cfg)
+		require.NoError(t, err)
+		require.NoError(t, validateTemplateSchemas(cfg, result))
+		require.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "field estimate should be a number, got soon (custom schema)")
+		assert.Error(t, lintWorkItems(cfg, lintOptions{}))
+	})
+}
+
+func TestFilenameID(t *testing.T) {
+	cfg := newTestConfig()
+
+	id, ok := filenameID(cfg, ".work/1_todo/001-fix-login.task.md")
+	assert.True(t, ok)
+	assert.Equal(t, "001", id)
+
+	_, ok = filenameID(cfg, ".work/1_todo/notes.md")
+	assert.False(t, ok)
+
+	cfg.FilenameFormat = "{date}-{id}-{slug}.md"
+	id, ok = filenameID(cfg, ".work/1_todo/2024-01-01-042-fix-login.md")
+	assert.True(t, ok)
+	assert.Equal(t, "042", id)
+}
+
+func TestLintWorkItemsFix(t *testing.T) {
+	writeItem := func(t *testing.T, path, frontMatter string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte("---\n"+frontMatter+"---\n\n# Item\n"), 0o600))
+	}
+
+	t.Run("reports mismatches without --fix", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := ".work/1_todo/001-item.task.md"
+		writeItem(t, path, "id: 002\ntitle: Item\nstatus: doing\nkind: task\ncreated: 2024-01-01\n")
+
+		result := &validation.ValidationResult{}
+		require.NoError(t, validateWorkItemLocations(newTestConfig(), result))
+		require.Len(t, result.Errors, 2)
+		assert.Contains(t, result.Errors[0].Error(), "status 'doing' does not match folder (expected 'todo')")
+		assert.Contains(t, result.Errors[1].Error(), "id '002' does not match filename (expected '001')")
+
+		assert.Error(t, lintWorkItems(newTestConfig(), lintOptions{}))
+	})
+
+	t.Run("syncs status to the folder", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := ".work/1_todo/001-item.task.md"
+		writeItem(t, path, "id: 001\ntitle: Item\nstatus: doing\nkind: task\ncreated: 2024-01-01\n")
+
+		require.NoError(t, lintWorkItems(newTestConfig(), lintOptions{Fix: true}))
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "todo", item.Status)
+	})
+
+	t.Run("syncs id to the filename", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := ".work/1_todo/007-item.task.md"
+		writeItem(t, path, "id: 001\ntitle: Item\nstatus: todo\nkind: task\ncreated: 2024-01-01\n")
+
+		require.NoError(t, lintWorkItems(newTestConfig(), lintOptions{Fix: true}))
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "007", item.ID)
+	})
+
+	t.Run("adds a missing created date", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := ".work/1_todo/001-item.task.md"
+		writeItem(t, path, "id: 001\ntitle: Item\nstatus: todo\nkind: task\n")
+		modTime := time.Date(2024, 3, 5, 12, 0, 0, 0, time.Local)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+
+		require.NoError(t, lintWorkItems(newTestConfig(), lintOptions{Fix: true}))
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "2024-03-05", item.Created)
+	})
+
+	t.Run("leaves issues it cannot fix", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := ".work/1_todo/001-item.task.md"
+		writeItem(t, path, "id: 001\ntitle: Item\nstatus: doing\nkind: task\ncreated: 01/02/2024\n")
+
+		err := lintWorkItems(newTestConfig(), lintOptions{Fix: true})
+		require.Error(t, err)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "status: todo")
+		assert.Contains(t, string(content), "created: 01/02/2024")
+	})
+}
+
+func TestLintWorkItemsDiff(t *testing.T) {
+	setup := func(t *testing.T) (string, string) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		path := ".work/1_todo/001-item.task.md"
+		content := "---\nid: 001\ntitle: Item\nstatus: doing\nkind: task\ncreated: 2024-01-01\n---\n\n# Item\n"
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path, content
+	}
+
+	t.Run("shows the proposed changes as a diff", func(t *testing.T) {
+		path, _ := setup(t)
+
+		fixes, err := planWorkItemFixes(newTestConfig())
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, writeFixDiffs(&buf, fixes, false))
+		assert.Contains(t, buf.String(), "--- a/"+path+"\n+++ b/"+path+"\n")
+		assert.Contains(t, buf.String(), "-status: doing\n+status: todo\n")
+		assert.NotContains(t, buf.String(), "\x1b[")
+	})
+
+	t.Run("colors removed and added lines", func(t *testing.T) {
+		setup(t)
+
+		fixes, err := planWorkItemFixes(newTestConfig())
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, writeFixDiffs(&buf, fixes, true))
+		assert.Contains(t, buf.String(), "\x1b[31m-status: doing\x1b[0m\n\x1b[32m+status: todo\x1b[0m\n")
+	})
+
+	t.Run("does not write without --fix", func(t *testing.T) {
+		path, content := setup(t)
+
+		assert.Error(t, lintWorkItems(newTestConfig(), lintOptions{Diff: true}))
+
+		after, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(after))
+	})
+
+	t.Run("applies the changes with --fix", func(t *testing.T) {
+		path, _ := setup(t)
+
+		require.NoError(t, lintWorkItems(newTestConfig(), lintOptions{Diff: true, Fix: true}))
+
+		after, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(after), "status: todo")
+	})
+}
+
+func TestLintWorkItemsCountOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	writeTestWorkItem(t, "1_todo", "001", "Valid", "todo", "task")
+	writeTestWorkItem(t, "1_todo", "002", "Bad status", "invalid-status", "task")
+	writeTestWorkItem(t, "2_doing", "003", "Wrong folder", "todo", "task")
+
+	result, err := collectLintResult(newTestConfig())
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, writeLintCounts(&buf, result))
+	// 002's status is both invalid and out of step with its folder.
+	assert.Equal(t, "errors: 3, warnings: 0\n", buf.String())
+
+	err = lintWorkItems(newTestConfig(), lintOptions{CountOnly: true})
+	assert.EqualError(t, err, "validation failed")
+
+	require.NoError(t, os.Remove(".work/1_todo/002-bad-status.task.md"))
+	require.NoError(t, os.Remove(".work/2_doing/003-wrong-folder.task.md"))
+	require.NoError(t, lintWorkItems(newTestConfig(), lintOptions{CountOnly: true}))
+}
@@ -0,0 +1,205 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"kira/internal/config"
+	"kira/internal/diffutil"
+	"kira/internal/fsutil"
+	"kira/internal/validation"
+)
+
+// expectedStatus returns the status configured for the folder holding path.
+func expectedStatus(cfg *config.Config, path string) (string, bool) {
+	rel, err := filepath.Rel(".work", filepath.Dir(path))
+	if err != nil {
+		return "", false
+	}
+	return config.StatusForFolder(cfg, rel)
+}
+
+// filenameID extracts the work item ID from a filename written with the
+// configured filename_format, matching the ID against validation.id_format
+// with an optional id_prefix_per_kind prefix.
+// It returns false when the filename does not follow the format.
+func filenameID(cfg *config.Config, path string) (string, bool) {
+	base := filepath.Base(path)
+	start, end, ok := filenameIDSpan(cfg, base)
+	if !ok {
+		return "", false
+	}
+	return base[start:end], true
+}
+
+// filenameIDSpan returns the position of the work item ID within a base
+// filename, as filenameID matches it.
+func filenameIDSpan(cfg *config.Config, base string) (int, int, bool) {
+	format := cfg.FilenameFormat
+	if format == "" {
+		format = config.DefaultFilenameFormat
+	}
+	idPattern := strings.TrimSuffix(strings.TrimPrefix(cfg.Validation.IDFormat, "^"), "$")
+	if len(cfg.IDPrefixPerKind) > 0 {
+		prefixes := make([]string, 0, len(cfg.IDPrefixPerKind))
+		for _, prefix := range cfg.IDPrefixPerKind {
+			prefixes = append(prefixes, regexp.QuoteMeta(prefix+"-"))
+		}
+		idPattern = "(?:" + strings.Join(prefixes, "|") + ")?(?:" + idPattern + ")"
+	}
+
+	pattern := regexp.QuoteMeta(format)
+	pattern = strings.Replace(pattern, regexp.QuoteMeta("{id}"), "(?P<id>(?:"+idPattern+"))", 1)
+	for _, placeholder := range []string{"{id}", "{slug}", "{kind}", "{template}", "{status}", "{date}"} {
+		pattern = strings.ReplaceAll(pattern, regexp.QuoteMeta(placeholder), ".*?")
+	}
+	re, err := regexp.Compile("^" + pattern + "$")
+	if err != nil {
+		return 0, 0, false
+	}
+
+	match := re.FindStringSubmatchIndex(base)
+	if match == nil {
+		return 0, 0, false
+	}
+	group := re.SubexpIndex("id")
+	return match[2*group], match[2*group+1], true
+}
+
+// validateWorkItemLocations records items whose status does not match their
+// folder or whose id does not match their filename.
+func validateWorkItemLocations(cfg *config.Config, result *validation.ValidationResult) error {
+	items, err := validation.LoadWorkItems()
+	if err != nil {
+		return fmt.Errorf("failed to load work items: %w", err)
+	}
+
+	for _, item := range items {
+		if status, ok := expectedStatus(cfg, item.Path); ok && item.Status != "" && item.Status != status {
+			result.AddError(item.Path, fmt.Sprintf("status '%s' does not match folder (expected '%s')", item.Status, status))
+		}
+		if id, ok := filenameID(cfg, item.Path); ok && item.ID != "" && item.ID != id {
+			result.AddError(item.Path, fmt.Sprintf("id '%s' does not match filename (expected '%s')", item.ID, id))
+		}
+	}
+	return nil
+}
+
+// workItemFix is the set of safe fixes lint --fix would make to one file.
+type workItemFix struct {
+	Path    string
+	Before  string
+	After   string
+	Changes []string
+}
+
+// planWorkItemFixes works out the issues lint can fix safely without writing
+// anything. Files that need no changes are left out.
+func planWorkItemFixes(cfg *config.Config) ([]workItemFix, error) {
+	items, err := validation.LoadWorkItems()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load work items: %w", err)
+	}
+
+	var fixes []workItemFix
+	for _, item := range items {
+		fix, err := planWorkItemFix(cfg, item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fix %s: %w", item.Path, err)
+		}
+		if len(fix.Changes) > 0 {
+			fixes = append(fixes, fix)
+		}
+	}
+	return fixes, nil
+}
+
+func planWorkItemFix(cfg *config.Config, item *validation.WorkItem) (workItemFix, error) {
+	content, err := safeReadFile(item.Path)
+	if err != nil {
+		return workItemFix{}, err
+	}
+	fix := workItemFix{Path: item.Path, Before: string(content), After: string(content)}
+
+	set := func(key, value string) error {
+		updated, err := setFrontMatterLine(fix.After, key, value)
+		if err != nil {
+			return err
+		}
+		fix.After = updated
+		fix.Changes = append(fix.Changes, fmt.Sprintf("set %s to %s", key, value))
+		return nil
+	}
+
+	if status, ok := expectedStatus(cfg, item.Path); ok && item.Status != status {
+		if err := set("status", status); err != nil {
+			return fix, err
+		}
+	}
+	if id, ok := filenameID(cfg, item.Path); ok && item.ID != id {
+		if err := set("id", id); err != nil {
+			return fix, err
+		}
+	}
+	if item.Created == "" {
+		info, err := os.Stat(item.Path)
+		if err != nil {
+			return fix, err
+		}
+		if err := set("created", cfg.FormatTimestamp(info.ModTime())); err != nil {
+			return fix, err
+		}
+	}
+	return fix, nil
+}
+
+// applyWorkItemFix writes a planned fix.
+func applyWorkItemFix(fix workItemFix) error {
+	if err := fsutil.WriteFile(fix.Path, []byte(fix.After), 0o600); err != nil {
+		return fmt.Errorf("failed to fix %s: %w", fix.Path, err)
+	}
+	return nil
+}
+
+// writeFixDiffs writes a unified diff of each planned fix to w, colorized
+// when color is set.
+func writeFixDiffs(w io.Writer, fixes []workItemFix, color bool) error {
+	for _, fix := range fixes {
+		diff := diffutil.Unified("a/"+filepath.ToSlash(fix.Path), "b/"+filepath.ToSlash(fix.Path), fix.Before, fix.After)
+		if _, err := io.WriteString(w, colorDiff(diff, color)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// colorDiff colors the header, hunk, removed, and added lines of a unified
+// diff when enabled.
+func colorDiff(diff string, enabled bool) string {
+	if !enabled || diff == "" {
+		return diff
+	}
+
+	lines := strings.SplitAfter(diff, "\n")
+	for i, line := range lines {
+		var code string
+		switch {
+		case strings.HasPrefix(line, "--- "), strings.HasPrefix(line, "+++ "):
+			code = "\x1b[1m"
+		case strings.HasPrefix(line, "@@"):
+			code = "\x1b[36m"
+		case strings.HasPrefix(line, "-"):
+			code = "\x1b[31m"
+		case strings.HasPrefix(line, "+"):
+			code = "\x1b[32m"
+		default:
+			continue
+		}
+		lines[i] = code + strings.TrimSuffix(line, "\n") + resetColor + "\n"
+	}
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,625 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+	"kira/internal/validation"
+)
+
+const (
+	sortByID       = "id"
+	sortByPriority = "priority"
+	sortByCreated  = "created"
+)
+
+const (
+	groupByStatus   = "status"
+	groupByKind     = "kind"
+	groupByAssignee = "assignee"
+)
+
+const (
+	// formatTable is the default list output: an aligned plain-text table.
+	formatTable = "table"
+	// formatJSONLines writes one JSON object per work item per line.
+	formatJSONLines = "jsonl"
+)
+
+// noGroupName heads the group of items with no value for the --group-by field.
+const noGroupName = "(none)"
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List work items",
+	Long: `Lists work items in a table, optionally filtered by status, kind, and assignee.
+Use --sort to order by id (default), priority, or created date, and
+--show-progress to add a column with each item's checklist progress.
+--group-by status, kind, or assignee prints the items under a header per group
+with its count; status groups follow the workflow order, others are alphabetical.
+--format markdown prints GitHub-flavored markdown tables for pasting into docs,
+and --format jsonl prints one JSON object per line for streaming into jq.
+--limit and --offset page through the filtered, sorted items and add a footer
+such as "showing 1-20 of 340".
+--fields id,title,assigned,due replaces the default columns with the named
+front matter fields, in that order; fields an item lacks are left blank.
+--no-header leaves out the table's header row, for piping into other tools.
+--tree indents items under the items they depend on (depends_on), flagging
+dependency cycles.
+--overdue lists items whose due date has passed and that are not yet done;
+--due-before/--due-after and --created-before/--created-after filter by date.
+--since <git-ref> lists only items whose files changed between the ref and
+HEAD, such as everything touched since the last release tag.
+--where filters on front matter with conditions of the form field==value,
+field!=value, or field contains value, joined by && and ||, e.g.
+--where 'status==todo && priority==high'.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		filter, err := listFilter(cmd, cfg, time.Now())
+		if err != nil {
+			return err
+		}
+		sortBy, _ := cmd.Flags().GetString("sort")
+		showProgress, _ := cmd.Flags().GetBool("show-progress")
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		format, _ := cmd.Flags().GetString("format")
+		limit, _ := cmd.Flags().GetInt("limit")
+		offset, _ := cmd.Flags().GetInt("offset")
+		fields, _ := cmd.Flags().GetString("fields")
+		tree, _ := cmd.Flags().GetBool("tree")
+		noHeader, _ := cmd.Flags().GetBool("no-header")
+
+		return listWorkItems(os.Stdout, cfg, listOptions{
+			Filter:       filter,
+			SortBy:       sortBy,
+			ShowProgress: showProgress,
+			GroupBy:      groupBy,
+			Format:       format,
+			Fields:       splitFieldList(fields),
+			Tree:         tree,
+			NoHeader:     noHeader,
+			Limit:        limit,
+			Offset:       offset,
+		})
+	},
+}
+
+func init() {
+	addListFlags(listCmd)
+}
+
+// addListFlags registers list's flags on cmd.
+func addListFlags(cmd *cobra.Command) {
+	cmd.Flags().String("status", "", "Only list work items with this status")
+	cmd.Flags().String("kind", "", "Only list work items of this kind")
+	cmd.Flags().String("assignee", "", "Only list work items assigned to this person")
+	cmd.Flags().String("sort", sortByID, "Sort order: id, priority, or created")
+	cmd.Flags().Bool("show-progress", false, "Add a column with checklist progress")
+	cmd.Flags().String("group-by", "", "Group items under headers: status, kind, or assignee")
+	cmd.Flags().String("format", formatTable, "Output format: table, markdown, or jsonl")
+	cmd.Flags().Bool("overdue", false, "Only list items past their due date that are not yet done")
+	cmd.Flags().String("due-before", "", "Only list items due before this date")
+	cmd.Flags().String("due-after", "", "Only list items due after this date")
+	cmd.Flags().String("created-before", "", "Only list items created before this date")
+	cmd.Flags().String("created-after", "", "Only list items created after this date")
+	cmd.Flags().String("since", "", "Only list items whose files changed between this git ref and HEAD")
+	cmd.Flags().String("where", "", "Only list items matching an expression such as 'status==todo && priority==high'")
+	cmd.Flags().Bool("tree", false, "Show items as a tree, with dependents indented under the items they depend on")
+	cmd.Flags().String("fields", "", "Comma-separated front matter fields to show as columns, in order (e.g. id,title,assigned,due)")
+	cmd.Flags().Bool("no-header", false, "Print only data rows, without the column header")
+	cmd.Flags().Int("limit", 0, "Show at most this many items (0 shows all)")
+	cmd.Flags().Int("offset", 0, "Skip this many items before listing")
+}
+
+// listFilter builds the work item filter from list's filter flags. Dates are
+// parsed leniently; --overdue narrows the due range to before today.
+func listFilter(cmd *cobra.Command, cfg *config.Config, now time.Time) (workItemFilter, error) {
+	var filter workItemFilter
+	filter.Status, _ = cmd.Flags().GetString("status")
+	filter.Kind, _ = cmd.Flags().GetString("kind")
+	filter.Assignee, _ = cmd.Flags().GetString("assignee")
+
+	bounds := []struct {
+		flag  string
+		field *time.Time
+	}{
+		{"due-before", &filter.Due.Before},
+		{"due-after", &filter.Due.After},
+		{"created-before", &filter.Created.Before},
+		{"created-after", &filter.Created.After},
+	}
+	for _, bound := range bounds {
+		value, _ := cmd.Flags().GetString(bound.flag)
+		if value == "" {
+			continue
+		}
+		date, err := parseLenientDate(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --%s: %w", bound.flag, err)
+		}
+		*bound.field = date
+	}
+
+	if overdue, _ := cmd.Flags().GetBool("overdue"); overdue {
+		today := dateOnly(now)
+		if filter.Due.Before.IsZero() || today.Before(filter.Due.Before) {
+			filter.Due.Before = today
+		}
+		filter.ExcludeStatuses = finishedStatuses(cfg)
+	}
+
+	if since, _ := cmd.Flags().GetString("since"); since != "" {
+		paths, err := changedSince(os.Stderr, since)
+		if err != nil {
+			return filter, err
+		}
+		filter.Paths = paths
+	}
+
+	if where, _ := cmd.Flags().GetString("where"); where != "" {
+		expr, err := parseWhere(where)
+		if err != nil {
+			return filter, err
+		}
+		filter.Where = expr
+	}
+	return filter, nil
+}
+
+// changedSince returns the set of work item files changed between ref and
+// HEAD. Outside a git repository it writes a warning to w, kept apart from the
+// listing so piped output stays parseable, and returns nil, which filters
+// nothing.
+func changedSince(w io.Writer, ref string) (map[string]bool, error) {
+	if !inGitRepo() {
+		fmt.Fprintf(w, "Warning: not in a git repository; ignoring --since %s\n", ref)
+		return nil, nil
+	}
+
+	files, err := gitChangedFiles(ref)
+	if err != nil {
+		return nil, err
+	}
+	paths := make(map[string]bool, len(files))
+	for _, file := range files {
+		paths[filepath.Clean(file)] = true
+	}
+	return paths, nil
+}
+
+// splitFieldList splits a comma-separated --fields value, dropping blanks.
+func splitFieldList(value string) []string {
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// listOptions controls which work items list prints and how.
+type listOptions struct {
+	Filter       workItemFilter
+	SortBy       string
+	ShowProgress bool
+	GroupBy      string
+	Format       string
+	// Tree prints the items as a forest along their depends_on edges.
+	Tree bool
+	// Fields selects and orders the front matter fields shown, in place of
+	// the default columns.
+	Fields []string
+	// NoHeader leaves the header row out of table output.
+	NoHeader bool
+	// Limit and Offset select a page of the filtered, sorted items; a zero
+	// Limit means no limit.
+	Limit  int
+	Offset int
+}
+
+func listWorkItems(w io.Writer, cfg *config.Config, opts listOptions) error {
+	switch opts.Format {
+	case formatTable, "", formatMarkdown:
+	case formatJSONLines:
+		if opts.GroupBy != "" {
+			return fmt.Errorf("--group-by cannot be combined with --format %s", formatJSONLines)
+		}
+	default:
+		return fmt.Errorf("invalid format '%s' (valid: %s, %s, %s)", opts.Format, formatTable, formatMarkdown, formatJSONLines)
+	}
+	if opts.Tree && (opts.GroupBy != "" || opts.Format == formatMarkdown || opts.Format == formatJSONLines || len(opts.Fields) > 0 || opts.Limit != 0 || opts.Offset != 0) {
+		return fmt.Errorf("--tree cannot be combined with --group-by, --format, --fields, --limit, or --offset")
+	}
+	if opts.NoHeader && opts.Format == formatMarkdown {
+		return fmt.Errorf("--no-header cannot be combined with --format %s", formatMarkdown)
+	}
+
+	items, err := loadWorkItems(opts.Filter)
+	if err != nil {
+		return err
+	}
+
+	if err := sortWorkItems(cfg, items, opts.SortBy); err != nil {
+		return err
+	}
+
+	if opts.Tree {
+		return writeWorkItemTree(w, items)
+	}
+
+	if opts.Limit < 0 || opts.Offset < 0 {
+		return fmt.Errorf("--limit and --offset must not be negative")
+	}
+	paginated := opts.Limit > 0 || opts.Offset > 0
+	total := len(items)
+	items, start := paginateWorkItems(items, opts.Limit, opts.Offset)
+
+	if opts.Format == formatJSONLines {
+		return writeJSONLines(w, items, opts.Fields)
+	}
+
+	if err := writeWorkItemGroups(w, cfg, items, opts); err != nil {
+		return err
+	}
+
+	if paginated {
+		if _, err := fmt.Fprintf(w, "\n%s\n", paginationFooter(start, len(items), total)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// paginateWorkItems returns the page of items selected by limit and offset,
+// along with the index of its first item.
+func paginateWorkItems(items []*validation.WorkItem, limit, offset int) ([]*validation.WorkItem, int) {
+	if offset > len(items) {
+		offset = len(items)
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items, offset
+}
+
+// paginationFooter describes which items of the total a page shows.
+func paginationFooter(start, count, total int) string {
+	if count == 0 {
+		return fmt.Sprintf("showing 0 of %d", total)
+	}
+	return fmt.Sprintf("showing %d-%d of %d", start+1, start+count, total)
+}
+
+// writeWorkItemGroups writes items as one table, or as a table per group when
+// opts.GroupBy is set.
+func writeWorkItemGroups(w io.Writer, cfg *config.Config, items []*validation.WorkItem, opts listOptions) error {
+	if opts.GroupBy == "" {
+		return writeWorkItemTable(w, items, opts)
+	}
+
+	groups, err := groupWorkItems(cfg, items, opts.GroupBy)
+	if err != nil {
+		return err
+	}
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if opts.Format == formatMarkdown {
+			fmt.Fprintf(w, "### %s (%d)\n\n", group.Name, len(group.Items))
+		} else {
+			fmt.Fprintf(w, "%s (%d)\n", group.Name, len(group.Items))
+		}
+		if err := writeWorkItemTable(w, group.Items, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listColumn is one column of list's table output.
+type listColumn struct {
+	Name  string
+	Value func(item *validation.WorkItem) string
+}
+
+// listColumns returns the table columns for opts: the front matter fields
+// named by opts.Fields in order, or the default ID, status, kind, priority,
+// and title columns.
+func listColumns(opts listOptions) []listColumn {
+	field := func(name string) listColumn {
+		return listColumn{Name: name, Value: func(item *validation.WorkItem) string { return item.Field(name) }}
+	}
+	progress := listColumn{Name: "progress", Value: func(item *validation.WorkItem) string {
+		return formatProgress(checklistProgress(item.Body))
+	}}
+
+	if len(opts.Fields) > 0 {
+		columns := make([]listColumn, 0, len(opts.Fields)+1)
+		for _, name := range opts.Fields {
+			columns = append(columns, field(name))
+		}
+		if opts.ShowProgress {
+			columns = append(columns, progress)
+		}
+		return columns
+	}
+
+	columns := []listColumn{field("id"), field("status"), field("kind"), {Name: "priority", Value: func(item *validation.WorkItem) string {
+		if priority := item.Field("priority"); priority != "" {
+			return priority
+		}
+		return "-"
+	}}}
+	if opts.ShowProgress {
+		columns = append(columns, progress)
+	}
+	return append(columns, field("title"))
+}
+
+// writeWorkItemTable writes items as a table with a header row, unless
+// opts.NoHeader is set, in markdown when opts.Format asks for it.
+func writeWorkItemTable(w io.Writer, items []*validation.WorkItem, opts listOptions) error {
+	if opts.Format == formatMarkdown {
+		return writeMarkdownTable(w, items, opts)
+	}
+
+	_, err := io.WriteString(w, formatWorkItemTable(items, opts, useColor(w)))
+	return err
+}
+
+// formatWorkItemTable lays out items in aligned columns, coloring status
+// cells when color is set.
+func formatWorkItemTable(items []*validation.WorkItem, opts listOptions, color bool) string {
+	columns := listColumns(opts)
+	var rows [][]string
+	headerRows := 0
+	if !opts.NoHeader {
+		header := make([]string, len(columns))
+		for i, column := range columns {
+			header[i] = strings.ToUpper(column.Name)
+		}
+		rows = append(rows, header)
+		headerRows = 1
+	}
+	for _, item := range items {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = column.Value(item)
+		}
+		rows = append(rows, cells)
+	}
+
+	widths := make([]int, len(columns))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	// Cells are padded before they are colored, so the escape codes do not
+	// count towards the column widths.
+	var sb strings.Builder
+	for r, row := range rows {
+		for i, cell := range row {
+			padding := ""
+			if i < len(row)-1 {
+				padding = strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2)
+			}
+			if r >= headerRows && columns[i].Name == "status" {
+				cell = colorStatus(items[r-headerRows].Status, cell, color)
+			}
+			sb.WriteString(cell + padding)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// writeMarkdownTable writes items as a GitHub-flavored markdown table.
+func writeMarkdownTable(w io.Writer, items []*validation.WorkItem, opts listOptions) error {
+	columns := listColumns(opts)
+	header := make([]string, len(columns))
+	separators := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = markdownHeader(column.Name)
+		separators[i] = "---"
+	}
+
+	rows := [][]string{header, separators}
+	for _, item := range items {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = column.Value(item)
+		}
+		rows = append(rows, row)
+	}
+
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = escapeMarkdownCell(cell)
+		}
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markdownHeader capitalizes a column name for a markdown table header.
+func markdownHeader(name string) string {
+	if name == "id" {
+		return "ID"
+	}
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// writeJSONLines writes each item as a JSON object on its own line, in the
+// shape kira export uses, encoding one item at a time. With fields, each object
+// holds just those front matter fields, in that order.
+func writeJSONLines(w io.Writer, items []*validation.WorkItem, fields []string) error {
+	if len(fields) > 0 {
+		return writeJSONLinesFields(w, items, fields)
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, item := range items {
+		if err := encoder.Encode(toExportedWorkItem(item)); err != nil {
+			return fmt.Errorf("failed to encode work item %s: %w", item.ID, err)
+		}
+	}
+	return nil
+}
+
+// writeJSONLinesFields writes each item as a JSON object of the named front
+// matter fields. Fields an item does not have are written as empty strings.
+func writeJSONLinesFields(w io.Writer, items []*validation.WorkItem, fields []string) error {
+	for _, item := range items {
+		values := workItemFields(item)
+		var line bytes.Buffer
+		line.WriteByte('{')
+		for i, name := range fields {
+			if i > 0 {
+				line.WriteByte(',')
+			}
+			var value interface{} = ""
+			if raw, ok := values[name]; ok && raw != nil {
+				value = jsonValue(raw)
+			}
+			key, _ := json.Marshal(name)
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("failed to encode work item %s: %w", item.ID, err)
+			}
+			line.Write(key)
+			line.WriteByte(':')
+			line.Write(encoded)
+		}
+		line.WriteString("}\n")
+		if _, err := w.Write(line.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeMarkdownCell escapes pipes so a value stays within its table cell.
+func escapeMarkdownCell(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+// workItemGroup is a set of work items sharing a --group-by value.
+type workItemGroup struct {
+	Name  string
+	Items []*validation.WorkItem
+}
+
+// groupWorkItems splits items by the groupBy field, keeping their order within
+// each group. Status groups follow the workflow order with unknown statuses
+// after it; other groups are alphabetical. Items without a value come last.
+func groupWorkItems(cfg *config.Config, items []*validation.WorkItem, groupBy string) ([]workItemGroup, error) {
+	var key func(*validation.WorkItem) string
+	switch groupBy {
+	case groupByStatus:
+		key = func(item *validation.WorkItem) string { return item.Status }
+	case groupByKind:
+		key = func(item *validation.WorkItem) string { return item.Kind }
+	case groupByAssignee:
+		key = workItemAssignee
+	default:
+		return nil, fmt.Errorf("invalid group-by: %s (valid: status, kind, assignee)", groupBy)
+	}
+
+	members := make(map[string][]*validation.WorkItem)
+	for _, item := range items {
+		name := key(item)
+		if name == "" {
+			name = noGroupName
+		}
+		members[name] = append(members[name], item)
+	}
+
+	rank := make(map[string]int)
+	if groupBy == groupByStatus {
+		for i, status := range config.OrderedStatuses(cfg) {
+			rank[status] = i + 1
+		}
+	}
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return groupSortKey(rank, names[i]) < groupSortKey(rank, names[j])
+	})
+
+	groups := make([]workItemGroup, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, workItemGroup{Name: name, Items: members[name]})
+	}
+	return groups, nil
+}
+
+// groupSortKey orders ranked group names first, then the rest alphabetically,
+// with the group of items without a value last.
+func groupSortKey(rank map[string]int, name string) string {
+	switch {
+	case name == noGroupName:
+		return "2"
+	case rank[name] > 0:
+		return fmt.Sprintf("0%06d", rank[name])
+	default:
+		return "1" + name
+	}
+}
+
+// sortWorkItems orders items in place. Items are already ordered by ID, so the
+// stable sorts below keep ID as the tie-breaker.
+func sortWorkItems(cfg *config.Config, items []*validation.WorkItem, sortBy string) error {
+	switch sortBy {
+	case sortByID, "":
+	case sortByPriority:
+		sort.SliceStable(items, func(i, j int) bool {
+			return prioritySortKey(cfg, items[i]) < prioritySortKey(cfg, items[j])
+		})
+	case sortByCreated:
+		sort.SliceStable(items, func(i, j int) bool {
+			return createdTime(items[i]).Before(createdTime(items[j]))
+		})
+	default:
+		return fmt.Errorf("invalid sort: %s (valid: id, priority, created)", sortBy)
+	}
+	return nil
+}
+
+// prioritySortKey ranks items by configured priority; items without a known
+// priority sort last.
+func prioritySortKey(cfg *config.Config, item *validation.WorkItem) int {
+	if rank := priorityRank(cfg, item.Field("priority")); rank >= 0 {
+		return rank
+	}
+	return len(cfg.Priorities)
+}
@@ -0,0 +1,531 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listedIDs returns the first column of each data row in list output.
+func listedIDs(output string) []string {
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n")[1:] {
+		ids = append(ids, strings.Fields(line)[0])
+	}
+	return ids
+}
+
+func TestListWorkItems(t *testing.T) {
+	t.Run("lists items by ID with a header", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "002", "Second", "todo", "task")
+		writeTestWorkItem(t, "0_backlog", "001", "First", "backlog", "prd")
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{SortBy: sortByID}))
+
+		assert.True(t, strings.HasPrefix(buf.String(), "ID"))
+		assert.Equal(t, []string{"001", "002"}, listedIDs(buf.String()))
+		assert.Contains(t, buf.String(), "First")
+	})
+
+	t.Run("sorts by priority with unprioritized items last", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "None", "todo", "task")
+		low := writeTestWorkItem(t, "1_todo", "002", "Low", "todo", "task")
+		high := writeTestWorkItem(t, "1_todo", "003", "High", "todo", "task")
+		medium := writeTestWorkItem(t, "1_todo", "004", "Medium", "todo", "task")
+		require.NoError(t, setFrontMatterField(low, "priority", "low"))
+		require.NoError(t, setFrontMatterField(high, "priority", "high"))
+		require.NoError(t, setFrontMatterField(medium, "priority", "medium"))
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{SortBy: sortByPriority}))
+
+		assert.Equal(t, []string{"003", "004", "002", "001"}, listedIDs(buf.String()))
+	})
+
+	t.Run("rejects an unknown sort", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Only", "todo", "task")
+
+		var buf bytes.Buffer
+		assert.Error(t, listWorkItems(&buf, newTestConfig(), listOptions{SortBy: "title"}))
+	})
+}
+
+func TestListWorkItemsGroupBy(t *testing.T) {
+	setup := func(t *testing.T) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		writeTestWorkItem(t, "4_done", "001", "Shipped", "done", "task")
+		writeTestWorkItem(t, "1_todo", "002", "Planned", "todo", "prd")
+		writeTestWorkItem(t, "0_backlog", "003", "Idea", "backlog", "task")
+		assigned := writeTestWorkItem(t, "1_todo", "004", "Owned", "todo", "issue")
+		require.NoError(t, setFrontMatterField(assigned, "assigned", "sam"))
+	}
+
+	// groupedIDs maps each group header to the IDs listed under it.
+	groupedIDs := func(output string) ([]string, map[string][]string) {
+		var headers []string
+		groups := make(map[string][]string)
+		for _, block := range strings.Split(strings.TrimSpace(output), "\n\n") {
+			header, table, _ := strings.Cut(block, "\n")
+			headers = append(headers, header)
+			groups[header] = listedIDs(table)
+		}
+		return headers, groups
+	}
+
+	t.Run("groups by status in workflow order", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{GroupBy: groupByStatus}))
+
+		headers, groups := groupedIDs(buf.String())
+		assert.Equal(t, []string{"backlog (1)", "todo (2)", "done (1)"}, headers)
+		assert.Equal(t, []string{"002", "004"}, groups["todo (2)"])
+		assert.Equal(t, []string{"001"}, groups["done (1)"])
+	})
+
+	t.Run("groups by kind alphabetically", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{GroupBy: groupByKind}))
+
+		headers, groups := groupedIDs(buf.String())
+		assert.Equal(t, []string{"issue (1)", "prd (1)", "task (2)"}, headers)
+		assert.Equal(t, []string{"001", "003"}, groups["task (2)"])
+	})
+
+	t.Run("puts unassigned items last", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{GroupBy: groupByAssignee}))
+
+		headers, groups := groupedIDs(buf.String())
+		assert.Equal(t, []string{"sam (1)", "(none) (3)"}, headers)
+		assert.Equal(t, []string{"004"}, groups["sam (1)"])
+	})
+
+	t.Run("rejects an unknown field", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		assert.Error(t, listWorkItems(&buf, newTestConfig(), listOptions{GroupBy: "title"}))
+	})
+}
+
+func TestListWorkItemsMarkdown(t *testing.T) {
+	t.Run("writes a markdown table", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+		piped := writeTestWorkItem(t, "1_todo", "002", "Second", "todo", "prd")
+		require.NoError(t, setFrontMatterField(piped, "title", "Read | write"))
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Format: formatMarkdown}))
+
+		expected := "| ID | Status | Kind | Priority | Title |\n" +
+			"| --- | --- | --- | --- | --- |\n" +
+			"| 001 | todo | task | - | First |\n" +
+			"| 002 | todo | prd | - | Read \\| write |\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("rejects an unknown format", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		var buf bytes.Buffer
+		assert.Error(t, listWorkItems(&buf, newTestConfig(), listOptions{Format: "csv"}))
+	})
+}
+
+func TestListWorkItemsPagination(t *testing.T) {
+	setup := func(t *testing.T) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		for i, kind := range []string{"task", "prd", "task", "task", "prd", "task"} {
+			id := fmt.Sprintf("%03d", i+1)
+			writeTestWorkItem(t, "1_todo", id, "Item "+id, "todo", kind)
+		}
+	}
+	page := func(output string) ([]string, string) {
+		body, footer, found := strings.Cut(output, "\n\n")
+		require.True(t, found, "missing footer in %q", output)
+		return listedIDs(body), strings.TrimSpace(footer)
+	}
+
+	t.Run("returns the page selected by limit and offset", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Limit: 2, Offset: 1}))
+
+		ids, footer := page(buf.String())
+		assert.Equal(t, []string{"002", "003"}, ids)
+		assert.Equal(t, "showing 2-3 of 6", footer)
+	})
+
+	t.Run("footer counts the filtered total", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		opts := listOptions{Filter: workItemFilter{Kind: "task"}, Limit: 3}
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), opts))
+
+		ids, footer := page(buf.String())
+		assert.Equal(t, []string{"001", "003", "004"}, ids)
+		assert.Equal(t, "showing 1-3 of 4", footer)
+	})
+
+	t.Run("an offset past the end shows no items", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Offset: 10}))
+
+		_, footer := page(buf.String())
+		assert.Equal(t, "showing 0 of 6", footer)
+	})
+
+	t.Run("rejects a negative limit", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		assert.Error(t, listWorkItems(&buf, newTestConfig(), listOptions{Limit: -1}))
+	})
+}
+
+func TestListWorkItemsJSONLines(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+	writeTestWorkItem(t, "1_todo", "002", "Second", "todo", "prd")
+	writeTestWorkItem(t, "2_doing", "003", "Third", "doing", "task")
+
+	var buf bytes.Buffer
+	opts := listOptions{Filter: workItemFilter{Kind: "task"}, Format: formatJSONLines}
+	require.NoError(t, listWorkItems(&buf, newTestConfig(), opts))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	var ids []string
+	for _, line := range lines {
+		var item exportedWorkItem
+		require.NoError(t, json.Unmarshal([]byte(line), &item), line)
+		assert.Equal(t, "task", item.Kind)
+		ids = append(ids, item.ID)
+	}
+	assert.Equal(t, []string{"001", "003"}, ids)
+
+	opts.GroupBy = groupByStatus
+	assert.Error(t, listWorkItems(&buf, newTestConfig(), opts))
+}
+
+func TestListWorkItemsDateFilters(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	for _, item := range []struct{ folder, id, status, created, due string }{
+		{"1_todo", "001", "todo", "2024-01-01", "2024-02-01"},
+		{"1_todo", "002", "todo", "2024-01-10", "2024-03-01"},
+		{"4_done", "003", "done", "2024-01-20", "2024-02-01"},
+		{"1_todo", "004", "todo", "2024-02-01", ""},
+	} {
+		path := writeTestWorkItem(t, item.folder, item.id, "Item "+item.id, item.status, "task")
+		require.NoError(t, setFrontMatterField(path, "created", item.created))
+		if item.due != "" {
+			require.NoError(t, setFrontMatterField(path, "due", item.due))
+		}
+	}
+
+	list := func(t *testing.T, flags map[string]string) []string {
+		t.Helper()
+		cmd := &cobra.Command{}
+		addListFlags(cmd)
+		for name, value := range flags {
+			require.NoError(t, cmd.Flags().Set(name, value))
+		}
+		filter, err := listFilter(cmd, newTestConfig(), time.Date(2024, 2, 15, 9, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Filter: filter}))
+		return listedIDs(buf.String())
+	}
+
+	t.Run("overdue excludes finished and undated items", func(t *testing.T) {
+		assert.Equal(t, []string{"001"}, list(t, map[string]string{"overdue": "true"}))
+	})
+
+	t.Run("due-before and due-after", func(t *testing.T) {
+		assert.Equal(t, []string{"001", "003"}, list(t, map[string]string{"due-before": "2024/02/15"}))
+		assert.Equal(t, []string{"002"}, list(t, map[string]string{"due-after": "Feb 1, 2024"}))
+	})
+
+	t.Run("created-before and created-after combine with status", func(t *testing.T) {
+		assert.Equal(t, []string{"001", "002"}, list(t, map[string]string{"created-before": "2024-01-15"}))
+		flags := map[string]string{"created-after": "2024-01-05", "status": "todo"}
+		assert.Equal(t, []string{"002", "004"}, list(t, flags))
+	})
+
+	t.Run("rejects an unparseable date", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		addListFlags(cmd)
+		require.NoError(t, cmd.Flags().Set("due-before", "someday"))
+		_, err := listFilter(cmd, newTestConfig(), time.Now())
+		assert.EqualError(t, err, "invalid --due-before: unrecognized date 'someday'")
+	})
+}
+
+func TestListWorkItemsFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	first := writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+	require.NoError(t, setFrontMatterField(first, "assignee", "ana"))
+	require.NoError(t, setFrontMatterField(first, "due", "2024-02-01"))
+	writeTestWorkItem(t, "1_todo", "002", "Second", "todo", "task")
+
+	fields := []string{"title", "assignee", "missing", "id"}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Fields: fields}))
+		assert.Equal(t, "TITLE   ASSIGNEE  MISSING  ID\nFirst   ana                001\nSecond                     002\n", buf.String())
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Fields: []string{"id", "due"}, Format: formatMarkdown}))
+		assert.Equal(t, "| ID | Due |\n| --- | --- |\n| 001 | 2024-02-01 |\n| 002 |  |\n", buf.String())
+	})
+
+	t.Run("jsonl keeps the field order", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Fields: []string{"id", "due", "assignee"}, Format: formatJSONLines}))
+		assert.Equal(t, `{"id":"001","due":"2024-02-01","assignee":"ana"}`+"\n"+`{"id":"002","due":"","assignee":""}`+"\n", buf.String())
+	})
+
+	t.Run("splits the flag value", func(t *testing.T) {
+		assert.Equal(t, []string{"id", "title", "due"}, splitFieldList(" id, title,,due "))
+		assert.Nil(t, splitFieldList(""))
+	})
+}
+
+func TestListWorkItemsNoHeader(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	first := writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+	require.NoError(t, setFrontMatterField(first, "assignee", "ana"))
+	writeTestWorkItem(t, "1_todo", "002", "Second", "todo", "task")
+
+	t.Run("prints only data rows", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{NoHeader: true}))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.True(t, strings.HasPrefix(lines[0], "001 "), lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "002 "), lines[1])
+	})
+
+	t.Run("combines with --fields", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Fields: []string{"id", "assignee"}, NoHeader: true}))
+		assert.Equal(t, "001  ana\n002  \n", buf.String())
+	})
+
+	t.Run("rejects markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := listWorkItems(&buf, newTestConfig(), listOptions{NoHeader: true, Format: formatMarkdown})
+		assert.EqualError(t, err, "--no-header cannot be combined with --format markdown")
+	})
+}
+
+func TestListWorkItemsTree(t *testing.T) {
+	setup := func(t *testing.T, deps map[string]string) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		for _, id := range []string{"001", "002", "003", "004"} {
+			path := writeTestWorkItem(t, "1_todo", id, "Item "+id, "todo", "task")
+			if dep, ok := deps[id]; ok {
+				setRawFrontMatterField(t, path, "depends_on", dep)
+			}
+		}
+	}
+
+	t.Run("indents dependents under their prerequisites", func(t *testing.T) {
+		setup(t, map[string]string{"002": "[001]", "003": "[002]", "004": "[001, 999]"})
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Tree: true}))
+
+		expected := "001 Item 001 [todo]\n" +
+			"├── 002 Item 002 [todo]\n" +
+			"│   └── 003 Item 003 [todo]\n" +
+			"└── 004 Item 004 [todo]\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("flags a cycle instead of looping", func(t *testing.T) {
+		setup(t, map[string]string{"001": "[003]", "002": "[001]", "003": "[002]"})
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Tree: true}))
+
+		expected := "004 Item 004 [todo]\n" +
+			"001 Item 001 [todo]\n" +
+			"└── 002 Item 002 [todo]\n" +
+			"    └── 003 Item 003 [todo]\n" +
+			"        └── 001 Item 001 [todo] (cycle)\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("rejects table-only options", func(t *testing.T) {
+		setup(t, nil)
+
+		err := listWorkItems(&bytes.Buffer{}, newTestConfig(), listOptions{Tree: true, Format: formatJSONLines})
+		assert.Error(t, err)
+	})
+}
+
+func TestCompareIDs(t *testing.T) {
+	ids := []string{"PRD-10", "10", "PRD-2", "2", "100", "010", "BUG-1", "PRD-001"}
+	sort.Slice(ids, func(i, j int) bool { return compareIDs(ids[i], ids[j]) < 0 })
+	assert.Equal(t, []string{"2", "010", "10", "100", "BUG-1", "PRD-001", "PRD-2", "PRD-10"}, ids)
+
+	assert.Negative(t, compareIDs("2", "10"))
+	assert.Positive(t, compareIDs("PRD-10", "PRD-9"))
+	assert.Zero(t, compareIDs("007", "007"))
+}
+
+func TestListWorkItemsNumericIDOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	for _, id := range []string{"10", "2", "100", "1"} {
+		writeTestWorkItem(t, "1_todo", id, "Item "+id, "todo", "task")
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{}))
+	assert.Equal(t, []string{"1", "2", "10", "100"}, listedIDs(buf.String()))
+}
+
+func TestListWorkItemsSince(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	git := func(t *testing.T, args ...string) {
+		t.Helper()
+		output, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, "git %s: %s", strings.Join(args, " "), output)
+	}
+
+	t.Run("lists items changed since the ref", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		git(t, "init", "-q")
+		git(t, "config", "user.email", "test@example.com")
+		git(t, "config", "user.name", "Test User")
+
+		writeTestWorkItem(t, "1_todo", "001", "Moved", "todo", "task")
+		second := writeTestWorkItem(t, "1_todo", "002", "Edited", "todo", "task")
+		writeTestWorkItem(t, "1_todo", "003", "Untouched", "todo", "task")
+		git(t, "add", ".")
+		git(t, "commit", "-q", "-m", "Add work items")
+		git(t, "tag", "v1")
+
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+		git(t, "mv", ".work/1_todo/001-moved.task.md", ".work/2_doing/001-moved.task.md")
+		require.NoError(t, setFrontMatterField(second, "priority", "high"))
+		writeTestWorkItem(t, "1_todo", "004", "Added", "todo", "task")
+		git(t, "add", ".")
+		git(t, "commit", "-q", "-m", "Work on items")
+
+		paths, err := changedSince(io.Discard, "v1")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Filter: workItemFilter{Paths: paths}}))
+		assert.Equal(t, []string{"001", "002", "004"}, listedIDs(buf.String()))
+	})
+
+	t.Run("rejects an unknown ref", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		git(t, "init", "-q")
+		git(t, "config", "user.email", "test@example.com")
+		git(t, "config", "user.name", "Test User")
+		writeTestWorkItem(t, "1_todo", "001", "Only", "todo", "task")
+		git(t, "add", ".")
+		git(t, "commit", "-q", "-m", "Add work item")
+
+		_, err := changedSince(io.Discard, "v9")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "git diff against v9 failed")
+	})
+
+	t.Run("lists everything outside a git repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(tmpDir))
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		var warnings bytes.Buffer
+		paths, err := changedSince(&warnings, "v1")
+		require.NoError(t, err)
+		assert.Nil(t, paths)
+		assert.Equal(t, "Warning: not in a git repository; ignoring --since v1\n", warnings.String())
+	})
+}
@@ -0,0 +1,85 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// workspaceLockPath is the advisory lock held while kira new allocates an ID
+// and writes the work item.
+var workspaceLockPath = filepath.Join(".work", ".kira.lock")
+
+const (
+	// lockTimeout is how long to wait for another kira to release the lock.
+	lockTimeout = 10 * time.Second
+	// lockRetryInterval is how often a held lock is checked again.
+	lockRetryInterval = 20 * time.Millisecond
+	// staleLockAge is the age after which a lock is assumed to be left over
+	// from a kira that exited without releasing it. The pre-create hook runs
+	// while the lock is held, so this is well beyond hookTimeout.
+	staleLockAge = hookTimeout + 4*time.Minute
+)
+
+// lockWorkspace takes the workspace lock, waiting up to lockTimeout for it,
+// and returns a function that releases it. The lock is a file created with
+// O_EXCL, which works the same on every platform.
+func lockWorkspace() (func(), error) {
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		file, err := os.OpenFile(workspaceLockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err == nil {
+			// The PID is followed by the time so that every lock has
+			// different content, even when the file system reuses inodes.
+			token := fmt.Sprintf("%d %d\n", os.Getpid(), time.Now().UnixNano())
+			_, _ = file.WriteString(token)
+			_ = file.Close()
+			return func() { releaseLock(token) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+
+		if info, err := os.Stat(workspaceLockPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			if content, err := os.ReadFile(workspaceLockPath); err == nil {
+				breakStaleLock(info, string(content))
+			}
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for %s; if no other kira is running, delete it", workspaceLockPath)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// releaseLock removes the lock file if it still holds token, so a lock that
+// was broken as stale and taken by another kira is left alone.
+func releaseLock(token string) {
+	if content, err := os.ReadFile(workspaceLockPath); err == nil && string(content) == token {
+		_ = os.Remove(workspaceLockPath)
+	}
+}
+
+// breakStaleLock removes the lock file seen with info and content. The file is
+// renamed aside first, which only one waiter can do, and is put back if it
+// turns out to be a newer lock taken after the stale one was seen.
+func breakStaleLock(info os.FileInfo, content string) {
+	aside := fmt.Sprintf("%s.%d-%d", workspaceLockPath, os.Getpid(), time.Now().UnixNano())
+	if err := os.Rename(workspaceLockPath, aside); err != nil {
+		return
+	}
+	defer func() { _ = os.Remove(aside) }()
+
+	asideInfo, err := os.Stat(aside)
+	asideContent, readErr := os.ReadFile(aside)
+	if err == nil && readErr == nil && asideInfo.ModTime().Equal(info.ModTime()) && string(asideContent) == content {
+		debugf("Removed stale lock %s", workspaceLockPath)
+		return
+	}
+	// Another waiter broke the stale lock first; this is its fresh lock.
+	_ = os.Link(aside, workspaceLockPath)
+}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/validation"
+)
+
+func TestLockWorkspace(t *testing.T) {
+	t.Run("releases the lock", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+		require.NoError(t, os.MkdirAll(".work", 0o700))
+
+		unlock, err := lockWorkspace()
+		require.NoError(t, err)
+		assert.FileExists(t, workspaceLockPath)
+
+		unlock()
+		assert.NoFileExists(t, workspaceLockPath)
+	})
+
+	t.Run("takes over a stale lock", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+		require.NoError(t, os.MkdirAll(".work", 0o700))
+
+		require.NoError(t, os.WriteFile(workspaceLockPath, []byte("12345\n"), 0o600))
+		old := time.Now().Add(-2 * staleLockAge)
+		require.NoError(t, os.Chtimes(workspaceLockPath, old, old))
+
+		unlock, err := lockWorkspace()
+		require.NoError(t, err)
+		unlock()
+	})
+
+	t.Run("leaves a fresh lock that replaced the stale one", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+		require.NoError(t, os.MkdirAll(".work", 0o700))
+
+		require.NoError(t, os.WriteFile(workspaceLockPath, []byte("12345\n"), 0o600))
+		stale, err := os.Stat(workspaceLockPath)
+		require.NoError(t, err)
+
+		// Another waiter breaks the stale lock and takes a new one.
+		require.NoError(t, os.Remove(workspaceLockPath))
+		unlock, err := lockWorkspace()
+		require.NoError(t, err)
+
+		breakStaleLock(stale, "12345\n")
+		assert.FileExists(t, workspaceLockPath)
+		entries, err := os.ReadDir(".work")
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+
+		unlock()
+		assert.NoFileExists(t, workspaceLockPath)
+	})
+
+	t.Run("does not release a lock taken by someone else", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+		require.NoError(t, os.MkdirAll(".work", 0o700))
+
+		unlock, err := lockWorkspace()
+		require.NoError(t, err)
+		require.NoError(t, os.Remove(workspaceLockPath))
+		require.NoError(t, os.WriteFile(workspaceLockPath, []byte("12345\n"), 0o600))
+
+		unlock()
+		assert.FileExists(t, workspaceLockPath)
+	})
+
+	t.Run("outlasts the pre-create hook", func(t *testing.T) {
+		assert.Greater(t, staleLockAge, 2*hookTimeout)
+	})
+}
+
+func TestCreateWorkItemConcurrently(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	cfg := setupCustomTemplate(t, "---\nid: <!--input-number:id:\"ID\"-->\ntitle: <!--input-string:title:\"Title\"-->\nstatus: <!--input-string:status:\"Status\"-->\nkind: custom\ncreated: <!--input-string:created:\"Created\"-->\n---\n")
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, title := range []string{"First", "Second"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = createWorkItem(cfg, []string{"custom", title}, false, map[string]string{}, false)
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+
+	entries, err := os.ReadDir(".work/1_todo")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	var ids []string
+	for _, entry := range entries {
+		item, err := validation.ParseWorkItemFile(".work/1_todo/" + entry.Name())
+		require.NoError(t, err)
+		ids = append(ids, item.ID)
+	}
+	sort.Strings(ids)
+	assert.Equal(t, []string{"001", "002"}, ids)
+	assert.NoFileExists(t, workspaceLockPath)
+}
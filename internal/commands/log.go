@@ -0,0 +1,63 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log <work-item-id>",
+	Short: "Show the git history of a work item",
+	Long: `Prints the commits that changed a work item's file, newest first, with their
+abbreviated hash, date, and subject. History is followed across moves between
+status folders. Outside a git repository a message is printed instead.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if _, err := loadWorkspaceConfig(); err != nil {
+			return err
+		}
+
+		return showWorkItemLog(os.Stdout, args[0])
+	},
+}
+
+func showWorkItemLog(w io.Writer, workItemID string) error {
+	workItemPath, err := findWorkItemFile(workItemID)
+	if err != nil {
+		return err
+	}
+
+	if !inGitRepo() {
+		_, err := fmt.Fprintf(w, "Not in a git repository; no history for work item %s\n", workItemID)
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+
+	// #nosec G204 - the path comes from findWorkItemFile and is passed after --
+	cmd := exec.CommandContext(ctx, "git", "log", "--follow", "--date=short", "--format=%h%x09%ad%x09%s", "--", workItemPath)
+	output, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to read git history: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		_, err := fmt.Fprintf(w, "No commits found for work item %s\n", workItemID)
+		return err
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, strings.ReplaceAll(line, "\t", "  ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowWorkItemLog(t *testing.T) {
+	git := func(t *testing.T, args ...string) {
+		t.Helper()
+		output, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, "git %s: %s", strings.Join(args, " "), output)
+	}
+
+	t.Run("prints the commits for the item across moves", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		git(t, "init", "-q")
+		git(t, "config", "user.email", "test@example.com")
+		git(t, "config", "user.name", "Test User")
+
+		writeTestWorkItem(t, "1_todo", "001", "Logged", "todo", "task")
+		writeTestWorkItem(t, "1_todo", "002", "Other", "todo", "task")
+		git(t, "add", ".")
+		git(t, "commit", "-q", "-m", "Add work items")
+
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+		git(t, "mv", ".work/1_todo/001-logged.task.md", ".work/2_doing/001-logged.task.md")
+		git(t, "commit", "-q", "-m", "Start 001")
+
+		var buf bytes.Buffer
+		require.NoError(t, showWorkItemLog(&buf, "001"))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		line := regexp.MustCompile(`^[0-9a-f]{7,}  \d{4}-\d{2}-\d{2}  (.+)$`)
+		assert.Equal(t, "Start 001", line.FindStringSubmatch(lines[0])[1])
+		assert.Equal(t, "Add work items", line.FindStringSubmatch(lines[1])[1])
+	})
+
+	t.Run("prints a message outside a git repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+		t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(tmpDir))
+
+		writeTestWorkItem(t, "1_todo", "001", "Untracked", "todo", "task")
+
+		var buf bytes.Buffer
+		require.NoError(t, showWorkItemLog(&buf, "001"))
+		assert.Equal(t, "Not in a git repository; no history for work item 001\n", buf.String())
+	})
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"kira/internal/validation"
+)
+
+// findWorkItemFileOrTitle resolves query as a work item ID, falling back to a
+// match on the title when no item has that ID. Only read-only commands use it,
+// so a loose title match never picks the item a command changes or deletes.
+func findWorkItemFileOrTitle(query string) (string, error) {
+	path, err := searchWorkItemFile(query)
+	if err != nil || path != "" {
+		return path, err
+	}
+	return findWorkItemByTitle(query)
+}
+
+// findWorkItemByTitle resolves query to the one work item whose title contains
+// it, ignoring case. Several matches are offered as a numbered choice when
+// stdin is a terminal and reported as an error otherwise.
+func findWorkItemByTitle(query string) (string, error) {
+	return matchWorkItemTitle(query, os.Stderr, os.Stdin, stdinIsTerminal())
+}
+
+func matchWorkItemTitle(query string, w io.Writer, r io.Reader, interactive bool) (string, error) {
+	items, err := loadWorkItems(workItemFilter{})
+	if err != nil {
+		return "", fmt.Errorf("failed to search for work item: %w", err)
+	}
+
+	var matches []*validation.WorkItem
+	needle := strings.ToLower(query)
+	for _, item := range items {
+		if needle != "" && strings.Contains(strings.ToLower(item.Title), needle) {
+			matches = append(matches, item)
+		}
+	}
+
+	switch {
+	case len(matches) == 0:
+		return "", fmt.Errorf("work item with ID %s not found", query)
+	case len(matches) == 1:
+		debugf("Matched %q to work item %s (%s)", query, matches[0].ID, matches[0].Title)
+		return matches[0].Path, nil
+	case !interactive:
+		candidates := make([]string, len(matches))
+		for i, item := range matches {
+			candidates[i] = fmt.Sprintf("%s (%s)", item.ID, item.Title)
+		}
+		return "", fmt.Errorf("%q matches %d work items: %s; use an ID", query, len(matches), strings.Join(candidates, ", "))
+	}
+
+	fmt.Fprintf(w, "Work items matching %q:\n", query)
+	for i, item := range matches {
+		fmt.Fprintf(w, "%d. %s  %s\n", i+1, item.ID, item.Title)
+	}
+	fmt.Fprint(w, "Select work item (number): ")
+	input, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	choice, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || choice < 1 || choice > len(matches) {
+		return "", fmt.Errorf("invalid work item selection")
+	}
+	return matches[choice-1].Path, nil
+}
+
+// stdinIsTerminal reports whether stdin is attached to a terminal.
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
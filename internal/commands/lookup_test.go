@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindWorkItemByTitle(t *testing.T) {
+	setup := func(t *testing.T) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		writeTestWorkItem(t, "1_todo", "001", "Auth login flow", "todo", "task")
+		writeTestWorkItem(t, "1_todo", "002", "OAuth tokens", "todo", "task")
+		writeTestWorkItem(t, "2_doing", "003", "Billing export", "doing", "task")
+	}
+
+	t.Run("exact IDs take priority", func(t *testing.T) {
+		setup(t)
+
+		path, err := findWorkItemFileOrTitle("003")
+		require.NoError(t, err)
+		assert.Equal(t, ".work/2_doing/003-billing-export.task.md", path)
+	})
+
+	t.Run("resolves a unique title match ignoring case", func(t *testing.T) {
+		setup(t)
+
+		path, err := findWorkItemFileOrTitle("BILLING")
+		require.NoError(t, err)
+		assert.Equal(t, ".work/2_doing/003-billing-export.task.md", path)
+	})
+
+	t.Run("is not used by commands that change work items", func(t *testing.T) {
+		setup(t)
+
+		_, err := findWorkItemFile("billing")
+		assert.EqualError(t, err, "work item with ID billing not found")
+	})
+
+	t.Run("lists the candidates of an ambiguous match", func(t *testing.T) {
+		setup(t)
+
+		_, err := matchWorkItemTitle("auth", &bytes.Buffer{}, strings.NewReader(""), false)
+		assert.EqualError(t, err, `"auth" matches 2 work items: 001 (Auth login flow), 002 (OAuth tokens); use an ID`)
+	})
+
+	t.Run("prompts to choose between several matches", func(t *testing.T) {
+		setup(t)
+
+		var out bytes.Buffer
+		path, err := matchWorkItemTitle("auth", &out, strings.NewReader("2\n"), true)
+		require.NoError(t, err)
+		assert.Equal(t, ".work/1_todo/002-oauth-tokens.task.md", path)
+		assert.Contains(t, out.String(), "1. 001  Auth login flow\n2. 002  OAuth tokens\n")
+
+		_, err = matchWorkItemTitle("auth", &out, strings.NewReader("3\n"), true)
+		assert.EqualError(t, err, "invalid work item selection")
+	})
+
+	t.Run("reports no match as not found", func(t *testing.T) {
+		setup(t)
+
+		_, err := findWorkItemFileOrTitle("payments")
+		assert.EqualError(t, err, "work item with ID payments not found")
+	})
+}
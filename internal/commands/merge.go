@@ -0,0 +1,219 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+	"kira/internal/fsutil"
+	"kira/internal/validation"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <source-id> <target-id>",
+	Short: "Merge a duplicate work item into another",
+	Long: `Merges the source work item into the target: the source's body is appended to
+the target under a "## Merged from <id>" heading, their tags and depends_on lists
+are combined, items that depend on the source are repointed to the target, and
+the source is deleted (or archived with --archive). Without --confirm the
+planned changes are printed and nothing is written. kira undo reverts the whole
+merge.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		confirm, _ := cmd.Flags().GetBool("confirm")
+		archive, _ := cmd.Flags().GetBool("archive")
+		return mergeWorkItems(os.Stdout, cfg, args[0], args[1], archive, confirm)
+	},
+}
+
+func init() {
+	mergeCmd.Flags().Bool("confirm", false, "Apply the merge")
+	mergeCmd.Flags().Bool("archive", false, "Archive the source item instead of deleting it")
+}
+
+// mergeRewrite is the new content of one work item changed by a merge, along
+// with its content before the merge.
+type mergeRewrite struct {
+	Path     string
+	Content  string
+	Original string
+}
+
+// mergeWorkItems prints the planned merge of sourceID into targetID and, when
+// confirm is set, applies it.
+func mergeWorkItems(w io.Writer, cfg *config.Config, sourceID, targetID string, archive, confirm bool) error {
+	source, err := findMergeItem(sourceID)
+	if err != nil {
+		return err
+	}
+	target, err := findMergeItem(targetID)
+	if err != nil {
+		return err
+	}
+	if source.Path == target.Path {
+		return fmt.Errorf("cannot merge work item %s into itself", source.ID)
+	}
+
+	rewrites, err := planMerge(w, source, target)
+	if err != nil {
+		return err
+	}
+	action := "delete"
+	if archive {
+		action = "archive"
+	}
+	fmt.Fprintf(w, "%s %s\n", action, source.Path)
+	if !confirm {
+		return fmt.Errorf("merge would rewrite %d work items and %s %s; re-run with --confirm to apply", len(rewrites), action, source.Path)
+	}
+
+	sourceContent, err := safeReadFile(source.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", source.Path, err)
+	}
+
+	// The whole merge is logged as one operation so that kira undo restores
+	// the source and every rewritten item together.
+	op := operation{Kind: operationMerge, Source: source.Path, Dest: target.Path, Content: string(sourceContent)}
+	for _, rewrite := range rewrites {
+		if err := fsutil.WriteFile(rewrite.Path, []byte(rewrite.Content), cfg.FilePerm()); err != nil {
+			return fmt.Errorf("failed to write %s: %w", rewrite.Path, err)
+		}
+		op.Edits = append(op.Edits, operationEdit{Path: rewrite.Path, Content: rewrite.Original})
+	}
+	if archive {
+		if _, err := archiveWorkItems(cfg, []string{source.Path}, filepath.Dir(source.Path)); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", source.Path, err)
+		}
+	}
+	if err := os.Remove(source.Path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", source.Path, err)
+	}
+	recordOperation(cfg, op)
+
+	infof("Merged work item %s into %s", source.ID, target.ID)
+	return nil
+}
+
+func findMergeItem(id string) (*validation.WorkItem, error) {
+	path, err := findWorkItemFile(id)
+	if err != nil {
+		return nil, err
+	}
+	item, err := validation.ParseWorkItemFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return item, nil
+}
+
+// planMerge works out the rewritten target and the items whose depends_on
+// must be repointed from the source to the target, describing each on w.
+func planMerge(w io.Writer, source, target *validation.WorkItem) ([]mergeRewrite, error) {
+	content, err := safeReadFile(target.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", target.Path, err)
+	}
+	merged := string(content)
+
+	if body := strings.Trim(source.Body, "\n"); strings.TrimSpace(body) != "" {
+		merged = fmt.Sprintf("%s\n\n## Merged from %s: %s\n\n%s\n", strings.TrimRight(merged, "\n"), source.ID, source.Title, body)
+		fmt.Fprintf(w, "append the body of %s to %s\n", source.ID, target.Path)
+	}
+
+	tags := fieldValues(target, "tags")
+	if union := unionValues(tags, fieldValues(source, "tags"), nil); !slices.Equal(union, tags) {
+		if merged, err = setFrontMatterList(merged, "tags", union); err != nil {
+			return nil, fmt.Errorf("%s: %w", target.Path, err)
+		}
+		fmt.Fprintf(w, "tags: [%s]\n", strings.Join(union, ", "))
+	}
+
+	// The merged item must not depend on itself or on the item being removed.
+	exclude := map[string]bool{source.ID: true, target.ID: true}
+	if union := unionValues(target.DependsOn, source.DependsOn, exclude); !slices.Equal(union, []string(target.DependsOn)) {
+		if merged, err = setFrontMatterList(merged, "depends_on", union); err != nil {
+			return nil, fmt.Errorf("%s: %w", target.Path, err)
+		}
+		fmt.Fprintf(w, "depends_on: [%s]\n", strings.Join(union, ", "))
+	}
+	rewrites := []mergeRewrite{{Path: target.Path, Content: merged, Original: string(content)}}
+
+	items, err := loadWorkItems(workItemFilter{})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.Path == source.Path || item.Path == target.Path || !slices.Contains(item.DependsOn, source.ID) {
+			continue
+		}
+		repointed := make([]string, len(item.DependsOn))
+		for i, dep := range item.DependsOn {
+			repointed[i] = dep
+			if dep == source.ID {
+				repointed[i] = target.ID
+			}
+		}
+		content, err := safeReadFile(item.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", item.Path, err)
+		}
+		updated, err := setFrontMatterList(string(content), "depends_on", unionValues(nil, repointed, nil))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", item.Path, err)
+		}
+		rewrites = append(rewrites, mergeRewrite{Path: item.Path, Content: updated, Original: string(content)})
+		fmt.Fprintf(w, "repoint %s: depends_on %s -> %s\n", item.ID, source.ID, target.ID)
+	}
+	return rewrites, nil
+}
+
+// fieldValues returns a front matter field as a list, accepting a YAML list or
+// a comma-separated string.
+func fieldValues(item *validation.WorkItem, field string) []string {
+	var values []string
+	switch v := item.Fields[field].(type) {
+	case nil:
+	case []interface{}:
+		for _, element := range v {
+			values = append(values, validation.FieldString(element))
+		}
+	default:
+		values = strings.Split(validation.FieldString(v), ",")
+	}
+
+	var trimmed []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+	return trimmed
+}
+
+// unionValues appends the values in extra missing from base, keeping the
+// order of first appearance and skipping duplicates and excluded values.
+func unionValues(base, extra []string, exclude map[string]bool) []string {
+	seen := make(map[string]bool, len(base)+len(extra))
+	var union []string
+	for _, value := range append(append([]string{}, base...), extra...) {
+		if seen[value] || exclude[value] {
+			continue
+		}
+		seen[value] = true
+		union = append(union, value)
+	}
+	return union
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/validation"
+)
+
+func TestMergeWorkItems(t *testing.T) {
+	type paths struct{ source, target, dependent, other string }
+	setup := func(t *testing.T) paths {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		p := paths{
+			target:    writeTestWorkItem(t, "1_todo", "001", "Fix login", "todo", "bug"),
+			source:    writeTestWorkItem(t, "1_todo", "002", "Login broken", "todo", "bug"),
+			dependent: writeTestWorkItem(t, "1_todo", "003", "Ship release", "todo", "task"),
+			other:     writeTestWorkItem(t, "1_todo", "004", "Unrelated", "todo", "task"),
+		}
+		writeTestWorkItem(t, "1_todo", "005", "Prerequisite", "todo", "task")
+		setRawFrontMatterField(t, p.target, "tags", "[auth, frontend]")
+		setRawFrontMatterField(t, p.source, "tags", "[backend, auth]")
+		setRawFrontMatterField(t, p.source, "depends_on", "[005, 001]")
+		setRawFrontMatterField(t, p.dependent, "depends_on", "[002, 004]")
+		setRawFrontMatterField(t, p.other, "depends_on", "[005]")
+		return p
+	}
+	parse := func(t *testing.T, path string) *validation.WorkItem {
+		t.Helper()
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		return item
+	}
+
+	t.Run("unions tags, repoints references, and removes the source", func(t *testing.T) {
+		p := setup(t)
+
+		require.NoError(t, mergeWorkItems(&bytes.Buffer{}, newTestConfig(), "002", "001", false, true))
+
+		target := parse(t, p.target)
+		assert.Equal(t, []string{"auth", "frontend", "backend"}, fieldValues(target, "tags"))
+		assert.Equal(t, validation.IDList{"005"}, target.DependsOn)
+		assert.Contains(t, target.Body, "# Fix login\n\n## Merged from 002: Login broken\n\n# Login broken\n")
+
+		assert.Equal(t, validation.IDList{"001", "004"}, parse(t, p.dependent).DependsOn)
+		assert.Equal(t, validation.IDList{"005"}, parse(t, p.other).DependsOn)
+		assert.NoFileExists(t, p.source)
+	})
+
+	t.Run("is undone as a whole", func(t *testing.T) {
+		p := setup(t)
+		before := make(map[string]string)
+		for _, path := range []string{p.source, p.target, p.dependent, p.other} {
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			before[path] = string(content)
+		}
+
+		require.NoError(t, mergeWorkItems(&bytes.Buffer{}, newTestConfig(), "002", "001", false, true))
+		op, err := undoLastOperation(newTestConfig())
+		require.NoError(t, err)
+		assert.Equal(t, operationMerge, op.Kind)
+
+		for path, content := range before {
+			restored, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, content, string(restored), path)
+		}
+		_, err = undoLastOperation(newTestConfig())
+		require.EqualError(t, err, "nothing to undo")
+	})
+
+	t.Run("archives the source with --archive", func(t *testing.T) {
+		p := setup(t)
+
+		require.NoError(t, mergeWorkItems(&bytes.Buffer{}, newTestConfig(), "002", "001", true, true))
+
+		assert.NoFileExists(t, p.source)
+		archived, err := filepath.Glob(filepath.Join(".work", "z_archive", "*", "1_todo", filepath.Base(p.source)))
+		require.NoError(t, err)
+		assert.Len(t, archived, 1)
+	})
+
+	t.Run("prints the plan without writing unless confirmed", func(t *testing.T) {
+		p := setup(t)
+		before, err := os.ReadFile(p.target)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		err = mergeWorkItems(&buf, newTestConfig(), "002", "001", false, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--confirm")
+		assert.Contains(t, buf.String(), "tags: [auth, frontend, backend]\n")
+		assert.Contains(t, buf.String(), "repoint 003: depends_on 002 -> 001\n")
+
+		after, err := os.ReadFile(p.target)
+		require.NoError(t, err)
+		assert.Equal(t, string(before), string(after))
+		assert.FileExists(t, p.source)
+	})
+
+	t.Run("rejects merging an item into itself", func(t *testing.T) {
+		setup(t)
+
+		err := mergeWorkItems(&bytes.Buffer{}, newTestConfig(), "001", "001", false, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "itself")
+	})
+}
@@ -8,44 +8,105 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"kira/internal/config"
+	"kira/internal/validation"
 )
 
 var moveCmd = &cobra.Command{
-	Use:   "move <work-item-id> [target-status]",
-	Short: "Move a work item to a different status folder",
-	Long:  `Moves the work item to the target status folder. Will display options if target status not provided.`,
-	Args:  cobra.RangeArgs(1, 2),
-	RunE: func(_ *cobra.Command, args []string) error {
-		if err := checkWorkDir(); err != nil {
+	Use:   "move <work-item-id>... [target-status]",
+	Short: "Move work items to a different status folder",
+	Long: `Moves the work item to the target status folder. Will display options if target status not provided.
+Several IDs can be moved at once by listing them before the target status, and
+glob patterns such as '00*' move every work item whose ID matches.
+--keep-status moves the file but leaves its status field unchanged, and
+--status-only updates the status field without moving the file; both warn when
+the status and folder no longer match. --to-default moves each item to the
+status configured for its current one under transitions in kira.yml.
+--reason records why the item moved: in its history when track_history is on,
+otherwise in its last_transition_reason field.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
 			return err
 		}
 
-		cfg, err := config.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+		var opts moveOptions
+		opts.KeepStatus, _ = cmd.Flags().GetBool("keep-status")
+		opts.StatusOnly, _ = cmd.Flags().GetBool("status-only")
+		opts.ToDefault, _ = cmd.Flags().GetBool("to-default")
+		opts.Reason, _ = cmd.Flags().GetString("reason")
+		if opts.KeepStatus && opts.StatusOnly {
+			return fmt.Errorf("--keep-status cannot be combined with --status-only")
+		}
+		if opts.KeepStatus && opts.Reason != "" {
+			return fmt.Errorf("--reason cannot be combined with --keep-status")
+		}
+		if opts.ToDefault {
+			if _, isStatus := cfg.StatusFolders[args[len(args)-1]]; isStatus {
+				return fmt.Errorf("--to-default cannot be combined with a target status")
+			}
+			return advanceWorkItems(cfg, args, opts)
 		}
 
-		workItemID := args[0]
+		idArgs := args
 		var targetStatus string
 		if len(args) > 1 {
-			targetStatus = args[1]
+			idArgs = args[:len(args)-1]
+			targetStatus = args[len(args)-1]
+		}
+
+		if len(idArgs) == 1 && !isIDPattern(idArgs[0]) {
+			return moveWorkItem(cfg, idArgs[0], targetStatus, opts)
+		}
+		if targetStatus == "" {
+			return fmt.Errorf("a target status is required when moving several work items")
 		}
 
-		return moveWorkItem(cfg, workItemID, targetStatus)
+		workItemIDs, err := expandWorkItemIDs(idArgs)
+		if err != nil {
+			return err
+		}
+		return moveWorkItems(cfg, workItemIDs, targetStatus, opts)
 	},
 }
 
-func moveWorkItem(cfg *config.Config, workItemID, targetStatus string) error {
+func init() {
+	moveCmd.Flags().Bool("keep-status", false, "Move the file but leave its status field unchanged")
+	moveCmd.Flags().Bool("status-only", false, "Update the status field without moving the file")
+	moveCmd.Flags().Bool("to-default", false, "Move to the status configured under transitions for the current one")
+	moveCmd.Flags().String("reason", "", "Record why the work item moved")
+}
+
+// moveOptions selects which half of a move to perform: by default both the
+// file and its status field change.
+type moveOptions struct {
+	KeepStatus bool
+	StatusOnly bool
+	// ToDefault picks the target status from the configured transitions.
+	ToDefault bool
+	// Reason is recorded with the status change when set.
+	Reason string
+}
+
+func moveWorkItem(cfg *config.Config, workItemID, targetStatus string, opts moveOptions) error {
 	// Find the work item file
 	workItemPath, err := findWorkItemFile(workItemID)
 	if err != nil {
 		return err
 	}
 
+	if opts.ToDefault {
+		targetStatus, err = defaultTransition(cfg, workItemPath)
+		if err != nil {
+			return fmt.Errorf("cannot advance work item %s: %w", workItemID, err)
+		}
+	}
+
 	// Get target status if not provided
 	if targetStatus == "" {
 		var err error
@@ -55,31 +116,211 @@ func moveWorkItem(cfg *config.Config, workItemID, targetStatus string) error {
 		}
 	}
 
-	// Validate target status
-	if _, exists := cfg.StatusFolders[targetStatus]; !exists {
+	switch {
+	case opts.KeepStatus:
+		return moveWorkItemKeepingStatus(cfg, workItemID, workItemPath, targetStatus)
+	case opts.StatusOnly:
+		if err := setWorkItemStatusInPlace(cfg, workItemPath, targetStatus, opts.Reason); err != nil {
+			return err
+		}
+		infof("Set status of work item %s to %s", workItemID, targetStatus)
+		warnStatusFolderMismatch(cfg, workItemID, workItemPath, targetStatus)
+		return nil
+	}
+
+	if _, err := relocateWorkItem(cfg, workItemPath, targetStatus, opts.Reason); err != nil {
+		return err
+	}
+
+	infof("Moved work item %s to %s", workItemID, targetStatus)
+	return nil
+}
+
+// moveWorkItemKeepingStatus moves a work item file into the folder for
+// targetStatus, leaving its status field as it was.
+func moveWorkItemKeepingStatus(cfg *config.Config, workItemID, workItemPath, targetStatus string) error {
+	targetPath, original, err := moveWorkItemFile(cfg, workItemPath, targetStatus)
+	if err != nil {
+		return err
+	}
+
+	item, err := validation.ParseWorkItemContent(original)
+	if err != nil {
+		return fmt.Errorf("failed to parse work item: %w", err)
+	}
+
+	infof("Moved work item %s to %s, keeping status %s", workItemID, filepath.Dir(targetPath), item.Status)
+	warnStatusFolderMismatch(cfg, workItemID, targetPath, item.Status)
+	return nil
+}
+
+// moveWorkItems moves each listed work item to targetStatus, reporting the
+// outcome per item and continuing past individual failures.
+func moveWorkItems(cfg *config.Config, workItemIDs []string, targetStatus string, opts moveOptions) error {
+	if _, err := config.FolderForStatus(cfg, targetStatus); err != nil {
 		return fmt.Errorf("invalid target status: %s", targetStatus)
 	}
 
-	// Get target folder path
-	targetFolder := filepath.Join(".work", cfg.StatusFolders[targetStatus])
+	return forEachWorkItem(workItemIDs, "move", func(workItemID string) error {
+		return moveWorkItem(cfg, workItemID, targetStatus, opts)
+	})
+}
 
-	// Move the file
-	filename := filepath.Base(workItemPath)
-	targetPath := filepath.Join(targetFolder, filename)
+// checkTransitionAllowed rejects a status change that allowed_transitions does
+// not permit, naming the statuses that are. relocateWorkItem and
+// setWorkItemStatusInPlace call it for every change they make; release and
+// abandon, which rewrite the status themselves, call it for each item first.
+func checkTransitionAllowed(cfg *config.Config, workItemPath, targetStatus string) error {
+	if len(cfg.AllowedTransitions) == 0 {
+		return nil
+	}
+	item, err := validation.ParseWorkItemFile(workItemPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse work item: %w", err)
+	}
+
+	allowed, restricted := cfg.AllowedTransitions[item.Status]
+	if !restricted || item.Status == targetStatus {
+		return nil
+	}
+	for _, status := range allowed {
+		if status == targetStatus {
+			return nil
+		}
+	}
+	if len(allowed) == 0 {
+		return fmt.Errorf("cannot move work item %s: moving from %s is not allowed", item.ID, item.Status)
+	}
+	return fmt.Errorf("cannot move work item %s: moving from %s to %s is not allowed (allowed: %s)", item.ID, item.Status, targetStatus, strings.Join(allowed, ", "))
+}
+
+// relocateWorkItem moves a work item file into the folder for targetStatus and
+// updates its status field, returning the new path. A non-empty reason is
+// recorded with the change.
+func relocateWorkItem(cfg *config.Config, workItemPath, targetStatus, reason string) (string, error) {
+	if err := checkTransitionAllowed(cfg, workItemPath, targetStatus); err != nil {
+		return "", err
+	}
 
-	if err := os.Rename(workItemPath, targetPath); err != nil {
-		return fmt.Errorf("failed to move work item: %w", err)
+	targetPath, original, err := moveWorkItemFile(cfg, workItemPath, targetStatus)
+	if err != nil {
+		return "", err
+	}
+
+	previousStatus := ""
+	if cfg.TrackHistory {
+		item, err := validation.ParseWorkItemContent(original)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse work item: %w", err)
+		}
+		previousStatus = item.Status
 	}
 
 	// Update the status in the file
 	if err := updateWorkItemStatus(targetPath, targetStatus); err != nil {
-		return fmt.Errorf("failed to update work item status: %w", err)
+		return "", fmt.Errorf("failed to update work item status: %w", err)
+	}
+
+	if err := recordTransition(cfg, targetPath, previousStatus, targetStatus, reason); err != nil {
+		return "", err
+	}
+	return targetPath, nil
+}
+
+// recordTransition notes a status change in the work item's history when
+// track_history is on. A reason goes into that history entry, or into the
+// last_transition_reason field when there is no history.
+func recordTransition(cfg *config.Config, workItemPath, from, to, reason string) error {
+	if !cfg.TrackHistory {
+		if reason == "" {
+			return nil
+		}
+		if err := setFrontMatterField(workItemPath, "last_transition_reason", reason); err != nil {
+			return fmt.Errorf("failed to record reason: %w", err)
+		}
+		return nil
 	}
 
-	fmt.Printf("Moved work item %s to %s\n", workItemID, targetStatus)
+	entry := fmt.Sprintf("moved %s -> %s", from, to)
+	if reason != "" {
+		entry += fmt.Sprintf(" (reason: %s)", reason)
+	}
+	if err := appendHistoryEntry(workItemPath, entry, time.Now()); err != nil {
+		return fmt.Errorf("failed to record history: %w", err)
+	}
 	return nil
 }
 
+// moveWorkItemFile moves a work item file into the folder for targetStatus
+// without touching its content, returning the new path and the original content.
+// depends_on references to the item by path or filename become its ID.
+func moveWorkItemFile(cfg *config.Config, workItemPath, targetStatus string) (string, []byte, error) {
+	// Validate target status
+	statusFolder, err := config.FolderForStatus(cfg, targetStatus)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid target status: %s", targetStatus)
+	}
+
+	original, err := safeReadFile(workItemPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read work item: %w", err)
+	}
+
+	// Move the file
+	targetPath := filepath.Join(".work", statusFolder, filepath.Base(workItemPath))
+	rename := os.Rename
+	if cfg.UseGitMv {
+		rename = gitMove
+	}
+	if err := rename(workItemPath, targetPath); err != nil {
+		return "", nil, fmt.Errorf("failed to move work item: %w", err)
+	}
+	debugf("Renamed %s to %s", workItemPath, targetPath)
+
+	recordOperation(cfg, operation{Kind: operationMove, Source: workItemPath, Dest: targetPath, Content: string(original)})
+
+	// Items that point at this one by path or filename would lose it now that
+	// it has moved; keep them pointing at its ID instead.
+	if item, err := validation.ParseWorkItemContent(original); err == nil && item.ID != "" {
+		if err := migrateDependencyReferences(item.ID, workItemPath, targetPath); err != nil {
+			return "", nil, err
+		}
+	}
+	return targetPath, original, nil
+}
+
+// setWorkItemStatusInPlace updates a work item's status field without moving
+// its file.
+func setWorkItemStatusInPlace(cfg *config.Config, workItemPath, targetStatus, reason string) error {
+	if _, err := config.FolderForStatus(cfg, targetStatus); err != nil {
+		return fmt.Errorf("invalid target status: %s", targetStatus)
+	}
+
+	if err := checkTransitionAllowed(cfg, workItemPath, targetStatus); err != nil {
+		return err
+	}
+	item, err := validation.ParseWorkItemFile(workItemPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse work item: %w", err)
+	}
+
+	if err := updateWorkItemStatus(workItemPath, targetStatus); err != nil {
+		return fmt.Errorf("failed to update work item status: %w", err)
+	}
+
+	return recordTransition(cfg, workItemPath, item.Status, targetStatus, reason)
+}
+
+// warnStatusFolderMismatch warns when a work item's status no longer matches
+// the folder its file is in.
+func warnStatusFolderMismatch(cfg *config.Config, workItemID, path, status string) {
+	folderStatus, ok := config.StatusForFolder(cfg, filepath.Dir(path))
+	if ok && folderStatus == status {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: work item %s has status %s but is in %s\n", workItemID, status, filepath.Dir(path))
+}
+
 func selectTargetStatus(cfg *config.Config) (string, error) {
 	fmt.Println("Available statuses:")
 	var statuses []string
@@ -0,0 +1,335 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/config"
+	"kira/internal/validation"
+)
+
+func TestMoveWorkItems(t *testing.T) {
+	t.Run("moves every listed item", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+		writeTestWorkItem(t, "1_todo", "002", "Second", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+
+		require.NoError(t, moveWorkItems(newTestConfig(), []string{"001", "002"}, "doing", moveOptions{}))
+
+		assert.FileExists(t, ".work/2_doing/001-first.task.md")
+		assert.FileExists(t, ".work/2_doing/002-second.task.md")
+	})
+
+	t.Run("continues past invalid IDs and reports them", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+		writeTestWorkItem(t, "1_todo", "003", "Third", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+
+		err := moveWorkItems(newTestConfig(), []string{"001", "002", "003", "999"}, "doing", moveOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to move 2 of 4 work items: 002, 999")
+
+		assert.FileExists(t, ".work/2_doing/001-first.task.md")
+		assert.FileExists(t, ".work/2_doing/003-third.task.md")
+	})
+
+	t.Run("rejects an invalid target status before moving anything", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+
+		err := moveWorkItems(newTestConfig(), []string{"001", "002"}, "nowhere", moveOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid target status: nowhere")
+		assert.FileExists(t, ".work/1_todo/001-first.task.md")
+	})
+}
+
+func TestMoveWorkItemCustomFolders(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	cfg := newTestConfig()
+	cfg.StatusFolders = map[string]string{"todo": "Todo", "doing": "in progress"}
+	writeTestWorkItem(t, "Todo", "001", "Plain Names", "todo", "task")
+	require.NoError(t, os.MkdirAll(".work/in progress", 0o700))
+
+	require.NoError(t, moveWorkItem(cfg, "001", "doing", moveOptions{}))
+
+	assert.FileExists(t, ".work/in progress/001-plain-names.task.md")
+}
+
+func TestMoveWorkItemPartialModes(t *testing.T) {
+	t.Run("keep-status moves the file and leaves the status", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+
+		require.NoError(t, moveWorkItem(newTestConfig(), "001", "doing", moveOptions{KeepStatus: true}))
+
+		assert.NoFileExists(t, ".work/1_todo/001-first.task.md")
+		item, err := validation.ParseWorkItemFile(".work/2_doing/001-first.task.md")
+		require.NoError(t, err)
+		assert.Equal(t, "todo", item.Status)
+	})
+
+	t.Run("status-only updates the status and leaves the file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+
+		require.NoError(t, moveWorkItem(newTestConfig(), "001", "doing", moveOptions{StatusOnly: true}))
+
+		item, err := validation.ParseWorkItemFile(".work/1_todo/001-first.task.md")
+		require.NoError(t, err)
+		assert.Equal(t, "doing", item.Status)
+	})
+
+	t.Run("status-only rejects an unknown status", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+
+		err := moveWorkItem(newTestConfig(), "001", "nowhere", moveOptions{StatusOnly: true})
+		assert.EqualError(t, err, "invalid target status: nowhere")
+	})
+}
+
+func TestNestedStatusFolders(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	cfg := newTestConfig()
+	cfg.StatusFolders["doing/blocked"] = "2_doing/blocked"
+	require.NoError(t, config.EnsureStatusFolders(cfg))
+	writeTestWorkItem(t, "2_doing/blocked", "001", "Stuck", "doing/blocked", "task")
+	writeTestWorkItem(t, "2_doing", "002", "Moving", "doing", "task")
+
+	var buf bytes.Buffer
+	opts := listOptions{Filter: workItemFilter{Status: "doing/blocked"}}
+	require.NoError(t, listWorkItems(&buf, cfg, opts))
+	assert.Equal(t, []string{"001"}, listedIDs(buf.String()))
+
+	require.NoError(t, lintWorkItems(cfg, lintOptions{}))
+
+	require.NoError(t, moveWorkItem(cfg, "002", "doing/blocked", moveOptions{}))
+	item, err := validation.ParseWorkItemFile(".work/2_doing/blocked/002-moving.task.md")
+	require.NoError(t, err)
+	assert.Equal(t, "doing/blocked", item.Status)
+
+	require.NoError(t, moveWorkItem(cfg, "001", "doing", moveOptions{}))
+	assert.FileExists(t, ".work/2_doing/001-stuck.task.md")
+
+	require.NoError(t, lintWorkItems(cfg, lintOptions{}))
+}
+
+func TestMoveWorkItemAllowedTransitions(t *testing.T) {
+	newRestrictedConfig := func() *config.Config {
+		cfg := newTestConfig()
+		cfg.AllowedTransitions = map[string][]string{"todo": {"doing", "backlog"}}
+		return cfg
+	}
+
+	t.Run("allows a permitted transition", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Item", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+
+		require.NoError(t, moveWorkItem(newRestrictedConfig(), "001", "doing", moveOptions{}))
+		assert.FileExists(t, ".work/2_doing/001-item.task.md")
+	})
+
+	t.Run("rejects a transition outside the allowed set", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Item", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/4_done", 0o700))
+
+		err := moveWorkItem(newRestrictedConfig(), "001", "done", moveOptions{})
+		assert.EqualError(t, err, "cannot move work item 001: moving from todo to done is not allowed (allowed: doing, backlog)")
+		assert.FileExists(t, ".work/1_todo/001-item.task.md")
+	})
+
+	t.Run("leaves statuses without an entry unrestricted", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "2_doing", "001", "Item", "doing", "task")
+		require.NoError(t, os.MkdirAll(".work/4_done", 0o700))
+
+		require.NoError(t, moveWorkItem(newRestrictedConfig(), "001", "done", moveOptions{}))
+	})
+
+	t.Run("applies to done and reopen", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Item", "todo", "task")
+		writeTestWorkItem(t, "4_done", "002", "Finished", "done", "task")
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+		cfg := newRestrictedConfig()
+		cfg.AllowedTransitions["done"] = []string{"todo"}
+
+		err := markWorkItemDone(cfg, "001", "")
+		assert.EqualError(t, err, "cannot move work item 001: moving from todo to done is not allowed (allowed: doing, backlog)")
+		assert.FileExists(t, ".work/1_todo/001-item.task.md")
+
+		err = reopenWorkItem(cfg, "002", "doing", "")
+		assert.EqualError(t, err, "cannot move work item 002: moving from done to doing is not allowed (allowed: todo)")
+		assert.FileExists(t, ".work/4_done/002-finished.task.md")
+
+		require.NoError(t, reopenWorkItem(cfg, "002", "todo", ""))
+		assert.FileExists(t, ".work/1_todo/002-finished.task.md")
+	})
+
+	t.Run("applies to release and abandon", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := writeTestWorkItem(t, "1_todo", "001", "Item", "todo", "task")
+		cfg := newRestrictedConfig()
+
+		err := releaseWorkItems(cfg, "todo", "")
+		assert.EqualError(t, err, "cannot move work item 001: moving from todo to released is not allowed (allowed: doing, backlog)")
+		err = abandonWorkItems(cfg, "001", "")
+		assert.EqualError(t, err, "cannot move work item 001: moving from todo to abandoned is not allowed (allowed: doing, backlog)")
+
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "todo", item.Status)
+		assert.NoDirExists(t, ".work/z_archive")
+	})
+}
+
+func TestMoveWorkItemUseGitMv(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	git := func(t *testing.T, args ...string) string {
+		t.Helper()
+		output, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, "git %s: %s", strings.Join(args, " "), output)
+		return string(output)
+	}
+
+	t.Run("stages the rename of a tracked item", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		git(t, "init", "-q")
+		git(t, "config", "user.email", "test@example.com")
+		git(t, "config", "user.name", "Test User")
+		writeTestWorkItem(t, "1_todo", "001", "Tracked", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+		git(t, "add", ".")
+		git(t, "commit", "-q", "-m", "Add work item")
+
+		cfg := newTestConfig()
+		cfg.UseGitMv = true
+		require.NoError(t, moveWorkItem(cfg, "001", "doing", moveOptions{}))
+
+		assert.Equal(t, "R100\t.work/1_todo/001-tracked.task.md\t.work/2_doing/001-tracked.task.md\n",
+			git(t, "diff", "--cached", "--name-status", "-M"))
+		item, err := validation.ParseWorkItemFile(".work/2_doing/001-tracked.task.md")
+		require.NoError(t, err)
+		assert.Equal(t, "doing", item.Status)
+	})
+
+	t.Run("renames an untracked item directly", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		git(t, "init", "-q")
+		writeTestWorkItem(t, "1_todo", "001", "Untracked", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+
+		cfg := newTestConfig()
+		cfg.UseGitMv = true
+		require.NoError(t, moveWorkItem(cfg, "001", "doing", moveOptions{}))
+
+		assert.FileExists(t, ".work/2_doing/001-untracked.task.md")
+		assert.Empty(t, git(t, "diff", "--cached", "--name-only"))
+	})
+
+	t.Run("falls back to a rename outside a git repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(tmpDir))
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Plain", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+
+		cfg := newTestConfig()
+		cfg.UseGitMv = true
+		require.NoError(t, moveWorkItem(cfg, "001", "doing", moveOptions{}))
+
+		item, err := validation.ParseWorkItemFile(".work/2_doing/001-plain.task.md")
+		require.NoError(t, err)
+		assert.Equal(t, "doing", item.Status)
+	})
+}
+
+func TestMoveWorkItemRewritesPathReferences(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+	byPath := writeTestWorkItem(t, "1_todo", "002", "Second", "todo", "task")
+	setRawFrontMatterField(t, byPath, "depends_on", "[.work/1_todo/001-first.task.md, 001]")
+	byName := writeTestWorkItem(t, "1_todo", "003", "Third", "todo", "task")
+	setRawFrontMatterField(t, byName, "depends_on", "[001-first.task, 999]")
+	require.NoError(t, os.MkdirAll(".work/4_done", 0o700))
+
+	require.NoError(t, moveWorkItem(newTestConfig(), "001", "done", moveOptions{}))
+
+	second, err := validation.ParseWorkItemFile(byPath)
+	require.NoError(t, err)
+	assert.Equal(t, validation.IDList{"001"}, second.DependsOn)
+	third, err := validation.ParseWorkItemFile(byName)
+	require.NoError(t, err)
+	assert.Equal(t, validation.IDList{"001", "999"}, third.DependsOn)
+
+	// The references now resolve, so 002 is no longer blocked by 001.
+	next, err := nextWorkItem(newTestConfig(), "")
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "002", next.ID)
+}
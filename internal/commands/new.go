@@ -2,48 +2,240 @@ package commands
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"kira/internal/config"
+	"kira/internal/fsutil"
 	"kira/internal/templates"
 	"kira/internal/validation"
 
 	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
 )
 
 var newCmd = &cobra.Command{
 	Use:   "new [template] [status] [title] [description]",
 	Short: "Create a new work item",
 	Long: `Creates a new work item from a template in the specified status folder.
-All arguments are optional - will prompt for selection if not provided.`,
+All arguments are optional - will prompt for selection if not provided.
+With --stdout the rendered item is printed instead of written, and the path it
+would have been written to goes to stderr, so pipelines can store it themselves.`,
 	Args: cobra.MaximumNArgs(4),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := checkWorkDir(); err != nil {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
 			return err
 		}
 
-		cfg, err := config.LoadConfig()
+		interactive, _ := cmd.Flags().GetBool("interactive")
+		inputFlags, _ := cmd.Flags().GetStringArray("input")
+		inputValues, err := parseInputFlags(inputFlags)
 		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+			return err
+		}
+		if inputFile, _ := cmd.Flags().GetString("input-file"); inputFile != "" {
+			fileValues, err := readInputFile(inputFile)
+			if err != nil {
+				return err
+			}
+			inputValues = mergeInputValues(fileValues, inputValues)
 		}
-
-		interactive, _ := cmd.Flags().GetBool("interactive")
-		inputValues, _ := cmd.Flags().GetStringToString("input")
 		helpInputs, _ := cmd.Flags().GetBool("help-inputs")
+		if templateDir, _ := cmd.Flags().GetString("template-dir"); templateDir != "" {
+			cfg.TemplateDir = templateDir
+		}
+		if strict, _ := cmd.Flags().GetBool("strict"); strict {
+			cfg.StrictTemplates = true
+		}
+
+		parsedArgs, err := newWorkItemArgs(cmd, cfg, args)
+		if err != nil {
+			return err
+		}
+
+		bodyFile, _ := cmd.Flags().GetString("body-file")
+		bodyStdin, _ := cmd.Flags().GetBool("body-stdin")
+		if bodyStdin && interactive {
+			return fmt.Errorf("--body-stdin cannot be combined with --interactive")
+		}
+		parsedArgs.body, err = readBodyInput(bodyFile, bodyStdin, os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		parsedArgs.open, _ = cmd.Flags().GetBool("open")
+		parsedArgs.gitAdd, _ = cmd.Flags().GetBool("git-add")
+		parsedArgs.stdout, _ = cmd.Flags().GetBool("stdout")
+		if parsedArgs.stdout && (interactive || parsedArgs.open || parsedArgs.gitAdd) {
+			return fmt.Errorf("--stdout cannot be combined with --interactive, --open, or --git-add")
+		}
 
-		return createWorkItem(cfg, args, interactive, inputValues, helpInputs)
+		if from, _ := cmd.Flags().GetString("from"); from != "" {
+			parsedArgs, inputValues, err = cloneWorkItemArgs(from, parsedArgs, inputValues)
+			if err != nil {
+				return err
+			}
+		}
+
+		return createParsedWorkItem(cfg, parsedArgs, interactive, inputValues, helpInputs)
 	},
 }
 
+// newWorkItemArgs resolves the template, status, title, and description from
+// explicit flags and positional arguments.
+func newWorkItemArgs(cmd *cobra.Command, cfg *config.Config, args []string) (workItemArgs, error) {
+	flagArgs := workItemArgs{}
+	flagArgs.template, _ = cmd.Flags().GetString("template")
+	flagArgs.status, _ = cmd.Flags().GetString("status")
+	flagArgs.title, _ = cmd.Flags().GetString("title")
+	flagArgs.description, _ = cmd.Flags().GetString("description")
+	if flagArgs == (workItemArgs{}) {
+		return parseWorkItemArgs(cfg, args)
+	}
+	if !cfg.DescriptionFromArgs() && len(args) > 2 {
+		return workItemArgs{}, fmt.Errorf("too many arguments: positional_description is false, so positional arguments are [template] [title]; use --description for the description")
+	}
+	return mergeWorkItemFlags(args, flagArgs)
+}
+
+// readBodyInput returns the body supplied with --body-file or --body-stdin, or
+// an empty string when neither is set.
+func readBodyInput(bodyFile string, bodyStdin bool, stdin io.Reader) (string, error) {
+	switch {
+	case bodyFile != "" && bodyStdin:
+		return "", fmt.Errorf("--body-file and --body-stdin cannot be used together")
+	case bodyFile != "":
+		// #nosec G304 - the body file is explicitly chosen by the user
+		content, err := os.ReadFile(filepath.Clean(bodyFile))
+		if err != nil {
+			return "", fmt.Errorf("failed to read body file: %w", err)
+		}
+		return string(content), nil
+	case bodyStdin:
+		content, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read body from stdin: %w", err)
+		}
+		return string(content), nil
+	default:
+		return "", nil
+	}
+}
+
+// readInputFile loads input values from a YAML or JSON object. Values are kept
+// as written, so an ID such as 007 is not turned into a number.
+func readInputFile(path string) (map[string]string, error) {
+	// #nosec G304 - the input file is explicitly chosen by the user
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse input file %s: %w", path, err)
+	}
+	values := make(map[string]string)
+	if len(doc.Content) == 0 {
+		return values, nil
+	}
+
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("input file %s must contain a map of input names to values", path)
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		name, value := mapping.Content[i].Value, mapping.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("input %s in %s must be a single value", name, path)
+		}
+		if value.Tag == "!!null" {
+			values[name] = ""
+			continue
+		}
+		values[name] = value.Value
+	}
+	return values, nil
+}
+
+// mergeInputValues combines input values from a file with --input flags, the
+// flags winning for inputs set in both.
+func mergeInputValues(fileValues, flagValues map[string]string) map[string]string {
+	merged := make(map[string]string, len(fileValues)+len(flagValues))
+	for k, v := range fileValues {
+		merged[k] = v
+	}
+	for k, v := range flagValues {
+		merged[k] = v
+	}
+	return merged
+}
+
+// cloneFieldsSkipped lists the front matter fields --from does not copy: the
+// new item gets a fresh ID, creation date, and status, and the title and kind
+// seed the title and template instead of inputs.
+var cloneFieldsSkipped = map[string]bool{"id": true, "created": true, "status": true, "title": true, "kind": true}
+
+// cloneWorkItemArgs seeds a new work item from the item with the given ID. The
+// source's kind, title, and body are used where args leave them unset, and its
+// other front matter fields become input values that inputValues override.
+func cloneWorkItemArgs(id string, args workItemArgs, inputValues map[string]string) (workItemArgs, map[string]string, error) {
+	sourcePath, err := findWorkItemFile(id)
+	if err != nil {
+		return args, nil, err
+	}
+	source, err := validation.ParseWorkItemFile(sourcePath)
+	if err != nil {
+		return args, nil, fmt.Errorf("failed to parse work item %s: %w", id, err)
+	}
+
+	if args.template == "" {
+		args.template = source.Kind
+	}
+	if args.title == "" {
+		args.title = source.Title
+	}
+	if args.body == "" {
+		args.body = source.Body
+	}
+
+	seeded := make(map[string]string, len(source.Fields))
+	for name := range source.Fields {
+		if cloneFieldsSkipped[name] || (name == "description" && args.description != "") {
+			continue
+		}
+		seeded[name] = source.Field(name)
+	}
+	return args, mergeInputValues(seeded, inputValues), nil
+}
+
 func init() {
 	newCmd.Flags().BoolP("interactive", "I", false, "Enable interactive input prompts for missing template fields")
-	newCmd.Flags().StringToStringP("input", "i", nil, "Provide input values directly (e.g., --input due=2025-10-01)")
+	newCmd.Flags().StringArrayP("input", "i", nil, "Provide input values directly (e.g., --input due=2025-10-01); repeatable")
+	newCmd.Flags().String("input-file", "", "Read input values from a YAML or JSON file; --input values take precedence")
 	newCmd.Flags().Bool("help-inputs", false, "List available input variables for a template")
+	newCmd.Flags().String("template-dir", "", "Directory template paths are resolved against (overrides template_dir in config)")
+	newCmd.Flags().Bool("strict", false, "Fail when a template input has no value instead of using its default (overrides strict_templates in config)")
+	newCmd.Flags().String("template", "", "Template to use (disables positional argument guessing)")
+	newCmd.Flags().String("status", "", "Initial status (disables positional argument guessing)")
+	newCmd.Flags().String("title", "", "Work item title (disables positional argument guessing)")
+	newCmd.Flags().String("description", "", "Work item description (disables positional argument guessing)")
+	newCmd.Flags().String("body-file", "", "Read the work item body from a file, replacing the template body")
+	newCmd.Flags().Bool("body-stdin", false, "Read the work item body from stdin, replacing the template body")
+	newCmd.Flags().Bool("git-add", false, "Stage the new work item with git add (skipped outside a git repository)")
+	newCmd.Flags().Bool("open", false, "Open the new work item in $VISUAL or $EDITOR after creating it")
+	newCmd.Flags().Bool("stdout", false, "Print the work item instead of writing it, and its path to stderr")
+	_ = newCmd.MarkFlagFilename("input-file", "yml", "yaml", "json")
+	_ = newCmd.MarkFlagFilename("body-file")
+	newCmd.Flags().String("from", "", "Copy inputs and body from an existing work item; flags and arguments override them")
 }
 
 func createWorkItem(cfg *config.Config, args []string, interactive bool, inputValues map[string]string, helpInputs bool) error {
@@ -52,6 +244,10 @@ func createWorkItem(cfg *config.Config, args []string, interactive bool, inputVa
 		return err
 	}
 
+	return createParsedWorkItem(cfg, parsedArgs, interactive, inputValues, helpInputs)
+}
+
+func createParsedWorkItem(cfg *config.Config, parsedArgs workItemArgs, interactive bool, inputValues map[string]string, helpInputs bool) error {
 	template, err := resolveTemplate(cfg, parsedArgs.template, helpInputs)
 	if err != nil {
 		return err
@@ -66,12 +262,22 @@ func createWorkItem(cfg *config.Config, args []string, interactive bool, inputVa
 		return err
 	}
 
-	status, err := resolveStatus(cfg, parsedArgs.status)
+	status := parsedArgs.status
+	if status == "" {
+		if status, err = templateDefaultStatus(cfg, template); err != nil {
+			return err
+		}
+	}
+	status, err = resolveStatus(cfg, status)
 	if err != nil {
 		return err
 	}
 
-	nextID, err := validation.GetNextID()
+	if err := checkStatusTemplate(cfg, status, template); err != nil {
+		return err
+	}
+
+	nextID, err := nextWorkItemID(cfg.IDPrefixPerKind[template])
 	if err != nil {
 		return fmt.Errorf("failed to get next ID: %w", err)
 	}
@@ -81,7 +287,29 @@ func createWorkItem(cfg *config.Config, args []string, interactive bool, inputVa
 		return err
 	}
 
-	return writeWorkItemFile(cfg, template, nextID, title, status, inputs)
+	if err := validateInputs(cfg, template, inputs); err != nil {
+		return err
+	}
+
+	if parsedArgs.stdout {
+		return printWorkItem(os.Stdout, os.Stderr, cfg, template, nextID, title, status, inputs, parsedArgs.body)
+	}
+
+	path, err := writeNewWorkItemFile(cfg, template, nextID, title, status, inputs, parsedArgs.body)
+	if err != nil {
+		return err
+	}
+
+	if parsedArgs.gitAdd || cfg.AutoGitAdd {
+		if err := gitAdd(path); err != nil {
+			return err
+		}
+	}
+
+	if parsedArgs.open {
+		return openInEditor(path)
+	}
+	return nil
 }
 
 type workItemArgs struct {
@@ -89,11 +317,23 @@ type workItemArgs struct {
 	title       string
 	status      string
 	description string
+	// body, when set, replaces everything after the template's front matter.
+	body string
+	// open launches the editor on the new work item once it is written.
+	open bool
+	// gitAdd stages the new work item with git add.
+	gitAdd bool
+	// stdout prints the work item instead of writing it, and its path to stderr.
+	stdout bool
 }
 
 func parseWorkItemArgs(cfg *config.Config, args []string) (workItemArgs, error) {
 	var result workItemArgs
 
+	if !cfg.DescriptionFromArgs() && len(args) > 3 {
+		return result, fmt.Errorf("too many arguments: positional_description is false, so positional arguments are [template] [status] [title]; use --description for the description")
+	}
+
 	if len(args) > 0 {
 		result.template = args[0]
 	}
@@ -122,6 +362,36 @@ func parseWorkItemArgs(cfg *config.Config, args []string) (workItemArgs, error)
 	return result, nil
 }
 
+// mergeWorkItemFlags combines explicit --template/--status/--title/--description
+// flags with positional arguments. When any flag is set, positionals are read
+// strictly as [template] [title] [description] with no status guessing, and a
+// positional that disagrees with the flag for the same field is an error.
+func mergeWorkItemFlags(args []string, flags workItemArgs) (workItemArgs, error) {
+	result := flags
+	slots := []struct {
+		name  string
+		field *string
+	}{
+		{"template", &result.template},
+		{"title", &result.title},
+		{"description", &result.description},
+	}
+
+	if len(args) > len(slots) {
+		return result, fmt.Errorf("too many arguments: with --template, --status, --title, or --description, positional arguments are [template] [title] [description]")
+	}
+
+	for i, arg := range args {
+		slot := slots[i]
+		if *slot.field != "" && *slot.field != arg {
+			return result, fmt.Errorf("conflicting %s: argument '%s' and --%s '%s'", slot.name, arg, slot.name, *slot.field)
+		}
+		*slot.field = arg
+	}
+
+	return result, nil
+}
+
 func buildStatusSet(cfg *config.Config) map[string]struct{} {
 	statusSet := make(map[string]struct{}, len(cfg.StatusFolders))
 	for s := range cfg.StatusFolders {
@@ -177,19 +447,50 @@ func resolveStatus(cfg *config.Config, status string) (string, error) {
 	if status == "" {
 		status = cfg.DefaultStatus
 	}
-	if _, ok := cfg.StatusFolders[status]; !ok {
+	if _, err := config.FolderForStatus(cfg, status); err != nil {
 		validStatuses := buildValidStatuses(cfg)
 		return "", fmt.Errorf("invalid status '%s' (valid: %s)", status, strings.Join(validStatuses, ", "))
 	}
 	return status, nil
 }
 
+// templateDefaultStatus returns the default_status declared in the template's
+// meta block, or "" when it declares none.
+func templateDefaultStatus(cfg *config.Config, template string) (string, error) {
+	meta, err := templates.GetTemplateMetaWithOptions(templateFilePath(cfg, template), templateOptions(cfg))
+	if err != nil {
+		return "", fmt.Errorf("failed to read template meta: %w", err)
+	}
+	if meta.DefaultStatus == "" {
+		return "", nil
+	}
+	if _, err := config.FolderForStatus(cfg, meta.DefaultStatus); err != nil {
+		return "", fmt.Errorf("template '%s' has invalid default_status '%s' (valid: %s)", template, meta.DefaultStatus, strings.Join(buildValidStatuses(cfg), ", "))
+	}
+	return meta.DefaultStatus, nil
+}
+
+// checkStatusTemplate enforces status_templates: when status lists allowed
+// templates, template must be one of them.
+func checkStatusTemplate(cfg *config.Config, status, template string) error {
+	allowed, restricted := cfg.StatusTemplates[status]
+	if !restricted {
+		return nil
+	}
+	for _, name := range allowed {
+		if name == template {
+			return nil
+		}
+	}
+	return fmt.Errorf("template '%s' cannot be created in status '%s' (allowed: %s)", template, status, strings.Join(allowed, ", "))
+}
+
 func collectInputs(cfg *config.Config, template, nextID, title, status, description string, inputValues map[string]string, interactive bool) (map[string]string, error) {
 	inputs := make(map[string]string)
 	inputs["id"] = nextID
 	inputs["title"] = title
 	inputs["status"] = status
-	inputs["created"] = time.Now().Format("2006-01-02")
+	inputs["created"] = cfg.FormatTimestamp(time.Now())
 
 	if description != "" {
 		if _, exists := inputValues["description"]; !exists {
@@ -211,64 +512,318 @@ func collectInputs(cfg *config.Config, template, nextID, title, status, descript
 }
 
 func collectInteractiveInputs(cfg *config.Config, template string, inputs map[string]string) error {
-	templatePath := filepath.Join(".work", cfg.Templates[template])
-	templateInputs, err := templates.GetTemplateInputs(templatePath)
+	templateInputs, err := templates.GetTemplateInputsWithOptions(templateFilePath(cfg, template), templateOptions(cfg))
 	if err != nil {
 		return fmt.Errorf("failed to get template inputs: %w", err)
 	}
 
+	// Ask unconditional inputs first so the fields that show_if conditions
+	// refer to are known by the time those conditions are checked.
+	sort.SliceStable(templateInputs, func(i, j int) bool {
+		return templateInputs[i].ShowIf == "" && templateInputs[j].ShowIf != ""
+	})
+
+	history := map[string]string{}
+	if cfg.RememberInputs {
+		if history, err = loadInputHistory(); err != nil {
+			return err
+		}
+	}
+
+	prompted := make(map[string]string)
 	for _, input := range templateInputs {
+		if templates.IsDerived(input.Name) || !input.Visible(conditionValues(template, inputs)) {
+			continue
+		}
 		if _, exists := inputs[input.Name]; !exists {
-			value, err := promptForInput(input)
+			// Secret values are neither offered from nor saved to the history.
+			defaultValue := history[input.Name]
+			if input.Secret {
+				defaultValue = ""
+			}
+			value, err := promptForInput(input, defaultValue)
 			if err != nil {
 				return err
 			}
 			inputs[input.Name] = value
+			if !input.Secret {
+				prompted[input.Name] = value
+			}
+		}
+	}
+
+	if cfg.RememberInputs && len(prompted) > 0 {
+		return saveInputHistory(cfg, prompted)
+	}
+	return nil
+}
+
+// validateInputs checks provided values against the constraints declared by the template's inputs.
+func validateInputs(cfg *config.Config, template string, inputs map[string]string) error {
+	templateInputs, err := templates.GetTemplateInputsWithOptions(templateFilePath(cfg, template), templateOptions(cfg))
+	if err != nil {
+		return fmt.Errorf("failed to get template inputs: %w", err)
+	}
+
+	for _, input := range templateInputs {
+		value, exists := inputs[input.Name]
+		if !exists || !input.Visible(conditionValues(template, inputs)) {
+			continue
+		}
+		checked := value
+		if input.Name == "id" {
+			checked = trimIDPrefix(cfg, value)
+		}
+		if err := input.Validate(checked); err != nil {
+			return fmt.Errorf("invalid input: %w", err)
 		}
+		inputs[input.Name] = input.Normalize(value)
 	}
 	return nil
 }
 
-func writeWorkItemFile(cfg *config.Config, template, nextID, title, status string, inputs map[string]string) error {
-	templatePath := filepath.Join(".work", cfg.Templates[template])
-	content, err := templates.ProcessTemplate(templatePath, inputs)
+// trimIDPrefix returns the number of an ID that carries one of the configured
+// id_prefix_per_kind prefixes, so PRD-001 is checked against a number input
+// as 001.
+func trimIDPrefix(cfg *config.Config, id string) string {
+	for _, prefix := range cfg.IDPrefixPerKind {
+		if number, found := strings.CutPrefix(id, prefix+"-"); found {
+			return number
+		}
+	}
+	return id
+}
+
+// conditionValues returns the values show_if conditions are evaluated against:
+// the inputs, plus kind set to the template name unless an input provides it.
+func conditionValues(template string, inputs map[string]string) map[string]string {
+	if _, exists := inputs["kind"]; exists {
+		return inputs
+	}
+	values := make(map[string]string, len(inputs)+1)
+	for k, v := range inputs {
+		values[k] = v
+	}
+	values["kind"] = template
+	return values
+}
+
+// templateFilePath resolves a configured template name against the template directory.
+func templateFilePath(cfg *config.Config, template string) string {
+	return filepath.Join(cfg.TemplateDir, cfg.Templates[template])
+}
+
+func templateOptions(cfg *config.Config) templates.Options {
+	opts := templates.Options{Dir: cfg.TemplateDir, Strict: cfg.StrictTemplates}
+	if len(cfg.TemplateDelimiters) == 2 {
+		opts.Delimiters = [2]string{cfg.TemplateDelimiters[0], cfg.TemplateDelimiters[1]}
+	}
+	return opts
+}
+
+// nextWorkItemID returns the next free ID for a prefix. Tests replace it to
+// simulate another process taking an ID first.
+var nextWorkItemID = validation.GetNextIDWithPrefix
+
+// maxIDAttempts is how many IDs kira new tries before giving up when each one
+// is taken by a file created in the meantime.
+const maxIDAttempts = 5
+
+// errWorkItemExists reports that a new work item's file was already there,
+// usually because another process took the same ID.
+var errWorkItemExists = errors.New("work item file already exists")
+
+// writeNewWorkItemFile writes the work item while holding the workspace lock,
+// fetching its ID again under the lock so concurrent runs cannot share one.
+// inputs["id"] follows the new ID unless it was set explicitly. If the file
+// turns out to exist already, the next free ID is tried.
+func writeNewWorkItemFile(cfg *config.Config, template, provisionalID, title, status string, inputs map[string]string, body string) (string, error) {
+	unlock, err := lockWorkspace()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	for attempt := 1; ; attempt++ {
+		nextID, err := nextWorkItemID(cfg.IDPrefixPerKind[template])
+		if err != nil {
+			return "", fmt.Errorf("failed to get next ID: %w", err)
+		}
+		if inputs["id"] == provisionalID {
+			inputs["id"] = nextID
+			provisionalID = nextID
+		}
+
+		path, err := writeWorkItemFile(cfg, template, nextID, title, status, inputs, body)
+		if !errors.Is(err, errWorkItemExists) {
+			return path, err
+		}
+		if attempt == maxIDAttempts {
+			return "", fmt.Errorf("no free ID after %d attempts: %w", maxIDAttempts, err)
+		}
+		debugf("ID %s was taken while creating the work item; retrying", nextID)
+	}
+}
+
+// reserveWorkItemPath creates an empty file at path, failing with
+// errWorkItemExists if one is already there, so that two processes cannot
+// write the same work item.
+func reserveWorkItemPath(path string, perm os.FileMode) error {
+	// #nosec G304 - path is built from the configured status folder and filename format
+	file, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%w: %s", errWorkItemExists, path)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to process template: %w", err)
+		return fmt.Errorf("failed to create work item file: %w", err)
 	}
+	// The umask may have narrowed perm, and the final write keeps the
+	// reserved file's permissions.
+	if err := file.Chmod(perm); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to set permissions on %s: %w", path, err)
+	}
+	return file.Close()
+}
 
-	filename := fmt.Sprintf("%s-%s.%s.md", nextID, kebabCase(title), template)
-	statusFolder, exists := cfg.StatusFolders[status]
-	if !exists || statusFolder == "" {
-		return fmt.Errorf("invalid status folder for status '%s'", status)
+// renderNewWorkItem renders a new work item, returning its content and the
+// status folder and filename it belongs in.
+func renderNewWorkItem(cfg *config.Config, template, nextID, title, status string, inputs map[string]string, body string) (content, statusFolder, filename string, err error) {
+	debugf("Rendering template %s", templateFilePath(cfg, template))
+	content, err = templates.ProcessTemplateWithOptions(templateFilePath(cfg, template), inputs, templateOptions(cfg))
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to process template: %w", err)
+	}
+	if body != "" {
+		content = replaceBody(content, body)
+	}
+
+	filename, err = workItemFilename(cfg.FilenameFormat, nextID, title, template, status, createdDate(inputs["created"]))
+	if err != nil {
+		return "", "", "", err
+	}
+	statusFolder, err = config.FolderForStatus(cfg, status)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid status folder for status '%s'", status)
+	}
+	return content, statusFolder, filename, nil
+}
+
+// printWorkItem writes a rendered work item to w and the path it would be
+// written to on errW, creating no files.
+func printWorkItem(w, errW io.Writer, cfg *config.Config, template, nextID, title, status string, inputs map[string]string, body string) error {
+	content, statusFolder, filename, err := renderNewWorkItem(cfg, template, nextID, title, status, inputs, body)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, content); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(errW, filepath.Join(".work", statusFolder, filename))
+	return err
+}
+
+func writeWorkItemFile(cfg *config.Config, template, nextID, title, status string, inputs map[string]string, body string) (string, error) {
+	content, statusFolder, filename, err := renderNewWorkItem(cfg, template, nextID, title, status, inputs, body)
+	if err != nil {
+		return "", err
 	}
 
 	statusFolderPath := filepath.Join(".work", statusFolder)
-	if err := os.MkdirAll(statusFolderPath, 0o700); err != nil {
-		return fmt.Errorf("failed to create status folder: %w", err)
+	if err := os.MkdirAll(statusFolderPath, cfg.DirPerm()); err != nil {
+		return "", fmt.Errorf("failed to create status folder: %w", err)
 	}
 
 	filePath := filepath.Join(statusFolderPath, filename)
-	if err := os.WriteFile(filePath, []byte(content), 0o600); err != nil {
-		return fmt.Errorf("failed to write work item file: %w", err)
+	if _, err := os.Stat(filePath); err == nil {
+		return "", fmt.Errorf("%w: %s", errWorkItemExists, filePath)
 	}
 
-	fmt.Printf("Created work item %s in %s\n", nextID, statusFolder)
-	return nil
+	hookEnv := workItemHookEnv{ID: nextID, Title: title, Status: status, Kind: template, Path: filePath}
+	if err := runHook(hookPreCreate, cfg.Hooks.PreCreate, hookEnv); err != nil {
+		return "", fmt.Errorf("work item not created: %w", err)
+	}
+
+	if err := reserveWorkItemPath(filePath, cfg.FilePerm()); err != nil {
+		return "", err
+	}
+	if err := fsutil.WriteFile(filePath, []byte(content), cfg.FilePerm()); err != nil {
+		_ = os.Remove(filePath)
+		return "", fmt.Errorf("failed to write work item file: %w", err)
+	}
+
+	infof("Created work item %s in %s", nextID, statusFolder)
+
+	if err := runHook(hookPostCreate, cfg.Hooks.PostCreate, hookEnv); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return filePath, nil
+}
+
+// createdDate returns the day of a created value, which may be a full
+// timestamp, for the {date} filename placeholder.
+func createdDate(created string) string {
+	if t, err := validation.ParseTimestamp(created); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return created
+}
+
+// workItemFilename renders a filename_format for a new work item, using
+// config.DefaultFilenameFormat when format is empty.
+func workItemFilename(format, id, title, kind, status, date string) (string, error) {
+	if err := config.ValidateFilenameFormat(format); err != nil {
+		return "", err
+	}
+	if format == "" {
+		format = config.DefaultFilenameFormat
+	}
+	return strings.NewReplacer(
+		"{id}", id,
+		"{slug}", kebabCase(title),
+		"{kind}", kind,
+		"{template}", kind,
+		"{status}", status,
+		"{date}", date,
+	).Replace(format), nil
+}
+
+// replaceBody keeps the front matter of a rendered template and replaces the
+// rest with body. Content without front matter is replaced entirely.
+func replaceBody(content, body string) string {
+	lines := strings.Split(content, "\n")
+	end := frontMatterEnd(lines)
+	if end < 0 {
+		return body
+	}
+	frontMatter := strings.Join(lines[:end+1], "\n")
+	return frontMatter + "\n\n" + strings.TrimLeft(body, "\n")
 }
 
 func selectTemplate(cfg *config.Config) (string, error) {
-	fmt.Println("Available templates:")
-	var templates []string
+	return promptTemplate(os.Stdout, os.Stdin, cfg)
+}
+
+// promptTemplate lists the configured templates by name, with their
+// descriptions where configured, and reads the chosen number from r.
+func promptTemplate(w io.Writer, r io.Reader, cfg *config.Config) (string, error) {
+	templates := make([]string, 0, len(cfg.Templates))
 	for template := range cfg.Templates {
 		templates = append(templates, template)
 	}
+	sort.Strings(templates)
 
+	fmt.Fprintln(w, "Available templates:")
 	for i, template := range templates {
-		fmt.Printf("%d. %s\n", i+1, template)
+		if description := cfg.TemplateDescriptions[template]; description != "" {
+			fmt.Fprintf(w, "%d. %s - %s\n", i+1, template, description)
+		} else {
+			fmt.Fprintf(w, "%d. %s\n", i+1, template)
+		}
 	}
 
-	fmt.Print("Select template (number): ")
-	reader := bufio.NewReader(os.Stdin)
+	fmt.Fprint(w, "Select template (number): ")
+	reader := bufio.NewReader(r)
 	input, err := reader.ReadString('\n')
 	if err != nil {
 		return "", err
@@ -283,8 +838,7 @@ func selectTemplate(cfg *config.Config) (string, error) {
 }
 
 func showTemplateInputs(cfg *config.Config, template string) error {
-	templatePath := filepath.Join(".work", cfg.Templates[template])
-	inputs, err := templates.GetTemplateInputs(templatePath)
+	inputs, err := templates.GetTemplateInputsWithOptions(templateFilePath(cfg, template), templateOptions(cfg))
 	if err != nil {
 		return fmt.Errorf("failed to get template inputs: %w", err)
 	}
@@ -300,22 +854,54 @@ func showTemplateInputs(cfg *config.Config, template string) error {
 	return nil
 }
 
-func promptForInput(input templates.Input) (string, error) {
+// promptForInput asks for input until the value satisfies the input's declared
+// constraints. A non-empty defaultValue is shown in the prompt and used when the
+// answer is left empty.
+func promptForInput(input templates.Input, defaultValue string) (string, error) {
+	for {
+		value, err := promptInputValue(input, defaultValue)
+		if err != nil {
+			return "", err
+		}
+		if err := input.Validate(value); err != nil {
+			fmt.Printf("Invalid value: %v\n", err)
+			continue
+		}
+		return input.Normalize(value), nil
+	}
+}
+
+func promptInputValue(input templates.Input, defaultValue string) (string, error) {
 	prompt := fmt.Sprintf("Enter %s (%s): ", input.Name, input.Description)
+	if defaultValue != "" {
+		prompt = fmt.Sprintf("Enter %s (%s) [%s]: ", input.Name, input.Description, defaultValue)
+	}
 
+	var value string
+	var err error
 	switch input.Type {
 	case templates.InputString:
+		if input.Secret {
+			value, err = promptSecret(prompt)
+			break
+		}
 		if len(input.Options) > 0 {
-			return promptStringOptions(prompt, input.Options)
+			return promptStringOptions(prompt, input.Options, defaultValue)
 		}
-		return promptString(prompt)
+		value, err = promptString(prompt)
 	case templates.InputNumber:
-		return promptNumber(prompt)
+		return promptNumber(prompt, false, defaultValue)
+	case templates.InputFloat:
+		return promptNumber(prompt, true, defaultValue)
 	case templates.InputDateTime:
-		return promptDateTime(prompt, input.DateFormat)
+		value, err = promptDateTime(prompt, input.DateFormat)
 	default:
-		return promptString(prompt)
+		value, err = promptString(prompt)
+	}
+	if err == nil && value == "" {
+		value = defaultValue
 	}
+	return value, err
 }
 
 func promptString(prompt string) (string, error) {
@@ -328,7 +914,7 @@ func promptString(prompt string) (string, error) {
 	return strings.TrimSpace(input), nil
 }
 
-func promptStringOptions(prompt string, options []string) (string, error) {
+func promptStringOptions(prompt string, options []string, defaultValue string) (string, error) {
 	fmt.Println(prompt)
 	for i, option := range options {
 		fmt.Printf("%d. %s\n", i+1, option)
@@ -341,6 +927,9 @@ func promptStringOptions(prompt string, options []string) (string, error) {
 		return "", err
 	}
 
+	if strings.TrimSpace(input) == "" && defaultValue != "" {
+		return defaultValue, nil
+	}
 	choice, err := strconv.Atoi(strings.TrimSpace(input))
 	if err != nil || choice < 1 || choice > len(options) {
 		return "", fmt.Errorf("invalid option selection")
@@ -349,16 +938,23 @@ func promptStringOptions(prompt string, options []string) (string, error) {
 	return options[choice-1], nil
 }
 
-func promptNumber(prompt string) (string, error) {
+func promptNumber(prompt string, decimal bool, defaultValue string) (string, error) {
 	fmt.Print(prompt)
 	reader := bufio.NewReader(os.Stdin)
 	input, err := reader.ReadString('\n')
 	if err != nil {
 		return "", err
 	}
+	if strings.TrimSpace(input) == "" && defaultValue != "" {
+		return defaultValue, nil
+	}
 
-	// Validate it's a number
-	_, err = strconv.Atoi(strings.TrimSpace(input))
+	// Validate it's a number (integers only unless decimal)
+	if decimal {
+		_, err = strconv.ParseFloat(strings.TrimSpace(input), 64)
+	} else {
+		_, err = strconv.Atoi(strings.TrimSpace(input))
+	}
 	if err != nil {
 		return "", fmt.Errorf("invalid number: %v", err)
 	}
@@ -374,19 +970,9 @@ func promptDateTime(prompt, format string) (string, error) {
 		return "", err
 	}
 
-	// Validate date format
-	_, err = time.Parse(format, strings.TrimSpace(input))
-	if err != nil {
-		return "", fmt.Errorf("invalid date format: %v", err)
-	}
-
 	return strings.TrimSpace(input), nil
 }
 
 func kebabCase(s string) string {
-	// Simple kebab case conversion
-	s = strings.ToLower(s)
-	s = strings.ReplaceAll(s, " ", "-")
-	s = strings.ReplaceAll(s, "_", "-")
-	return s
+	return templates.Slug(s)
 }
@@ -0,0 +1,1001 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/config"
+	"kira/internal/validation"
+)
+
+func TestCreateWorkItemTemplateDir(t *testing.T) {
+	t.Run("resolves templates from a directory outside .work", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+
+		sharedDir := filepath.Join(tmpDir, "shared-templates")
+		require.NoError(t, os.MkdirAll(filepath.Join(sharedDir, "templates"), 0o700))
+		templateContent := `---
+id: <!--input-number:id:"ID"-->
+title: <!--input-string:title:"Title"-->
+status: <!--input-string:status:"Status"-->
+kind: shared
+---
+`
+		require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "templates", "template.shared.md"), []byte(templateContent), 0o600))
+
+		cfg := &config.Config{
+			Templates:     map[string]string{"shared": "templates/template.shared.md"},
+			TemplateDir:   sharedDir,
+			StatusFolders: map[string]string{"todo": "1_todo"},
+			DefaultStatus: "todo",
+		}
+
+		err := createWorkItem(cfg, []string{"shared", "Shared Item"}, false, map[string]string{}, false)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(".work/1_todo/001-shared-item.shared.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "title: Shared Item")
+		assert.Contains(t, string(content), "kind: shared")
+	})
+}
+
+// setupCustomTemplate creates a workspace with a single "custom" template and returns its config.
+func setupCustomTemplate(t *testing.T, templateContent string) *config.Config {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(".work/templates", 0o700))
+	require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+	require.NoError(t, os.WriteFile(".work/templates/template.custom.md", []byte(templateContent), 0o600))
+
+	return &config.Config{
+		Templates:     map[string]string{"custom": "templates/template.custom.md"},
+		TemplateDir:   ".work",
+		StatusFolders: map[string]string{"todo": "1_todo"},
+		DefaultStatus: "todo",
+	}
+}
+
+func TestCreateWorkItemInputValidation(t *testing.T) {
+	templateContent := `---
+id: <!--input-number:id:"ID"-->
+title: <!--input-string:title:"Title"-->
+ticket: <!--input-string:ticket:"Ticket reference" pattern="JIRA-\d+"-->
+---
+`
+
+	t.Run("accepts an --input value matching the pattern", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		err := createWorkItem(cfg, []string{"custom", "Linked"}, false, map[string]string{"ticket": "JIRA-42"}, false)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(".work/1_todo/001-linked.custom.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "ticket: JIRA-42")
+	})
+
+	t.Run("rejects an --input value not matching the pattern", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		err := createWorkItem(cfg, []string{"custom", "Linked"}, false, map[string]string{"ticket": "42"}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `does not match pattern JIRA-\d+`)
+		assert.NoFileExists(t, ".work/1_todo/001-linked.custom.md")
+	})
+}
+
+func TestCreateWorkItemNumberRange(t *testing.T) {
+	templateContent := `---
+id: <!--input-number:id:"ID"-->
+title: <!--input-string:title:"Title"-->
+points: <!--input-number:points:"Story points" min="1" max="10"-->
+---
+`
+
+	t.Run("rejects an --input value above max", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		err := createWorkItem(cfg, []string{"custom", "Big"}, false, map[string]string{"points": "12"}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "value 12 exceeds max 10")
+	})
+
+	t.Run("accepts an in-range --input value", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		err := createWorkItem(cfg, []string{"custom", "Small"}, false, map[string]string{"points": "3"}, false)
+		require.NoError(t, err)
+		assert.FileExists(t, ".work/1_todo/001-small.custom.md")
+	})
+}
+
+func TestMergeWorkItemFlags(t *testing.T) {
+	t.Run("uses flags without positionals", func(t *testing.T) {
+		result, err := mergeWorkItemFlags(nil, workItemArgs{template: "prd", status: "todo", title: "todo"})
+		require.NoError(t, err)
+		assert.Equal(t, workItemArgs{template: "prd", status: "todo", title: "todo"}, result)
+	})
+
+	t.Run("reads positionals as template, title, description", func(t *testing.T) {
+		result, err := mergeWorkItemFlags([]string{"prd", "doing", "Details"}, workItemArgs{status: "todo"})
+		require.NoError(t, err)
+		assert.Equal(t, workItemArgs{template: "prd", status: "todo", title: "doing", description: "Details"}, result)
+	})
+
+	t.Run("fills the template positionally alongside --title", func(t *testing.T) {
+		result, err := mergeWorkItemFlags([]string{"task"}, workItemArgs{title: "Write docs"})
+		require.NoError(t, err)
+		assert.Equal(t, workItemArgs{template: "task", title: "Write docs"}, result)
+	})
+
+	t.Run("errors when a positional conflicts with a flag", func(t *testing.T) {
+		_, err := mergeWorkItemFlags([]string{"prd", "Positional Title"}, workItemArgs{title: "Flag Title"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "conflicting title")
+
+		_, err = mergeWorkItemFlags([]string{"prd"}, workItemArgs{template: "task"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "conflicting template")
+	})
+
+	t.Run("errors on too many positionals", func(t *testing.T) {
+		_, err := mergeWorkItemFlags([]string{"prd", "todo", "Title", "Description"}, workItemArgs{status: "todo"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "too many arguments")
+	})
+
+	t.Run("creates an item whose title is also a status name", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, "---\ntitle: <!--input-string:title:\"Title\"-->\n---\n")
+		parsedArgs, err := mergeWorkItemFlags([]string{"custom"}, workItemArgs{title: "todo"})
+		require.NoError(t, err)
+		require.NoError(t, createParsedWorkItem(cfg, parsedArgs, false, map[string]string{}, false))
+
+		content, err := os.ReadFile(".work/1_todo/001-todo.custom.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "title: todo")
+	})
+}
+
+func TestCreateWorkItemBody(t *testing.T) {
+	templateContent := `---
+title: <!--input-string:title:"Title"-->
+---
+
+# <!--input-string:title:"Title"-->
+
+## Notes
+Template body
+`
+
+	t.Run("replaces the template body with a body file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		require.NoError(t, os.WriteFile("body.md", []byte("# Written Elsewhere\n\nLong description.\n"), 0o600))
+
+		body, err := readBodyInput("body.md", false, strings.NewReader(""))
+		require.NoError(t, err)
+		parsedArgs := workItemArgs{template: "custom", title: "From File", body: body}
+		require.NoError(t, createParsedWorkItem(cfg, parsedArgs, false, map[string]string{}, false))
+
+		content, err := os.ReadFile(".work/1_todo/001-from-file.custom.md")
+		require.NoError(t, err)
+		assert.Equal(t, "---\ntitle: From File\n---\n\n# Written Elsewhere\n\nLong description.\n", string(content))
+	})
+
+	t.Run("reads the body from stdin", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+
+		body, err := readBodyInput("", true, strings.NewReader("Piped in\n"))
+		require.NoError(t, err)
+		parsedArgs := workItemArgs{template: "custom", title: "Piped", body: body}
+		require.NoError(t, createParsedWorkItem(cfg, parsedArgs, false, map[string]string{}, false))
+
+		content, err := os.ReadFile(".work/1_todo/001-piped.custom.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "title: Piped")
+		assert.Contains(t, string(content), "Piped in")
+		assert.NotContains(t, string(content), "Template body")
+	})
+
+	t.Run("keeps the template body when no body is supplied", func(t *testing.T) {
+		body, err := readBodyInput("", false, strings.NewReader("ignored"))
+		require.NoError(t, err)
+		assert.Empty(t, body)
+	})
+
+	t.Run("rejects both body sources together", func(t *testing.T) {
+		_, err := readBodyInput("body.md", true, strings.NewReader(""))
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateWorkItemStatusTemplates(t *testing.T) {
+	templateContent := `---
+title: <!--input-string:title:"Title"-->
+---
+`
+
+	t.Run("creates an allowed template in a restricted status", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		cfg.StatusTemplates = map[string][]string{"todo": {"custom"}}
+
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "todo", "Allowed"}, false, map[string]string{}, false))
+		assert.FileExists(t, ".work/1_todo/001-allowed.custom.md")
+	})
+
+	t.Run("rejects a template not allowed in the status", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		cfg.StatusTemplates = map[string][]string{"todo": {"bug", "task"}}
+
+		err := createWorkItem(cfg, []string{"custom", "todo", "Blocked"}, false, map[string]string{}, false)
+		require.EqualError(t, err, "template 'custom' cannot be created in status 'todo' (allowed: bug, task)")
+		assert.NoFileExists(t, ".work/1_todo/001-blocked.custom.md")
+	})
+}
+
+func TestWorkItemFilename(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", "007-fix-login.issue.md"},
+		{"{id}-{slug}.{template}.md", "007-fix-login.issue.md"},
+		{"{date}-{id}-{slug}.md", "2024-03-05-007-fix-login.md"},
+		{"{kind}-{id}.md", "issue-007.md"},
+		{"{status}_{id}_{slug}.md", "todo_007_fix-login.md"},
+	}
+	for _, tt := range tests {
+		got, err := workItemFilename(tt.format, "007", "Fix Login", "issue", "todo", "2024-03-05")
+		require.NoError(t, err, tt.format)
+		assert.Equal(t, tt.want, got, tt.format)
+	}
+
+	_, err := workItemFilename("{slug}.{kind}.md", "007", "Fix Login", "issue", "todo", "2024-03-05")
+	require.EqualError(t, err, "filename_format '{slug}.{kind}.md' must contain {id}")
+
+	_, err = workItemFilename("{kind}/{id}.md", "007", "Fix Login", "issue", "todo", "2024-03-05")
+	require.Error(t, err)
+}
+
+func TestCreateWorkItemFilenameFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	cfg := setupCustomTemplate(t, "---\ntitle: <!--input-string:title:\"Title\"-->\n---\n")
+	cfg.FilenameFormat = "{kind}-{id}-{slug}.md"
+
+	require.NoError(t, createWorkItem(cfg, []string{"custom", "todo", "Custom Name"}, false, map[string]string{}, false))
+	assert.FileExists(t, ".work/1_todo/custom-001-custom-name.md")
+}
+
+func TestReadInputFile(t *testing.T) {
+	t.Run("reads YAML and JSON input files", func(t *testing.T) {
+		dir := t.TempDir()
+		yamlPath := filepath.Join(dir, "inputs.yml")
+		jsonPath := filepath.Join(dir, "inputs.json")
+		require.NoError(t, os.WriteFile(yamlPath, []byte("points: 3\nticket: 007\nnotes:\n"), 0o600))
+		require.NoError(t, os.WriteFile(jsonPath, []byte(`{"points": 5, "ticket": "JIRA-1"}`), 0o600))
+
+		values, err := readInputFile(yamlPath)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"points": "3", "ticket": "007", "notes": ""}, values)
+
+		values, err = readInputFile(jsonPath)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"points": "5", "ticket": "JIRA-1"}, values)
+	})
+
+	t.Run("rejects nested values", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "inputs.yml")
+		require.NoError(t, os.WriteFile(path, []byte("tags: [a, b]\n"), 0o600))
+
+		_, err := readInputFile(path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "input tags")
+	})
+
+	t.Run("--input values override the file", func(t *testing.T) {
+		merged := mergeInputValues(
+			map[string]string{"points": "3", "ticket": "JIRA-1"},
+			map[string]string{"points": "8"},
+		)
+		assert.Equal(t, map[string]string{"points": "8", "ticket": "JIRA-1"}, merged)
+	})
+}
+
+func TestCreateWorkItemInputFileValidation(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	cfg := setupCustomTemplate(t, `---
+title: <!--input-string:title:"Title"-->
+points: <!--input-number:points:"Story points" min="1" max="10"-->
+---
+`)
+	require.NoError(t, os.WriteFile("inputs.yml", []byte("points: lots\n"), 0o600))
+
+	values, err := readInputFile("inputs.yml")
+	require.NoError(t, err)
+	err = createWorkItem(cfg, []string{"custom", "From File"}, false, values, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid input")
+}
+
+func TestCreateWorkItemShowIf(t *testing.T) {
+	templateContent := `---
+title: <!--input-string:title:"Title"-->
+severity: <!--input-string[low,high]:severity:"Severity" pattern="low|high" show_if="kind==bug"-->
+---
+`
+	setup := func(t *testing.T, stdin string) *config.Config {
+		t.Helper()
+		cfg := setupCustomTemplate(t, templateContent)
+		cfg.Templates["bug"] = "templates/template.custom.md"
+
+		stdinFile, err := os.CreateTemp(t.TempDir(), "stdin")
+		require.NoError(t, err)
+		_, err = stdinFile.WriteString(stdin)
+		require.NoError(t, err)
+		_, err = stdinFile.Seek(0, 0)
+		require.NoError(t, err)
+		original := os.Stdin
+		os.Stdin = stdinFile
+		t.Cleanup(func() {
+			os.Stdin = original
+			_ = stdinFile.Close()
+		})
+		return cfg
+	}
+
+	t.Run("prompts for a conditional input when its condition holds", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setup(t, "2\n")
+		require.NoError(t, createWorkItem(cfg, []string{"bug", "Crash"}, true, map[string]string{}, false))
+
+		content, err := os.ReadFile(".work/1_todo/001-crash.bug.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "severity: high")
+	})
+
+	t.Run("skips a conditional input when its condition is false", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		// No stdin: prompting for severity would fail with EOF.
+		cfg := setup(t, "")
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "Chore"}, true, map[string]string{}, false))
+		assert.FileExists(t, ".work/1_todo/001-chore.custom.md")
+	})
+
+	t.Run("does not validate hidden inputs", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setup(t, "")
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "Chore"}, false, map[string]string{"severity": "urgent"}, false))
+
+		err := createWorkItem(cfg, []string{"bug", "Crash"}, false, map[string]string{"severity": "urgent"}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid input")
+	})
+}
+
+func TestCreateWorkItemDateTimeInputs(t *testing.T) {
+	templateContent := `---
+title: <!--input-string:title:"Title"-->
+launch: <!--input-datetime[Jan 2, 2006]:launch:"Launch date"-->
+---
+`
+
+	t.Run("rejects a --input value in the wrong format", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		err := createWorkItem(cfg, []string{"custom", "Launch"}, false, map[string]string{"launch": "2025-03-04"}, false)
+		require.EqualError(t, err, `invalid input: value "2025-03-04" for launch does not match date format Jan 2, 2006`)
+	})
+
+	t.Run("stores a --input value in normalized form", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "Launch"}, false, map[string]string{"launch": "mar 4, 2025"}, false))
+
+		content, err := os.ReadFile(".work/1_todo/001-launch.custom.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "launch: Mar 4, 2025")
+	})
+
+	t.Run("validates and normalizes a prompted value", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		stdinFile, err := os.CreateTemp(t.TempDir(), "stdin")
+		require.NoError(t, err)
+		_, err = stdinFile.WriteString("MAR 4, 2025\n")
+		require.NoError(t, err)
+		_, err = stdinFile.Seek(0, 0)
+		require.NoError(t, err)
+		original := os.Stdin
+		os.Stdin = stdinFile
+		defer func() { os.Stdin = original }()
+
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "Launch"}, true, map[string]string{}, false))
+
+		content, err := os.ReadFile(".work/1_todo/001-launch.custom.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "launch: Mar 4, 2025")
+	})
+}
+
+func TestCreateWorkItemRememberInputs(t *testing.T) {
+	templateContent := `---
+id: <!--input-number:id:"ID"-->
+title: <!--input-string:title:"Title"-->
+owner: <!--input-string:owner:"Owner"-->
+---
+`
+	useStdin := func(t *testing.T, stdin string) {
+		t.Helper()
+		stdinFile, err := os.CreateTemp(t.TempDir(), "stdin")
+		require.NoError(t, err)
+		_, err = stdinFile.WriteString(stdin)
+		require.NoError(t, err)
+		_, err = stdinFile.Seek(0, 0)
+		require.NoError(t, err)
+		original := os.Stdin
+		os.Stdin = stdinFile
+		t.Cleanup(func() {
+			os.Stdin = original
+			_ = stdinFile.Close()
+		})
+	}
+
+	t.Run("offers the value entered in the previous run", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		cfg.RememberInputs = true
+
+		useStdin(t, "alice\n")
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "First"}, true, map[string]string{}, false))
+
+		history, err := loadInputHistory()
+		require.NoError(t, err)
+		assert.Equal(t, "alice", history["owner"])
+
+		useStdin(t, "\n")
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "Second"}, true, map[string]string{}, false))
+
+		content, err := os.ReadFile(".work/1_todo/002-second.custom.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "owner: alice")
+	})
+
+	t.Run("does not remember values unless enabled", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+
+		useStdin(t, "alice\n")
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "First"}, true, map[string]string{}, false))
+		assert.NoFileExists(t, inputHistoryPath)
+	})
+}
+
+func TestCloneWorkItemArgs(t *testing.T) {
+	templateContent := `---
+id: <!--input-number:id:"ID"-->
+title: <!--input-string:title:"Title"-->
+status: <!--input-string:status:"Status"-->
+kind: custom
+created: <!--input-datetime:created:"Created"-->
+priority: <!--input-string:priority:"Priority"-->
+owner: <!--input-string:owner:"Owner"-->
+---
+
+# Template body
+`
+	writeSource := func(t *testing.T) {
+		t.Helper()
+		content := `---
+id: 001
+title: Source item
+status: todo
+kind: custom
+created: 2024-01-01
+priority: high
+owner: alice
+---
+
+# Source body
+`
+		require.NoError(t, os.WriteFile(".work/1_todo/001-source-item.custom.md", []byte(content), 0o600))
+	}
+
+	t.Run("copies inputs and body from the source item", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		writeSource(t)
+
+		parsedArgs, inputValues, err := cloneWorkItemArgs("001", workItemArgs{}, map[string]string{})
+		require.NoError(t, err)
+		require.NoError(t, createParsedWorkItem(cfg, parsedArgs, false, inputValues, false))
+
+		content, err := os.ReadFile(".work/1_todo/002-source-item.custom.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "id: 002")
+		assert.Contains(t, string(content), "priority: high")
+		assert.Contains(t, string(content), "owner: alice")
+		assert.Contains(t, string(content), "# Source body")
+		assert.NotContains(t, string(content), "created: 2024-01-01")
+	})
+
+	t.Run("applies overriding flags and inputs", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		cfg.StatusFolders["doing"] = "2_doing"
+		writeSource(t)
+
+		parsedArgs, inputValues, err := cloneWorkItemArgs("001",
+			workItemArgs{title: "Copy", status: "doing"},
+			map[string]string{"priority": "low"})
+		require.NoError(t, err)
+		require.NoError(t, createParsedWorkItem(cfg, parsedArgs, false, inputValues, false))
+
+		content, err := os.ReadFile(".work/2_doing/002-copy.custom.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "title: Copy")
+		assert.Contains(t, string(content), "status: doing")
+		assert.Contains(t, string(content), "priority: low")
+		assert.Contains(t, string(content), "owner: alice")
+	})
+
+	t.Run("rejects an unknown source", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		setupCustomTemplate(t, templateContent)
+		_, _, err := cloneWorkItemArgs("042", workItemArgs{}, map[string]string{})
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateWorkItemIDPrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	cfg := setupCustomTemplate(t, "---\nid: <!--input-number:id:\"ID\"-->\ntitle: <!--input-string:title:\"Title\"-->\n---\n")
+	cfg.Templates["bug"] = "templates/template.custom.md"
+	cfg.IDPrefixPerKind = map[string]string{"bug": "BUG"}
+
+	require.NoError(t, createWorkItem(cfg, []string{"bug", "Crash"}, false, map[string]string{}, false))
+	require.NoError(t, createWorkItem(cfg, []string{"custom", "Chore"}, false, map[string]string{}, false))
+	require.NoError(t, createWorkItem(cfg, []string{"bug", "Hang"}, false, map[string]string{}, false))
+
+	content, err := os.ReadFile(".work/1_todo/BUG-001-crash.bug.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "id: BUG-001")
+	assert.FileExists(t, ".work/1_todo/001-chore.custom.md")
+	assert.FileExists(t, ".work/1_todo/BUG-002-hang.bug.md")
+}
+
+func TestParseInputFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags []string
+		want  map[string]string
+	}{
+		{"single pair", []string{"due=2025-10-01"}, map[string]string{"due": "2025-10-01"}},
+		{"equals in value", []string{"link=https://example.com/?a=1&b=2"}, map[string]string{"link": "https://example.com/?a=1&b=2"}},
+		{"commas in value", []string{"tags=api,cli,docs"}, map[string]string{"tags": "api,cli,docs"}},
+		{"several pairs", []string{"a=1,b=2", "c=3"}, map[string]string{"a": "1", "b": "2", "c": "3"}},
+		{"escaped comma", []string{`note=x\,y=z`}, map[string]string{"note": "x,y=z"}},
+		{"quoted value", []string{`note="x,y=z",owner=bob`}, map[string]string{"note": "x,y=z", "owner": "bob"}},
+		{"later flag wins", []string{"a=1", "a=2"}, map[string]string{"a": "2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseInputFlags(tt.flags)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, flag := range []string{"novalue", "=x", `note="open`, `note="x"y`} {
+		t.Run("rejects "+flag, func(t *testing.T) {
+			_, err := parseInputFlags([]string{flag})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPromptTemplate(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Templates = map[string]string{"task": "t.md", "bug": "b.md", "prd": "p.md"}
+	cfg.TemplateDescriptions = map[string]string{"bug": "Something is broken", "prd": "A feature to design"}
+
+	var out bytes.Buffer
+	template, err := promptTemplate(&out, strings.NewReader("2\n"), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "prd", template)
+
+	expected := "Available templates:\n" +
+		"1. bug - Something is broken\n" +
+		"2. prd - A feature to design\n" +
+		"3. task\n" +
+		"Select template (number): "
+	assert.Equal(t, expected, out.String())
+
+	_, err = promptTemplate(&out, strings.NewReader("9\n"), cfg)
+	assert.EqualError(t, err, "invalid template selection")
+}
+
+func TestCreateWorkItemOpen(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	require.NoError(t, os.WriteFile("editor.sh", []byte(`echo "$@" > editor.log`+"\n"), 0o600))
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "sh editor.sh")
+
+	cfg := setupCustomTemplate(t, "---\nid: <!--input-number:id:\"ID\"-->\ntitle: <!--input-string:title:\"Title\"-->\n---\n")
+	args := workItemArgs{template: "custom", title: "Edit me", open: true}
+	require.NoError(t, createParsedWorkItem(cfg, args, false, map[string]string{}, false))
+
+	log, err := os.ReadFile("editor.log")
+	require.NoError(t, err)
+	assert.Equal(t, ".work/1_todo/001-edit-me.custom.md\n", string(log))
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	assert.Equal(t, []string{"vi"}, editorCommand())
+
+	t.Setenv("EDITOR", "code --wait")
+	assert.Equal(t, []string{"code", "--wait"}, editorCommand())
+
+	t.Setenv("VISUAL", "nvim")
+	assert.Equal(t, []string{"nvim"}, editorCommand())
+}
+
+func TestCreateWorkItemGitAdd(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	t.Run("stages the new item in a git repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+		require.NoError(t, exec.Command("git", "init", "-q").Run())
+
+		cfg := setupCustomTemplate(t, "---\nid: <!--input-number:id:\"ID\"-->\ntitle: <!--input-string:title:\"Title\"-->\n---\n")
+		args := workItemArgs{template: "custom", title: "Staged", gitAdd: true}
+		require.NoError(t, createParsedWorkItem(cfg, args, false, map[string]string{}, false))
+
+		staged, err := exec.Command("git", "diff", "--cached", "--name-only").Output()
+		require.NoError(t, err)
+		assert.Equal(t, ".work/1_todo/001-staged.custom.md\n", string(staged))
+	})
+
+	t.Run("auto_git_add stages without the flag", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+		require.NoError(t, exec.Command("git", "init", "-q").Run())
+
+		cfg := setupCustomTemplate(t, "---\nid: <!--input-number:id:\"ID\"-->\ntitle: <!--input-string:title:\"Title\"-->\n---\n")
+		cfg.AutoGitAdd = true
+		require.NoError(t, createParsedWorkItem(cfg, workItemArgs{template: "custom", title: "Auto"}, false, map[string]string{}, false))
+
+		staged, err := exec.Command("git", "diff", "--cached", "--name-only").Output()
+		require.NoError(t, err)
+		assert.Equal(t, ".work/1_todo/001-auto.custom.md\n", string(staged))
+	})
+
+	t.Run("skips silently outside a git repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(tmpDir))
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, "---\nid: <!--input-number:id:\"ID\"-->\ntitle: <!--input-string:title:\"Title\"-->\n---\n")
+		args := workItemArgs{template: "custom", title: "Untracked", gitAdd: true}
+		require.NoError(t, createParsedWorkItem(cfg, args, false, map[string]string{}, false))
+		assert.FileExists(t, ".work/1_todo/001-untracked.custom.md")
+	})
+}
+
+func TestCreateWorkItemTemplateDefaultStatus(t *testing.T) {
+	templateContent := "<!--meta\ndefault_status: doing\n-->\n---\nid: <!--input-number:id:\"ID\"-->\ntitle: <!--input-string:title:\"Title\"-->\nstatus: <!--input-string:status:\"Status\"-->\n---\n"
+	setup := func(t *testing.T, content string) *config.Config {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		cfg := setupCustomTemplate(t, content)
+		cfg.StatusFolders["doing"] = "2_doing"
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+		return cfg
+	}
+
+	t.Run("uses the template's default status", func(t *testing.T) {
+		cfg := setup(t, templateContent)
+
+		require.NoError(t, createParsedWorkItem(cfg, workItemArgs{template: "custom", title: "Started"}, false, map[string]string{}, false))
+
+		item, err := validation.ParseWorkItemFile(".work/2_doing/001-started.custom.md")
+		require.NoError(t, err)
+		assert.Equal(t, "doing", item.Status)
+	})
+
+	t.Run("an explicit status overrides it", func(t *testing.T) {
+		cfg := setup(t, templateContent)
+
+		args := workItemArgs{template: "custom", title: "Queued", status: "todo"}
+		require.NoError(t, createParsedWorkItem(cfg, args, false, map[string]string{}, false))
+
+		assert.FileExists(t, ".work/1_todo/001-queued.custom.md")
+	})
+
+	t.Run("rejects an unknown default status", func(t *testing.T) {
+		cfg := setup(t, strings.Replace(templateContent, "default_status: doing", "default_status: shipped", 1))
+
+		err := createParsedWorkItem(cfg, workItemArgs{template: "custom", title: "Lost"}, false, map[string]string{}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "template 'custom' has invalid default_status 'shipped'")
+	})
+}
+
+func TestCreateWorkItemPositionalDescription(t *testing.T) {
+	templateContent := "---\ntitle: <!--input-string:title:\"Title\"-->\ndescription: <!--input-string:description:\"Description\"-->\n---\n"
+
+	t.Run("reads the fourth positional as the description", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "todo", "Login", "Fix the form"}, false, map[string]string{}, false))
+
+		content, err := os.ReadFile(".work/1_todo/001-login.custom.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "description: Fix the form")
+	})
+
+	t.Run("rejects extra positionals when disabled", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, templateContent)
+		disabled := false
+		cfg.PositionalDescription = &disabled
+
+		err := createWorkItem(cfg, []string{"custom", "todo", "Login", "Fix the form"}, false, map[string]string{}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "positional_description is false")
+		assert.NoFileExists(t, ".work/1_todo/001-login.custom.md")
+
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "todo", "Login"}, false, map[string]string{"description": "Fix the form"}, false))
+		content, err := os.ReadFile(".work/1_todo/001-login.custom.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "description: Fix the form")
+	})
+}
+
+func TestCreateWorkItemFileModes(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	cfg := setupCustomTemplate(t, "---\ntitle: <!--input-string:title:\"Title\"-->\n---\n")
+	cfg.StatusFolders["doing"] = "2_doing"
+	cfg.FileMode = "0640"
+	cfg.DirMode = "0750"
+
+	require.NoError(t, createWorkItem(cfg, []string{"custom", "doing", "Shared"}, false, map[string]string{}, false))
+
+	file, err := os.Stat(".work/2_doing/001-shared.custom.md")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), file.Mode().Perm())
+
+	dir, err := os.Stat(".work/2_doing")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o750), dir.Mode().Perm())
+}
+
+func TestCreateWorkItemIDCollision(t *testing.T) {
+	setup := func(t *testing.T, ids ...string) (*config.Config, *int) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		cfg := setupCustomTemplate(t, "---\nid: <!--input-number:id:\"ID\"-->\ntitle: <!--input-string:title:\"Title\"-->\n---\n")
+		// Another process wrote 001 after the ID was picked.
+		require.NoError(t, os.WriteFile(".work/1_todo/001-racy.custom.md", []byte("taken"), 0o600))
+
+		calls := 0
+		original := nextWorkItemID
+		nextWorkItemID = func(string) (string, error) {
+			id := ids[min(calls, len(ids)-1)]
+			calls++
+			return id, nil
+		}
+		t.Cleanup(func() { nextWorkItemID = original })
+		return cfg, &calls
+	}
+
+	t.Run("retries with a fresh ID", func(t *testing.T) {
+		cfg, calls := setup(t, "001", "001", "002")
+
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "Racy"}, false, map[string]string{}, false))
+
+		// One provisional ID for the inputs, then one per attempt.
+		assert.Equal(t, 3, *calls)
+		content, err := os.ReadFile(".work/1_todo/002-racy.custom.md")
+		require.NoError(t, err)
+		assert.Equal(t, "---\nid: 002\ntitle: Racy\n---\n", string(content))
+		taken, err := os.ReadFile(".work/1_todo/001-racy.custom.md")
+		require.NoError(t, err)
+		assert.Equal(t, "taken", string(taken))
+	})
+
+	t.Run("gives up after repeated collisions", func(t *testing.T) {
+		cfg, calls := setup(t, "001")
+
+		err := createWorkItem(cfg, []string{"custom", "Racy"}, false, map[string]string{}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no free ID after 5 attempts")
+		assert.Equal(t, 1+maxIDAttempts, *calls)
+
+		taken, err := os.ReadFile(".work/1_todo/001-racy.custom.md")
+		require.NoError(t, err)
+		assert.Equal(t, "taken", string(taken))
+	})
+}
+
+func TestPrintWorkItem(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	cfg := setupCustomTemplate(t, "---\nid: <!--input-number:id:\"ID\"-->\ntitle: <!--input-string:title:\"Title\"-->\n---\n")
+	inputs := map[string]string{"id": "001", "title": "Piped"}
+
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, printWorkItem(&stdout, &stderr, cfg, "custom", "001", "Piped", "todo", inputs, ""))
+
+	assert.Equal(t, "---\nid: 001\ntitle: Piped\n---\n", stdout.String())
+	assert.Equal(t, filepath.Join(".work", "1_todo", "001-piped.custom.md")+"\n", stderr.String())
+	entries, err := os.ReadDir(".work/1_todo")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestCreateWorkItemTimestampFormat(t *testing.T) {
+	setup := func(t *testing.T, format string) *config.Config {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		cfg := setupCustomTemplate(t, "---\nid: <!--input-number:id:\"ID\"-->\ntitle: <!--input-string:title:\"Title\"-->\n"+
+			"status: <!--input-string:status:\"Status\"-->\nkind: custom\ncreated: <!--input-datetime[yyyy-mm-dd]:created:\"Created\"-->\n---\n")
+		cfg.FilenameFormat = "{date}-{id}.md"
+		cfg.TimestampFormat = format
+		return cfg
+	}
+	created := func(t *testing.T, path string) string {
+		t.Helper()
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		return item.Created
+	}
+
+	t.Run("records the date by default", func(t *testing.T) {
+		cfg := setup(t, "")
+		before := time.Now()
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "todo", "Dated"}, false, map[string]string{}, false))
+
+		path := filepath.Join(".work", "1_todo", before.Format("2006-01-02")+"-001.md")
+		require.FileExists(t, path)
+		assert.Regexp(t, `^\d{4}-\d{2}-\d{2}$`, created(t, path))
+	})
+
+	t.Run("records a full timestamp with datetime", func(t *testing.T) {
+		cfg := setup(t, config.TimestampDateTime)
+		before := time.Now().Truncate(time.Second)
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "todo", "First"}, false, map[string]string{}, false))
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "todo", "Second"}, false, map[string]string{}, false))
+
+		path := filepath.Join(".work", "1_todo", before.Format("2006-01-02")+"-001.md")
+		require.FileExists(t, path, "the {date} placeholder stays date-only")
+		value := created(t, path)
+		stamp, err := time.Parse(time.RFC3339, value)
+		require.NoError(t, err, value)
+		assert.False(t, stamp.Before(before))
+
+		cfg.Validation.StatusValues = []string{"todo"}
+		result, err := validation.ValidateWorkItems(cfg)
+		require.NoError(t, err)
+		assert.Empty(t, result.Errors)
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, cfg, listOptions{SortBy: sortByCreated}))
+		assert.Equal(t, []string{"001", "002"}, listedIDs(buf.String()))
+	})
+}
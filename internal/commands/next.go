@@ -0,0 +1,117 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+	"kira/internal/validation"
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Suggest the next work item to pick up",
+	Long: `Suggests the oldest actionable work item: statuses are considered in folder order
+up to (but not including) the done status, and items whose depends_on entries are not
+yet done are skipped. Use --status to only consider a single status.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		status, _ := cmd.Flags().GetString("status")
+		if status != "" {
+			if _, err := config.FolderForStatus(cfg, status); err != nil {
+				return fmt.Errorf("invalid status: %s", status)
+			}
+		}
+
+		item, err := nextWorkItem(cfg, status)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			fmt.Println("No actionable work items found.")
+			return nil
+		}
+
+		fmt.Printf("%s %s\n%s\n", item.ID, item.Title, item.Path)
+		return nil
+	},
+}
+
+func init() {
+	nextCmd.Flags().String("status", "", "Only consider work items with this status")
+}
+
+// nextWorkItem returns the oldest unblocked item in the first actionable status
+// that has one, or nil when nothing is actionable.
+func nextWorkItem(cfg *config.Config, status string) (*validation.WorkItem, error) {
+	items, err := loadWorkItems(workItemFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	statusByID := make(map[string]string, len(items))
+	for _, item := range items {
+		statusByID[item.ID] = item.Status
+	}
+
+	pool := actionableStatuses(cfg)
+	if status != "" {
+		pool = []string{status}
+	}
+
+	for _, candidate := range pool {
+		var ready []*validation.WorkItem
+		for _, item := range items {
+			if item.Status == candidate && !isBlocked(cfg, item, statusByID) {
+				ready = append(ready, item)
+			}
+		}
+		if len(ready) == 0 {
+			continue
+		}
+		sort.SliceStable(ready, func(i, j int) bool {
+			if a, b := createdTime(ready[i]), createdTime(ready[j]); !a.Equal(b) {
+				return a.Before(b)
+			}
+			return compareIDs(ready[i].ID, ready[j].ID) < 0
+		})
+		return ready[0], nil
+	}
+	return nil, nil
+}
+
+// actionableStatuses returns statuses in workflow order that come before the done status.
+func actionableStatuses(cfg *config.Config) []string {
+	var actionable []string
+	for _, status := range config.OrderedStatuses(cfg) {
+		if status == cfg.DoneStatus {
+			break
+		}
+		actionable = append(actionable, status)
+	}
+	return actionable
+}
+
+// isBlocked reports whether any of the item's dependencies is missing or not yet complete.
+func isBlocked(cfg *config.Config, item *validation.WorkItem, statusByID map[string]string) bool {
+	for _, dep := range item.DependsOn {
+		depStatus, exists := statusByID[dep]
+		if !exists || !isCompletedStatus(cfg, depStatus) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCompletedStatus reports whether a status means the work is finished.
+func isCompletedStatus(cfg *config.Config, status string) bool {
+	return status == cfg.DoneStatus || status == "released" || status == "archived"
+}
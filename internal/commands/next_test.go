@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextWorkItem(t *testing.T) {
+	t.Run("skips a blocked item in favor of an unblocked one", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		blocked := writeTestWorkItem(t, "0_backlog", "001", "Blocked", "backlog", "task")
+		setRawFrontMatterField(t, blocked, "depends_on", "[003]")
+		writeTestWorkItem(t, "0_backlog", "002", "Ready", "backlog", "task")
+		writeTestWorkItem(t, "2_doing", "003", "In Progress", "doing", "task")
+
+		item, err := nextWorkItem(newTestConfig(), "")
+		require.NoError(t, err)
+		require.NotNil(t, item)
+		assert.Equal(t, "002", item.ID)
+		assert.Equal(t, ".work/0_backlog/002-ready.task.md", item.Path)
+	})
+
+	t.Run("treats dependencies in done as met", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		waiting := writeTestWorkItem(t, "1_todo", "001", "Waiting", "todo", "task")
+		setRawFrontMatterField(t, waiting, "depends_on", "[002]")
+		writeTestWorkItem(t, "4_done", "002", "Finished", "done", "task")
+
+		item, err := nextWorkItem(newTestConfig(), "")
+		require.NoError(t, err)
+		require.NotNil(t, item)
+		assert.Equal(t, "001", item.ID)
+	})
+
+	t.Run("scopes the pool with a status override", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "0_backlog", "001", "Someday", "backlog", "task")
+		writeTestWorkItem(t, "1_todo", "002", "Soon", "todo", "task")
+
+		item, err := nextWorkItem(newTestConfig(), "todo")
+		require.NoError(t, err)
+		require.NotNil(t, item)
+		assert.Equal(t, "002", item.ID)
+	})
+
+	t.Run("returns nil when everything is blocked", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		blocked := writeTestWorkItem(t, "1_todo", "001", "Blocked", "todo", "task")
+		setRawFrontMatterField(t, blocked, "depends_on", "[099]")
+
+		item, err := nextWorkItem(newTestConfig(), "")
+		require.NoError(t, err)
+		assert.Nil(t, item)
+	})
+}
@@ -0,0 +1,33 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Verbosity set by the --quiet and --verbose persistent flags.
+var (
+	quietOutput   bool
+	verboseOutput bool
+)
+
+// infoOut is where informational and debug lines are written; tests replace it.
+var infoOut io.Writer = os.Stdout
+
+// infof prints an informational line, such as a success message, unless --quiet is set.
+func infof(format string, args ...interface{}) {
+	if quietOutput {
+		return
+	}
+	fmt.Fprintf(infoOut, format+"\n", args...)
+}
+
+// debugf prints a diagnostic line only when --verbose is set.
+func debugf(format string, args ...interface{}) {
+	if !verboseOutput || quietOutput {
+		return
+	}
+	fmt.Fprintf(infoOut, format+"\n", args...)
+}
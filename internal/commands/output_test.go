@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureOutput redirects informational output to a buffer and sets the
+// verbosity flags for the duration of the test.
+func captureOutput(t *testing.T, quiet, verbose bool) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prevOut, prevQuiet, prevVerbose := infoOut, quietOutput, verboseOutput
+	infoOut, quietOutput, verboseOutput = &buf, quiet, verbose
+	t.Cleanup(func() {
+		infoOut, quietOutput, verboseOutput = prevOut, prevQuiet, prevVerbose
+	})
+	return &buf
+}
+
+func TestOutputVerbosity(t *testing.T) {
+	templateContent := `---
+id: <!--input-number:id:"ID"-->
+title: <!--input-string:title:"Title"-->
+---
+`
+
+	t.Run("prints the created line by default", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		buf := captureOutput(t, false, false)
+		cfg := setupCustomTemplate(t, templateContent)
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "Loud"}, false, map[string]string{}, false))
+
+		assert.Equal(t, "Created work item 001 in 1_todo\n", buf.String())
+	})
+
+	t.Run("suppresses the created line in quiet mode", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		buf := captureOutput(t, true, false)
+		cfg := setupCustomTemplate(t, templateContent)
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "Silent"}, false, map[string]string{}, false))
+
+		assert.Empty(t, buf.String())
+		assert.FileExists(t, ".work/1_todo/001-silent.custom.md")
+	})
+
+	t.Run("adds debug details in verbose mode", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		buf := captureOutput(t, false, true)
+		cfg := setupCustomTemplate(t, templateContent)
+		require.NoError(t, createWorkItem(cfg, []string{"custom", "Chatty"}, false, map[string]string{}, false))
+
+		assert.Contains(t, buf.String(), "Rendering template .work/templates/template.custom.md")
+		assert.Contains(t, buf.String(), "Created work item 001 in 1_todo")
+	})
+}
@@ -0,0 +1,47 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var pathCmd = &cobra.Command{
+	Use:   "path <work-item-id>",
+	Short: "Print the absolute path of a work item",
+	Long: `Prints the absolute path of a work item file and nothing else, so it can be
+used by scripts and editors, e.g. vim $(kira path 001). A glob pattern such as
+'00*' prints the path of every work item whose ID matches, one per line.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if _, err := loadWorkspaceConfig(); err != nil {
+			return err
+		}
+
+		return forEachWorkItemArg(args[0], "find", func(workItemID string) error {
+			path, err := workItemAbsPath(workItemID)
+			if err != nil {
+				return err
+			}
+			fmt.Println(path)
+			return nil
+		})
+	},
+}
+
+// workItemAbsPath resolves a work item ID, or part of its title, to the
+// absolute path of its file.
+func workItemAbsPath(workItemID string) (string, error) {
+	path, err := findWorkItemFileOrTitle(workItemID)
+	if err != nil {
+		return "", err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path for work item %s: %w", workItemID, err)
+	}
+	return absPath, nil
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkItemAbsPath(t *testing.T) {
+	t.Run("prints the absolute path of a work item", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+		content := "---\nid: 001\ntitle: Test\nstatus: todo\nkind: task\ncreated: 2024-01-01\n---\n"
+		require.NoError(t, os.WriteFile(".work/1_todo/001-test.task.md", []byte(content), 0o600))
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+
+		path, err := workItemAbsPath("001")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(cwd, ".work/1_todo/001-test.task.md"), path)
+	})
+
+	t.Run("returns an error when the work item does not exist", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+
+		_, err := workItemAbsPath("999")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "work item with ID 999 not found")
+	})
+}
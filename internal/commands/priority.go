@@ -0,0 +1,59 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+)
+
+var priorityCmd = &cobra.Command{
+	Use:   "priority <work-item-id> <level>",
+	Short: "Set the priority of a work item",
+	Long: `Sets the priority field of a work item. The level must be one of the priorities
+configured in kira.yml (high, medium, low by default), listed from highest to lowest.
+A glob pattern such as '00*' sets the priority of every work item whose ID matches.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(_ *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		return forEachWorkItemArg(args[0], "set the priority of", func(workItemID string) error {
+			return setWorkItemPriority(cfg, workItemID, args[1])
+		})
+	},
+}
+
+func setWorkItemPriority(cfg *config.Config, workItemID, level string) error {
+	if priorityRank(cfg, level) < 0 {
+		return fmt.Errorf("invalid priority: %s (valid: %s)", level, strings.Join(cfg.Priorities, ", "))
+	}
+
+	workItemPath, err := findWorkItemFile(workItemID)
+	if err != nil {
+		return err
+	}
+
+	if err := setFrontMatterField(workItemPath, "priority", level); err != nil {
+		return fmt.Errorf("failed to set priority: %w", err)
+	}
+
+	infof("Set priority of work item %s to %s", workItemID, level)
+	return nil
+}
+
+// priorityRank returns the position of level in the configured priorities,
+// highest first, or -1 when the level is not configured.
+func priorityRank(cfg *config.Config, level string) int {
+	for i, priority := range cfg.Priorities {
+		if priority == level {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/validation"
+)
+
+func TestSetWorkItemPriority(t *testing.T) {
+	t.Run("sets a configured priority", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := writeTestWorkItem(t, "1_todo", "001", "Urgent Fix", "todo", "issue")
+
+		require.NoError(t, setWorkItemPriority(newTestConfig(), "001", "high"))
+
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "high", item.Field("priority"))
+	})
+
+	t.Run("rejects an unknown priority", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Urgent Fix", "todo", "issue")
+
+		err := setWorkItemPriority(newTestConfig(), "001", "critical")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid priority: critical")
+	})
+
+	t.Run("accepts numeric priorities when configured", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := newTestConfig()
+		cfg.Priorities = []string{"1", "2", "3"}
+		path := writeTestWorkItem(t, "1_todo", "001", "Urgent Fix", "todo", "issue")
+
+		require.NoError(t, setWorkItemPriority(cfg, "001", "2"))
+
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "2", item.Field("priority"))
+	})
+}
@@ -0,0 +1,78 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/validation"
+)
+
+var progressCmd = &cobra.Command{
+	Use:   "progress <work-item-id>",
+	Short: "Show checklist progress for a work item",
+	Long: `Counts the markdown checkboxes (- [ ] and - [x]) in a work item's body and reports
+how many are checked, with a percentage. Items without a checklist show "-".
+A glob pattern such as '00*' shows the progress of every work item whose ID
+matches.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if _, err := loadWorkspaceConfig(); err != nil {
+			return err
+		}
+
+		return forEachWorkItemArg(args[0], "show progress of", func(workItemID string) error {
+			workItemPath, err := findWorkItemFile(workItemID)
+			if err != nil {
+				return err
+			}
+			item, err := validation.ParseWorkItemFile(workItemPath)
+			if err != nil {
+				return fmt.Errorf("failed to parse work item: %w", err)
+			}
+
+			checked, total := checklistProgress(item.Body)
+			fmt.Printf("%s %s: %s\n", item.ID, item.Title, formatProgress(checked, total))
+			return nil
+		})
+	},
+}
+
+var checkboxPattern = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]`)
+
+// checklistProgress counts checked and total markdown checkboxes in body,
+// ignoring any inside fenced code blocks.
+func checklistProgress(body string) (checked, total int) {
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		match := checkboxPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		total++
+		if match[1] != " " {
+			checked++
+		}
+	}
+	return checked, total
+}
+
+// formatProgress renders checklist progress as "checked/total (pct%)", or "-"
+// when there is no checklist.
+func formatProgress(checked, total int) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d (%d%%)", checked, total, checked*100/total)
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecklistProgress(t *testing.T) {
+	body := "## Tasks\n" +
+		"- [x] Write the parser\n" +
+		"- [ ] Add tests\n" +
+		"  * [X] Nested and checked\n" +
+		"+ [ ] Plus bullet\n" +
+		"- [] not a checkbox\n" +
+		"```\n- [x] inside a code fence\n```\n"
+
+	checked, total := checklistProgress(body)
+	assert.Equal(t, 2, checked)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, "2/4 (50%)", formatProgress(checked, total))
+
+	checked, total = checklistProgress("No checklist here.\n")
+	assert.Equal(t, 0, total)
+	assert.Equal(t, "-", formatProgress(checked, total))
+}
+
+func TestListShowProgress(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	path := writeTestWorkItem(t, "1_todo", "001", "Checklist", "todo", "task")
+	writeTestWorkItem(t, "1_todo", "002", "Plain", "todo", "task")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	content = append(content, []byte("\n- [x] one\n- [ ] two\n- [x] three\n")...)
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+
+	var buf bytes.Buffer
+	require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{ShowProgress: true}))
+
+	assert.Contains(t, buf.String(), "PROGRESS")
+	assert.Regexp(t, `001\s+todo\s+task\s+-\s+2/3 \(66%\)\s+Checklist`, buf.String())
+	assert.Regexp(t, `002\s+todo\s+task\s+-\s+-\s+Plain`, buf.String())
+}
@@ -0,0 +1,119 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+	"kira/internal/validation"
+)
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Delete markdown files in .work that are not valid work items",
+	Long: `Lists the .md files under .work that are not work items: files with no front
+matter, front matter that cannot be parsed, or no id. Templates and IDEAS.md are
+never considered. Without --confirm nothing is deleted; with it the listed files
+are removed, and each delete can be reverted with kira undo.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		confirm, _ := cmd.Flags().GetBool("confirm")
+		return purgeMalformedFiles(os.Stdout, cfg, confirm)
+	},
+}
+
+func init() {
+	purgeCmd.Flags().Bool("confirm", false, "Delete the listed files")
+}
+
+// malformedFile is a markdown file in .work that is not a valid work item.
+type malformedFile struct {
+	Path   string
+	Reason string
+}
+
+// purgeMalformedFiles prints the malformed files and, when confirm is set,
+// deletes them.
+func purgeMalformedFiles(w io.Writer, cfg *config.Config, confirm bool) error {
+	files, err := findMalformedFiles()
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		infof("No malformed files found")
+		return nil
+	}
+
+	for _, file := range files {
+		fmt.Fprintf(w, "%s  (%s)\n", file.Path, file.Reason)
+	}
+	if !confirm {
+		return fmt.Errorf("purge would delete %d files; re-run with --confirm to delete them", len(files))
+	}
+
+	for _, file := range files {
+		if err := removeWorkItemFile(cfg, file.Path); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", file.Path, err)
+		}
+	}
+	infof("Deleted %d files", len(files))
+	return nil
+}
+
+// findMalformedFiles returns the files kira would treat as work items but
+// cannot load: those with no front matter, unparseable front matter, or no id.
+func findMalformedFiles() ([]malformedFile, error) {
+	var files []malformedFile
+	err := filepath.Walk(".work", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".md") {
+			return nil
+		}
+		if strings.Contains(path, "template") || strings.HasSuffix(path, "IDEAS.md") {
+			return nil
+		}
+
+		content, err := safeReadFile(path)
+		if err != nil {
+			return err
+		}
+		if reason := malformedReason(content); reason != "" {
+			files = append(files, malformedFile{Path: path, Reason: reason})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan .work: %w", err)
+	}
+	return files, nil
+}
+
+// malformedReason explains why content is not a work item, or returns "" if
+// it is one.
+func malformedReason(content []byte) string {
+	lines := strings.SplitN(string(content), "\n", 2)
+	if strings.TrimSpace(lines[0]) != "---" {
+		return "no front matter"
+	}
+	item, err := validation.ParseWorkItemContent(content)
+	if err != nil {
+		return "unparseable front matter"
+	}
+	if strings.TrimSpace(item.ID) == "" {
+		return "no id"
+	}
+	return ""
+}
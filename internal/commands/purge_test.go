@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeMalformedFiles(t *testing.T) {
+	setup := func(t *testing.T) []string {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		valid := []string{
+			writeTestWorkItem(t, "1_todo", "001", "Valid", "todo", "task"),
+			writeTestWorkItem(t, "z_archive/2024-01-01/4_done", "002", "Archived", "done", "task"),
+		}
+		files := map[string]string{
+			".work/1_todo/notes.md":              "Just some notes\n",
+			".work/1_todo/broken.md":             "---\nid: [unclosed\n---\n",
+			".work/2_doing/no-id.md":             "---\ntitle: Missing ID\nstatus: doing\n---\n",
+			".work/templates/template.custom.md": "No front matter in a template\n",
+			".work/IDEAS.md":                     "# Ideas\n",
+			".work/1_todo/scratch.txt":           "not markdown\n",
+		}
+		for path, content := range files {
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		}
+		return valid
+	}
+
+	t.Run("selects only malformed files", func(t *testing.T) {
+		setup(t)
+
+		files, err := findMalformedFiles()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []malformedFile{
+			{Path: filepath.Join(".work", "1_todo", "notes.md"), Reason: "no front matter"},
+			{Path: filepath.Join(".work", "1_todo", "broken.md"), Reason: "unparseable front matter"},
+			{Path: filepath.Join(".work", "2_doing", "no-id.md"), Reason: "no id"},
+		}, files)
+	})
+
+	t.Run("lists without deleting unless confirmed", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		err := purgeMalformedFiles(&buf, newTestConfig(), false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--confirm")
+		assert.Contains(t, buf.String(), filepath.Join(".work", "1_todo", "notes.md")+"  (no front matter)")
+		assert.FileExists(t, ".work/1_todo/notes.md")
+	})
+
+	t.Run("deletes malformed files and keeps everything else", func(t *testing.T) {
+		valid := setup(t)
+
+		require.NoError(t, purgeMalformedFiles(&bytes.Buffer{}, newTestConfig(), true))
+
+		assert.NoFileExists(t, ".work/1_todo/notes.md")
+		assert.NoFileExists(t, ".work/1_todo/broken.md")
+		assert.NoFileExists(t, ".work/2_doing/no-id.md")
+		for _, path := range valid {
+			assert.FileExists(t, path)
+		}
+		assert.FileExists(t, ".work/templates/template.custom.md")
+		assert.FileExists(t, ".work/IDEAS.md")
+		assert.FileExists(t, ".work/1_todo/scratch.txt")
+
+		files, err := findMalformedFiles()
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
@@ -0,0 +1,98 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	recentByModified = "modified"
+	recentByCreated  = "created"
+)
+
+var recentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "List recently modified work items",
+	Long: `Lists the most recently touched work items, newest first. By default items are
+ordered by file modification time; use --by created to order by the created field.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if _, err := loadWorkspaceConfig(); err != nil {
+			return err
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		by, _ := cmd.Flags().GetString("by")
+		return listRecentWorkItems(os.Stdout, limit, by)
+	},
+}
+
+func init() {
+	recentCmd.Flags().Int("limit", 10, "Maximum number of work items to show")
+	recentCmd.Flags().String("by", recentByModified, "Order by file modification time (modified) or created date (created)")
+}
+
+type recentWorkItem struct {
+	id, title, status string
+	// timestamp is the modification time or created value as displayed, and
+	// at is the time it sorts by.
+	timestamp string
+	at        time.Time
+}
+
+func listRecentWorkItems(w io.Writer, limit int, by string) error {
+	if limit < 1 {
+		return fmt.Errorf("invalid limit: %d (must be at least 1)", limit)
+	}
+	if by != recentByModified && by != recentByCreated {
+		return fmt.Errorf("invalid order: %s (valid: %s, %s)", by, recentByModified, recentByCreated)
+	}
+
+	items, err := loadWorkItems(workItemFilter{})
+	if err != nil {
+		return err
+	}
+
+	recent := make([]recentWorkItem, 0, len(items))
+	for _, item := range items {
+		entry := recentWorkItem{id: item.ID, title: item.Title, status: item.Status, timestamp: item.Created, at: createdTime(item)}
+		if by == recentByModified {
+			info, err := os.Stat(item.Path)
+			if err != nil {
+				return fmt.Errorf("failed to stat %s: %w", item.Path, err)
+			}
+			// Sort at the displayed precision so items modified in the same
+			// second keep ID order.
+			entry.at = info.ModTime().Truncate(time.Second)
+			entry.timestamp = entry.at.Format(time.DateTime)
+		}
+		recent = append(recent, entry)
+	}
+
+	// Items arrive ordered by ID, so the stable sort keeps ID order for ties.
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].at.After(recent[j].at)
+	})
+	if len(recent) > limit {
+		recent = recent[:limit]
+	}
+
+	column := "MODIFIED"
+	if by == recentByCreated {
+		column = "CREATED"
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID\tSTATUS\t%s\tTITLE\n", column)
+	for _, entry := range recent {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", entry.id, entry.status, entry.timestamp, entry.title)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRecentWorkItems(t *testing.T) {
+	t.Run("orders by modification time and applies the limit", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		// 002 is the newest, then 003, then 001.
+		ages := map[string]time.Duration{"001": 3 * time.Hour, "002": time.Hour, "003": 2 * time.Hour}
+		now := time.Now()
+		for id, age := range ages {
+			path := writeTestWorkItem(t, "1_todo", id, "Item "+id, "todo", "task")
+			require.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, listRecentWorkItems(&buf, 2, recentByModified))
+
+		assert.Contains(t, buf.String(), "MODIFIED")
+		assert.Equal(t, []string{"002", "003"}, listedIDs(buf.String()))
+	})
+
+	t.Run("orders by created date", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Old", "todo", "task")
+		path := writeTestWorkItem(t, "1_todo", "002", "New", "todo", "task")
+		require.NoError(t, setFrontMatterField(path, "created", "2024-06-01"))
+
+		var buf bytes.Buffer
+		require.NoError(t, listRecentWorkItems(&buf, 10, recentByCreated))
+		assert.Equal(t, []string{"002", "001"}, listedIDs(buf.String()))
+		assert.Contains(t, buf.String(), "2024-06-01")
+	})
+
+	t.Run("orders full timestamps within a day", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		created := map[string]string{
+			"001": "2024-06-01T09:00:00Z",
+			"002": "2024-06-01T17:30:00+02:00",
+			"003": "2024-06-01",
+			"004": "2024-06-01T16:00:00Z",
+		}
+		for id, value := range created {
+			path := writeTestWorkItem(t, "1_todo", id, "Item "+id, "todo", "task")
+			require.NoError(t, setFrontMatterField(path, "created", value))
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, listRecentWorkItems(&buf, 10, recentByCreated))
+		assert.Equal(t, []string{"004", "002", "001", "003"}, listedIDs(buf.String()))
+		assert.Contains(t, buf.String(), "2024-06-01T17:30:00+02:00")
+	})
+
+	t.Run("rejects invalid options", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.Error(t, listRecentWorkItems(&buf, 0, recentByModified))
+		assert.Error(t, listRecentWorkItems(&buf, 5, "title"))
+	})
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"kira/internal/fsutil"
+	"kira/internal/validation"
+)
+
+// migrateDependencyReferences rewrites depends_on entries that name the work
+// item with the given ID by one of its paths, its filename, or its filename
+// without the .md extension, replacing them with the ID. References are
+// matched against every path given, so both the old and the new location of
+// a moved item are covered.
+func migrateDependencyReferences(id string, paths ...string) error {
+	names := make(map[string]bool)
+	for _, path := range paths {
+		clean := filepath.ToSlash(filepath.Clean(path))
+		base := filepath.Base(clean)
+		names[clean] = true
+		names[strings.TrimPrefix(clean, ".work/")] = true
+		names[base] = true
+		names[strings.TrimSuffix(base, ".md")] = true
+	}
+
+	items, err := validation.LoadWorkItems()
+	if err != nil {
+		return fmt.Errorf("failed to load work items: %w", err)
+	}
+	for _, item := range items {
+		deps := make([]string, 0, len(item.DependsOn))
+		changed := false
+		for _, dep := range item.DependsOn {
+			if names[filepath.ToSlash(filepath.Clean(dep))] {
+				dep = id
+				changed = true
+			}
+			if dep == id && slices.Contains(deps, id) {
+				continue
+			}
+			deps = append(deps, dep)
+		}
+		if !changed {
+			continue
+		}
+
+		content, err := safeReadFile(item.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", item.Path, err)
+		}
+		updated, err := setFrontMatterList(string(content), "depends_on", deps)
+		if err != nil {
+			return fmt.Errorf("%s: %w", item.Path, err)
+		}
+		if err := fsutil.WriteFile(item.Path, []byte(updated), 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", item.Path, err)
+		}
+		debugf("Rewrote depends_on references to %s in %s", id, item.Path)
+	}
+	return nil
+}
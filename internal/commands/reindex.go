@@ -0,0 +1,260 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+
+	"kira/internal/config"
+	"kira/internal/fsutil"
+	"kira/internal/validation"
+)
+
+var idCmd = &cobra.Command{
+	Use:   "id",
+	Short: "Manage work item IDs",
+}
+
+var idReindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Renumber all work items sequentially",
+	Long: `Reassigns sequential IDs to every work item, ordered by created date, rewriting
+the id field, the ID in each filename, and depends_on references to renumbered
+items. Kinds with an id_prefix_per_kind prefix are numbered separately.
+Without --confirm the planned changes are printed and nothing is written.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		confirm, _ := cmd.Flags().GetBool("confirm")
+		return reindexWorkItems(os.Stdout, cfg, confirm)
+	},
+}
+
+func init() {
+	idReindexCmd.Flags().Bool("confirm", false, "Apply the renumbering")
+	idCmd.AddCommand(idReindexCmd)
+}
+
+// reindexChange is the rewrite of one work item during a reindex.
+type reindexChange struct {
+	Path    string
+	NewPath string
+	OldID   string
+	NewID   string
+	Content string
+}
+
+// reindexWorkItems prints the renumbering and, when confirm is set, applies it.
+func reindexWorkItems(w io.Writer, cfg *config.Config, confirm bool) error {
+	changes, err := planReindex(cfg)
+	if err != nil {
+		return err
+	}
+
+	renumbered := 0
+	for _, change := range changes {
+		if change.OldID != change.NewID {
+			renumbered++
+			fmt.Fprintf(w, "%s -> %s  %s\n", change.OldID, change.NewID, change.NewPath)
+		}
+	}
+	if len(changes) == 0 {
+		infof("Work item IDs are already sequential")
+		return nil
+	}
+	if !confirm {
+		return fmt.Errorf("reindex would renumber %d work items; re-run with --confirm to apply", renumbered)
+	}
+
+	if err := applyReindex(cfg, changes); err != nil {
+		return err
+	}
+	infof("Renumbered %d work items", renumbered)
+	return nil
+}
+
+// planReindex works out the new ID, path, and content of every work item
+// whose ID or depends_on references change.
+func planReindex(cfg *config.Config) ([]reindexChange, error) {
+	items, err := loadWorkItems(workItemFilter{})
+	if err != nil {
+		return nil, err
+	}
+	if err := sortWorkItems(cfg, items, sortByCreated); err != nil {
+		return nil, err
+	}
+
+	newIDs := make([]string, len(items))
+	mapping := make(map[string]string, len(items))
+	counters := make(map[string]int)
+	for i, item := range items {
+		prefix := cfg.IDPrefixPerKind[item.Kind]
+		counters[prefix]++
+		newIDs[i] = fmt.Sprintf("%03d", counters[prefix])
+		if prefix != "" {
+			newIDs[i] = prefix + "-" + newIDs[i]
+		}
+		// A duplicated ID keeps pointing at its oldest item.
+		if _, seen := mapping[item.ID]; !seen {
+			mapping[item.ID] = newIDs[i]
+		}
+	}
+
+	var changes []reindexChange
+	for i, item := range items {
+		change, changed, err := planReindexItem(cfg, item, newIDs[i], mapping)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// planReindexItem rewrites one item for its new ID and remapped references,
+// reporting whether anything changed.
+func planReindexItem(cfg *config.Config, item *validation.WorkItem, newID string, mapping map[string]string) (reindexChange, bool, error) {
+	change := reindexChange{Path: item.Path, NewPath: item.Path, OldID: item.ID, NewID: newID}
+
+	deps := make([]string, len(item.DependsOn))
+	depsChanged := false
+	for i, dep := range item.DependsOn {
+		deps[i] = dep
+		if mapped, ok := mapping[dep]; ok && mapped != dep {
+			deps[i] = mapped
+			depsChanged = true
+		}
+	}
+	if newID == item.ID && !depsChanged {
+		return change, false, nil
+	}
+
+	content, err := safeReadFile(item.Path)
+	if err != nil {
+		return change, false, fmt.Errorf("failed to read %s: %w", item.Path, err)
+	}
+	change.Content = string(content)
+
+	if newID != item.ID {
+		if change.Content, err = setFrontMatterLine(change.Content, "id", newID); err != nil {
+			return change, false, fmt.Errorf("%s: %w", item.Path, err)
+		}
+		base := filepath.Base(item.Path)
+		if start, end, ok := filenameIDSpan(cfg, base); ok && base[start:end] == item.ID {
+			change.NewPath = filepath.Join(filepath.Dir(item.Path), base[:start]+newID+base[end:])
+		}
+	}
+	if depsChanged {
+		if change.Content, err = setFrontMatterList(change.Content, "depends_on", deps); err != nil {
+			return change, false, fmt.Errorf("%s: %w", item.Path, err)
+		}
+	}
+	return change, true, nil
+}
+
+// renameFile is os.Rename, replaced in tests to simulate failures.
+var renameFile = os.Rename
+
+// applyReindex writes every change. All new contents are staged under
+// temporary names before any original is touched, and the originals are
+// moved aside rather than removed, so that a failure at any step leaves the
+// work items as they were. Renumbered items can take each other's filenames.
+func applyReindex(cfg *config.Config, changes []reindexChange) error {
+	moving := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		moving[change.Path] = true
+	}
+	for _, change := range changes {
+		if _, err := os.Stat(change.NewPath); err == nil && !moving[change.NewPath] {
+			return fmt.Errorf("cannot rename %s: %s already exists", change.Path, change.NewPath)
+		}
+	}
+
+	var staged, backedUp, placed []reindexChange
+	rollback := func() {
+		for _, change := range placed {
+			_ = os.Remove(change.NewPath)
+		}
+		for _, change := range backedUp {
+			_ = renameFile(change.Path+".reindex-old", change.Path)
+		}
+		for _, change := range staged {
+			_ = os.Remove(change.NewPath + ".reindex")
+		}
+	}
+
+	for _, change := range changes {
+		if err := fsutil.WriteFile(change.NewPath+".reindex", []byte(change.Content), cfg.FilePerm()); err != nil {
+			rollback()
+			return fmt.Errorf("failed to write %s: %w", change.NewPath, err)
+		}
+		staged = append(staged, change)
+	}
+	for _, change := range changes {
+		if err := renameFile(change.Path, change.Path+".reindex-old"); err != nil {
+			rollback()
+			return fmt.Errorf("failed to move %s aside: %w", change.Path, err)
+		}
+		backedUp = append(backedUp, change)
+	}
+	for _, change := range changes {
+		if err := renameFile(change.NewPath+".reindex", change.NewPath); err != nil {
+			rollback()
+			return fmt.Errorf("failed to write %s: %w", change.NewPath, err)
+		}
+		placed = append(placed, change)
+	}
+
+	for _, change := range changes {
+		if err := os.Remove(change.Path + ".reindex-old"); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", change.Path+".reindex-old", err)
+		}
+		debugf("Rewrote %s as %s", change.Path, change.NewPath)
+	}
+	return nil
+}
+
+// setFrontMatterList sets key to an inline YAML list, replacing the existing
+// value whether it was written inline or as a block list. Elements are
+// encoded as frontMatterScalar does.
+func setFrontMatterList(content, key string, values []string) (string, error) {
+	list := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, value := range values {
+		list.Content = append(list.Content, frontMatterScalar(key, value))
+	}
+	encoded, err := encodeYAMLNode(list)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(content, "\n")
+	end := frontMatterEnd(lines)
+	if end < 0 {
+		return "", fmt.Errorf("no front matter found")
+	}
+
+	for i := 1; i < end; i++ {
+		if !strings.HasPrefix(strings.TrimSpace(lines[i]), key+":") {
+			continue
+		}
+		next := i + 1
+		for next < end && (strings.HasPrefix(lines[next], " ") || strings.HasPrefix(strings.TrimSpace(lines[next]), "- ")) {
+			next++
+		}
+		lines = append(lines[:i+1], lines[next:]...)
+		break
+	}
+	return setFrontMatterYAML(strings.Join(lines, "\n"), key, encoded)
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/validation"
+)
+
+func TestReindexWorkItems(t *testing.T) {
+	setup := func(t *testing.T) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		second := writeTestWorkItem(t, "1_todo", "003", "Second", "todo", "task")
+		require.NoError(t, setFrontMatterField(second, "created", "2024-01-02"))
+		writeTestWorkItem(t, "0_backlog", "007", "First", "backlog", "task")
+		third := writeTestWorkItem(t, "1_todo", "010", "Third", "todo", "task")
+		require.NoError(t, setFrontMatterField(third, "created", "2024-01-03"))
+		setRawFrontMatterField(t, third, "depends_on", "\n  - 003\n  - 007\n  - 999")
+	}
+
+	t.Run("renumbers by created date and remaps depends_on", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, reindexWorkItems(&buf, newTestConfig(), true))
+
+		for path, id := range map[string]string{
+			".work/0_backlog/001-first.task.md": "001",
+			".work/1_todo/002-second.task.md":   "002",
+			".work/1_todo/003-third.task.md":    "003",
+		} {
+			item, err := validation.ParseWorkItemFile(path)
+			require.NoError(t, err, path)
+			assert.Equal(t, id, item.ID, path)
+		}
+		assert.NoFileExists(t, ".work/1_todo/010-third.task.md")
+
+		third, err := validation.ParseWorkItemFile(".work/1_todo/003-third.task.md")
+		require.NoError(t, err)
+		assert.Equal(t, validation.IDList{"002", "001", "999"}, third.DependsOn)
+		assert.Contains(t, buf.String(), "010 -> 003  .work/1_todo/003-third.task.md")
+	})
+
+	t.Run("gives renamed files the configured file mode", func(t *testing.T) {
+		setup(t)
+		cfg := newTestConfig()
+		cfg.FileMode = "0640"
+
+		require.NoError(t, reindexWorkItems(&bytes.Buffer{}, cfg, true))
+
+		info, err := os.Stat(".work/1_todo/003-third.task.md")
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+	})
+
+	t.Run("changes nothing without confirm", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		err := reindexWorkItems(&buf, newTestConfig(), false)
+		require.EqualError(t, err, "reindex would renumber 3 work items; re-run with --confirm to apply")
+		assert.FileExists(t, ".work/1_todo/010-third.task.md")
+		assert.FileExists(t, ".work/0_backlog/007-first.task.md")
+	})
+	snapshot := func(t *testing.T) map[string]string {
+		t.Helper()
+		files := make(map[string]string)
+		require.NoError(t, filepath.WalkDir(".work", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			content, err := os.ReadFile(path)
+			files[path] = string(content)
+			return err
+		}))
+		return files
+	}
+
+	t.Run("leaves the originals alone when a write fails", func(t *testing.T) {
+		setup(t)
+		before := snapshot(t)
+		require.NoError(t, os.Mkdir(".work/1_todo/003-third.task.md.reindex", 0o700))
+
+		err := reindexWorkItems(&bytes.Buffer{}, newTestConfig(), true)
+		require.Error(t, err)
+		require.NoError(t, os.Remove(".work/1_todo/003-third.task.md.reindex"))
+		assert.Equal(t, before, snapshot(t))
+	})
+
+	t.Run("leaves the originals alone when a rename fails", func(t *testing.T) {
+		setup(t)
+		before := snapshot(t)
+		prev := renameFile
+		defer func() { renameFile = prev }()
+		renameFile = func(oldpath, newpath string) error {
+			if newpath == ".work/1_todo/003-third.task.md" {
+				return errors.New("disk full")
+			}
+			return prev(oldpath, newpath)
+		}
+
+		err := reindexWorkItems(&bytes.Buffer{}, newTestConfig(), true)
+		require.ErrorContains(t, err, "disk full")
+		assert.Equal(t, before, snapshot(t))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"kira/internal/config"
+	"kira/internal/fsutil"
 
 	"github.com/spf13/cobra"
 )
@@ -20,13 +21,9 @@ var releaseCmd = &cobra.Command{
 Updates work item status to "released" before archival.`,
 	Args: cobra.MaximumNArgs(2),
 	RunE: func(_ *cobra.Command, args []string) error {
-		if err := checkWorkDir(); err != nil {
-			return err
-		}
-
-		cfg, err := config.LoadConfig()
+		cfg, err := loadWorkspaceConfig()
 		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+			return err
 		}
 
 		var targetPath string
@@ -54,9 +51,9 @@ func releaseWorkItems(cfg *config.Config, targetPath, subfolder string) error {
 		sourcePath = filepath.Join(".work", targetPath)
 	} else {
 		// Status name provided
-		statusFolder, exists := cfg.StatusFolders[targetPath]
-		if !exists {
-			return fmt.Errorf("invalid status: %s", targetPath)
+		statusFolder, err := config.FolderForStatus(cfg, targetPath)
+		if err != nil {
+			return err
 		}
 		sourcePath = filepath.Join(".work", statusFolder)
 	}
@@ -78,10 +75,16 @@ func releaseWorkItems(cfg *config.Config, targetPath, subfolder string) error {
 	}
 
 	if len(workItems) == 0 {
-		fmt.Println("No work items found to release.")
+		infof("No work items found to release.")
 		return nil
 	}
 
+	for _, workItem := range workItems {
+		if err := checkTransitionAllowed(cfg, workItem, "released"); err != nil {
+			return err
+		}
+	}
+
 	// Generate release notes
 	releaseNotes, err := generateReleaseNotes(workItems)
 	if err != nil {
@@ -96,7 +99,7 @@ func releaseWorkItems(cfg *config.Config, targetPath, subfolder string) error {
 	}
 
 	// Archive work items
-	archivePath, err := archiveWorkItems(workItems, sourcePath)
+	archivePath, err := archiveWorkItems(cfg, workItems, sourcePath)
 	if err != nil {
 		return fmt.Errorf("failed to archive work items: %w", err)
 	}
@@ -108,12 +111,12 @@ func releaseWorkItems(cfg *config.Config, targetPath, subfolder string) error {
 
 	// Remove original files
 	for _, workItem := range workItems {
-		if err := os.Remove(workItem); err != nil {
-			fmt.Printf("Warning: failed to remove %s: %v\n", workItem, err)
+		if err := removeWorkItemFile(cfg, workItem); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove %s: %v\n", workItem, err)
 		}
 	}
 
-	fmt.Printf("Released %d work items to %s\n", len(workItems), archivePath)
+	infof("Released %d work items to %s", len(workItems), archivePath)
 	return nil
 }
 
@@ -177,7 +180,7 @@ func updateReleasesFile(cfg *config.Config, releaseNotes string) error {
 	newContent := fmt.Sprintf("# Release %s\n\n%s\n\n%s", date, releaseNotes, content)
 
 	// Write back to file
-	if err := os.WriteFile(releasesPath, []byte(newContent), 0o600); err != nil {
+	if err := fsutil.WriteFile(releasesPath, []byte(newContent), cfg.FilePerm()); err != nil {
 		return fmt.Errorf("failed to write releases file: %w", err)
 	}
 
@@ -0,0 +1,77 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+	"kira/internal/validation"
+)
+
+var reopenCmd = &cobra.Command{
+	Use:   "reopen <work-item-id> [status]",
+	Short: "Move a finished work item back into progress",
+	Long: `Moves a done, released, archived, or abandoned work item back to the given status
+(default_status when omitted) and clears its completed and archived fields.
+--reason records why, as it does for kira move. A glob pattern such as '00*'
+reopens every work item whose ID matches.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		targetStatus := cfg.DefaultStatus
+		if len(args) > 1 {
+			targetStatus = args[1]
+		}
+
+		reason, _ := cmd.Flags().GetString("reason")
+		return forEachWorkItemArg(args[0], "reopen", func(workItemID string) error {
+			return reopenWorkItem(cfg, workItemID, targetStatus, reason)
+		})
+	},
+}
+
+func init() {
+	reopenCmd.Flags().String("reason", "", "Record why the work item is reopened")
+}
+
+// reopenedFields are cleared from a work item when it is reopened.
+var reopenedFields = []string{"completed", "archived"}
+
+func reopenWorkItem(cfg *config.Config, workItemID, targetStatus, reason string) error {
+	if isCompletedStatus(cfg, targetStatus) {
+		return fmt.Errorf("cannot reopen into %s: choose a status that is not done", targetStatus)
+	}
+
+	workItemPath, err := findWorkItemFile(workItemID)
+	if err != nil {
+		return err
+	}
+
+	item, err := validation.ParseWorkItemFile(workItemPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse work item: %w", err)
+	}
+	if !isCompletedStatus(cfg, item.Status) && item.Status != "abandoned" {
+		return fmt.Errorf("work item %s is not done (status: %s)", workItemID, item.Status)
+	}
+
+	targetPath, err := relocateWorkItem(cfg, workItemPath, targetStatus, reason)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range reopenedFields {
+		if err := removeFrontMatterField(targetPath, field); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", field, err)
+		}
+	}
+
+	infof("Reopened work item %s in %s", workItemID, targetStatus)
+	return nil
+}
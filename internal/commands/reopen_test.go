@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/validation"
+)
+
+func TestReopenWorkItem(t *testing.T) {
+	t.Run("moves a done item to the default status and clears completed", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "2_doing", "001", "Come Back", "doing", "task")
+		require.NoError(t, os.MkdirAll(".work/0_backlog", 0o700))
+		require.NoError(t, os.MkdirAll(".work/4_done", 0o700))
+		cfg := newTestConfig()
+		require.NoError(t, markWorkItemDone(cfg, "001", ""))
+
+		require.NoError(t, reopenWorkItem(cfg, "001", cfg.DefaultStatus, ""))
+
+		path := ".work/0_backlog/001-come-back.task.md"
+		assert.NoFileExists(t, ".work/4_done/001-come-back.task.md")
+		item, err := validation.ParseWorkItemFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "backlog", item.Status)
+		assert.NotContains(t, item.Fields, "completed")
+	})
+
+	t.Run("reopens an archived item into a chosen status", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := writeTestWorkItem(t, "z_archive/2024-01-01/4_done", "001", "Old Work", "released", "task")
+		require.NoError(t, setFrontMatterField(path, "archived", "2024-01-01"))
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+
+		require.NoError(t, reopenWorkItem(newTestConfig(), "001", "doing", ""))
+
+		item, err := validation.ParseWorkItemFile(".work/2_doing/001-old-work.task.md")
+		require.NoError(t, err)
+		assert.Equal(t, "doing", item.Status)
+		assert.NotContains(t, item.Fields, "archived")
+	})
+
+	t.Run("rejects an item that is not done", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "Still Open", "todo", "task")
+
+		err := reopenWorkItem(newTestConfig(), "001", "doing", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not done")
+	})
+}
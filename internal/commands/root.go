@@ -6,6 +6,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"kira/internal/config"
 )
 
 var rootCmd = &cobra.Command{
@@ -14,6 +16,7 @@ var rootCmd = &cobra.Command{
 	Long: `Kira is a git-based, plaintext productivity tool designed with both
 clankers (LLMs) and meatbags (people) in mind. It uses markdown files, git,
 and a lightweight CLI to manage and coordinate work.`,
+	PersistentPreRunE: enterWorkDir,
 }
 
 // Execute runs the root command and returns any error encountered.
@@ -22,16 +25,51 @@ func Execute() error {
 }
 
 func init() {
+	rootCmd.PersistentFlags().BoolVarP(&quietOutput, "quiet", "q", false, "Only print errors and requested output")
+	rootCmd.PersistentFlags().BoolVarP(&verboseOutput, "verbose", "v", false, "Print additional diagnostic output")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	rootCmd.PersistentFlags().StringVar(&workDir, "work-dir", "", "Run against the project at this path (or its .work directory) instead of the current directory; also set by "+workDirEnv)
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also disabled by NO_COLOR or when not writing to a terminal)")
+
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(newCmd)
 	rootCmd.AddCommand(moveCmd)
+	rootCmd.AddCommand(bumpCmd)
+	rootCmd.AddCommand(advanceCmd)
+	rootCmd.AddCommand(sweepCmd)
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(doneCmd)
+	rootCmd.AddCommand(reopenCmd)
+	rootCmd.AddCommand(nextCmd)
+	rootCmd.AddCommand(progressCmd)
+	rootCmd.AddCommand(pathCmd)
+	rootCmd.AddCommand(showCmd)
+	rootCmd.AddCommand(logCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(recentCmd)
+	rootCmd.AddCommand(countCmd)
+	rootCmd.AddCommand(velocityCmd)
+	rootCmd.AddCommand(grepCmd)
+	rootCmd.AddCommand(priorityCmd)
+	rootCmd.AddCommand(assignCmd)
+	rootCmd.AddCommand(watchCmd)
 	rootCmd.AddCommand(ideaCmd)
 	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(checkLinksCmd)
+	rootCmd.AddCommand(fmtCmd)
 	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(purgeCmd)
 	rootCmd.AddCommand(releaseCmd)
 	rootCmd.AddCommand(abandonCmd)
+	rootCmd.AddCommand(mergeCmd)
 	rootCmd.AddCommand(saveCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(idCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
 }
 
 func checkWorkDir() error {
@@ -40,3 +78,27 @@ func checkWorkDir() error {
 	}
 	return nil
 }
+
+// loadWorkspaceConfig checks for a kira workspace, loads its config, and
+// recreates any configured status folders that are missing on disk.
+func loadWorkspaceConfig() (*config.Config, error) {
+	if err := checkWorkDir(); err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	debugf("Loaded config with %d statuses and %d templates", len(cfg.StatusFolders), len(cfg.Templates))
+
+	if err := config.ValidateModes(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := config.EnsureStatusFolders(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"kira/internal/config"
+	"kira/internal/fsutil"
 	"kira/internal/validation"
 )
 
@@ -23,13 +24,9 @@ var saveCmd = &cobra.Command{
 Validates all non-archived work items before staging.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(_ *cobra.Command, args []string) error {
-		if err := checkWorkDir(); err != nil {
-			return err
-		}
-
-		cfg, err := config.LoadConfig()
+		cfg, err := loadWorkspaceConfig()
 		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+			return err
 		}
 
 		var commitMessage string
@@ -57,7 +54,7 @@ func saveWorkItems(cfg *config.Config, commitMessage string) error {
 	}
 
 	// Update timestamps for modified work items
-	if err := updateWorkItemTimestamps(); err != nil {
+	if err := updateWorkItemTimestamps(cfg); err != nil {
 		return fmt.Errorf("failed to update timestamps: %w", err)
 	}
 
@@ -87,12 +84,17 @@ func saveWorkItems(cfg *config.Config, commitMessage string) error {
 		return fmt.Errorf("failed to commit changes: %w", err)
 	}
 
-	fmt.Println("Work items saved and committed successfully.")
+	infof("Work items saved and committed successfully.")
 	return nil
 }
 
-func updateWorkItemTimestamps() error {
+func updateWorkItemTimestamps(cfg *config.Config) error {
 	currentTime := time.Now().Format("2006-01-02T15:04:05Z")
+	archiveFolder, err := config.FolderForStatus(cfg, "archived")
+	if err != nil {
+		return fmt.Errorf("no archive folder: %w", err)
+	}
+	archiveDir := filepath.Join(".work", archiveFolder)
 
 	return filepath.Walk(".work", func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -106,7 +108,7 @@ func updateWorkItemTimestamps() error {
 		// Skip template files, IDEAS.md, and archived items
 		if strings.Contains(path, "template") ||
 			strings.HasSuffix(path, "IDEAS.md") ||
-			strings.Contains(path, "z_archive") {
+			isWithinDir(path, archiveDir) {
 			return nil
 		}
 
@@ -152,7 +154,7 @@ func updateFileTimestamp(filePath, timestamp string) error {
 		}
 	}
 
-	return os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0o600)
+	return fsutil.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0o600)
 }
 
 // sanitizeCommitMessage validates and sanitizes a commit message
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// readPassword reads a line from stdin's terminal with echo turned off. Echo
+// is restored before exiting if the read is interrupted with Ctrl+C. It is a
+// variable so tests can replace it.
+var readPassword = func() ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+	state, err := term.GetState(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	done := make(chan struct{})
+	defer func() {
+		signal.Stop(interrupts)
+		close(done)
+	}()
+	go func() {
+		select {
+		case <-interrupts:
+			_ = term.Restore(fd, state)
+			fmt.Fprintln(os.Stderr)
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	return term.ReadPassword(fd)
+}
+
+// promptSecret asks for a value without echoing it to the terminal.
+func promptSecret(prompt string) (string, error) {
+	return readSecret(os.Stdout, os.Stdin, prompt, term.IsTerminal(int(os.Stdin.Fd())))
+}
+
+// readSecret writes prompt to w and reads the value. On a terminal it is read
+// with echo off, and a failure to turn echo off is an error rather than a
+// reason to echo the secret. Otherwise a line is read from r, such as a value
+// piped to stdin, which is never echoed.
+func readSecret(w io.Writer, r io.Reader, prompt string, terminal bool) (string, error) {
+	fmt.Fprint(w, prompt)
+	if terminal {
+		value, err := readPassword()
+		// The newline typed after the value was not echoed either.
+		fmt.Fprintln(w)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret input: %w", err)
+		}
+		return strings.TrimSpace(string(value)), nil
+	}
+
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSecret(t *testing.T) {
+	// fakeTerminal makes readSecret's terminal reads return value and err,
+	// counting the calls.
+	fakeTerminal := func(t *testing.T, value string, err error) *int {
+		t.Helper()
+		calls := 0
+		prev := readPassword
+		readPassword = func() ([]byte, error) {
+			calls++
+			return []byte(value), err
+		}
+		t.Cleanup(func() { readPassword = prev })
+		return &calls
+	}
+
+	t.Run("reads from the terminal without echo", func(t *testing.T) {
+		calls := fakeTerminal(t, "s3cret", nil)
+
+		var out bytes.Buffer
+		value, err := readSecret(&out, strings.NewReader("ignored\n"), "Enter token: ", true)
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret", value)
+		assert.Equal(t, 1, *calls)
+		assert.Equal(t, "Enter token: \n", out.String())
+	})
+
+	t.Run("reads a line from stdin when not a terminal", func(t *testing.T) {
+		calls := fakeTerminal(t, "", nil)
+
+		var out bytes.Buffer
+		value, err := readSecret(&out, strings.NewReader("piped\n"), "Enter token: ", false)
+		require.NoError(t, err)
+		assert.Equal(t, "piped", value)
+		assert.Zero(t, *calls)
+		assert.Equal(t, "Enter token: ", out.String())
+	})
+
+	t.Run("fails instead of echoing when the terminal cannot be read", func(t *testing.T) {
+		fakeTerminal(t, "", errors.New("not a console"))
+
+		_, err := readSecret(&bytes.Buffer{}, strings.NewReader("typed\n"), "Enter token: ", true)
+		assert.ErrorContains(t, err, "not a console")
+	})
+}
@@ -0,0 +1,125 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/validation"
+)
+
+// readingWordsPerMinute is the reading speed used for reading time estimates.
+const readingWordsPerMinute = 200
+
+var showCmd = &cobra.Command{
+	Use:   "show <work-item-id>",
+	Short: "Print a work item",
+	Long: `Prints a work item file. With --stats, also prints the word count of its body and
+an estimated reading time at about 200 words per minute. With --raw, prints the
+file's bytes exactly as stored, without parsing it, for piping to a pager or
+another tool.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := loadWorkspaceConfig(); err != nil {
+			return err
+		}
+
+		stats, _ := cmd.Flags().GetBool("stats")
+		if raw, _ := cmd.Flags().GetBool("raw"); raw {
+			if stats {
+				return fmt.Errorf("--raw cannot be combined with --stats")
+			}
+			return showRawWorkItem(os.Stdout, args[0])
+		}
+		return showWorkItem(os.Stdout, args[0], stats)
+	},
+}
+
+func init() {
+	showCmd.Flags().Bool("stats", false, "Print the body's word count and estimated reading time")
+	showCmd.Flags().Bool("raw", false, "Print the file exactly as stored, without parsing it")
+}
+
+// showRawWorkItem writes the work item's file to w byte for byte.
+func showRawWorkItem(w io.Writer, workItemID string) error {
+	workItemPath, err := findWorkItemFileOrTitle(workItemID)
+	if err != nil {
+		return err
+	}
+	content, err := safeReadFile(workItemPath)
+	if err != nil {
+		return fmt.Errorf("failed to read work item: %w", err)
+	}
+	_, err = w.Write(content)
+	return err
+}
+
+// showWorkItem writes the work item's file to w, followed by body statistics
+// when stats is set.
+func showWorkItem(w io.Writer, workItemID string, stats bool) error {
+	workItemPath, err := findWorkItemFileOrTitle(workItemID)
+	if err != nil {
+		return err
+	}
+	content, err := safeReadFile(workItemPath)
+	if err != nil {
+		return fmt.Errorf("failed to read work item: %w", err)
+	}
+
+	if _, err := w.Write(content); err != nil {
+		return err
+	}
+	if !stats {
+		return nil
+	}
+
+	item, err := validation.ParseWorkItemContent(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse work item: %w", err)
+	}
+	words := countWords(item.Body)
+	_, err = fmt.Fprintf(w, "\nWords: %d\nReading time: %s\n", words, formatReadingTime(words))
+	return err
+}
+
+var (
+	markdownLinkPattern    = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	markdownCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+)
+
+// countWords counts the words in a markdown body, best effort: link targets,
+// HTML comments, and code fence markers are skipped, and tokens made only of
+// markdown syntax such as "#", "-", or a checkbox are not words.
+func countWords(body string) int {
+	body = markdownCommentPattern.ReplaceAllString(body, " ")
+	body = markdownLinkPattern.ReplaceAllString(body, "$1")
+
+	words := 0
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			continue
+		}
+		line = checkboxPattern.ReplaceAllString(line, "")
+		for _, field := range strings.Fields(line) {
+			if strings.IndexFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+				words++
+			}
+		}
+	}
+	return words
+}
+
+// formatReadingTime estimates how long words take to read, rounding up to
+// whole minutes.
+func formatReadingTime(words int) string {
+	if words == 0 {
+		return "0 min"
+	}
+	return fmt.Sprintf("%d min", (words+readingWordsPerMinute-1)/readingWordsPerMinute)
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountWords(t *testing.T) {
+	body := "\n# Login bug\n\n" +
+		"- [ ] Reproduce the [crash](https://example.com/issue/1) locally\n" +
+		"- [x] **Write** a `test`\n\n" +
+		"<!-- internal note -->\n" +
+		"```go\nfmt.Println(1)\n```\n"
+
+	// Login, bug, Reproduce, the, crash, locally, Write, a, test, fmt.Println(1)
+	assert.Equal(t, 10, countWords(body))
+	assert.Equal(t, 0, countWords("\n## \n- \n"))
+}
+
+func TestFormatReadingTime(t *testing.T) {
+	assert.Equal(t, "0 min", formatReadingTime(0))
+	assert.Equal(t, "1 min", formatReadingTime(1))
+	assert.Equal(t, "1 min", formatReadingTime(200))
+	assert.Equal(t, "2 min", formatReadingTime(201))
+}
+
+func TestShowWorkItem(t *testing.T) {
+	setup := func(t *testing.T) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+		content := "---\nid: 001\ntitle: Front matter words\nstatus: todo\nkind: task\ncreated: 2024-01-01\n---\n\n# Three body words\n"
+		require.NoError(t, os.WriteFile(".work/1_todo/001-test.task.md", []byte(content), 0o600))
+	}
+
+	t.Run("prints the work item file", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, showWorkItem(&buf, "001", false))
+		assert.True(t, strings.HasPrefix(buf.String(), "---\nid: 001\n"))
+		assert.NotContains(t, buf.String(), "Words:")
+	})
+
+	t.Run("counts only body words with --stats", func(t *testing.T) {
+		setup(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, showWorkItem(&buf, "001", true))
+		assert.Contains(t, buf.String(), "Words: 3\nReading time: 1 min\n")
+	})
+}
+
+func TestShowRawWorkItem(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+	// CRLF line endings, quoting, and a missing trailing newline must all survive.
+	original := []byte("---\r\nid: 001\r\ntitle: 'Raw'\r\nstatus: todo\r\nkind: task\r\n---\r\n\r\n# Body\t\r\nlast line")
+	require.NoError(t, os.WriteFile(".work/1_todo/001-raw.task.md", original, 0o600))
+
+	var buf bytes.Buffer
+	require.NoError(t, showRawWorkItem(&buf, "001"))
+	assert.Equal(t, original, buf.Bytes())
+}
@@ -0,0 +1,67 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+)
+
+var sweepCmd = &cobra.Command{
+	Use:   "sweep --from <status> --to <status>",
+	Short: "Move every work item in one status to another",
+	Long: `Moves every work item whose status is --from to the --to status, updating each
+item's front matter the same way as kira move. Useful at the end of a sprint, e.g.
+kira sweep --from done --to archived.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		_, err = sweepWorkItems(cfg, from, to)
+		return err
+	},
+}
+
+func init() {
+	sweepCmd.Flags().String("from", "", "Status to move work items out of")
+	sweepCmd.Flags().String("to", "", "Status to move work items into")
+	_ = sweepCmd.MarkFlagRequired("from")
+	_ = sweepCmd.MarkFlagRequired("to")
+}
+
+// sweepWorkItems moves every item in status from to status to and returns how
+// many were moved.
+func sweepWorkItems(cfg *config.Config, from, to string) (int, error) {
+	if _, err := config.FolderForStatus(cfg, from); err != nil {
+		return 0, fmt.Errorf("invalid source status: %s", from)
+	}
+	if _, err := config.FolderForStatus(cfg, to); err != nil {
+		return 0, fmt.Errorf("invalid target status: %s", to)
+	}
+
+	items, err := loadWorkItems(workItemFilter{Status: from})
+	if err != nil {
+		return 0, err
+	}
+	if len(items) == 0 {
+		infof("No work items in %s", from)
+		return 0, nil
+	}
+
+	for _, item := range items {
+		if _, err := relocateWorkItem(cfg, item.Path, to, ""); err != nil {
+			return 0, fmt.Errorf("failed to move work item %s: %w", item.ID, err)
+		}
+		debugf("Moved work item %s to %s", item.ID, to)
+	}
+
+	infof("Moved %d work items from %s to %s", len(items), from, to)
+	return len(items), nil
+}
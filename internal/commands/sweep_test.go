@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepWorkItems(t *testing.T) {
+	t.Run("moves every item in the source status", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "4_done", "001", "First", "done", "task")
+		writeTestWorkItem(t, "4_done", "002", "Second", "done", "issue")
+		writeTestWorkItem(t, "1_todo", "003", "Third", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/z_archive", 0o700))
+
+		moved, err := sweepWorkItems(newTestConfig(), "done", "archived")
+		require.NoError(t, err)
+		assert.Equal(t, 2, moved)
+
+		content, err := os.ReadFile(".work/z_archive/001-first.task.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "status: archived")
+		assert.FileExists(t, ".work/z_archive/002-second.issue.md")
+		assert.FileExists(t, ".work/1_todo/003-third.task.md")
+	})
+
+	t.Run("does nothing when the source status is empty", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+
+		moved, err := sweepWorkItems(newTestConfig(), "done", "archived")
+		require.NoError(t, err)
+		assert.Equal(t, 0, moved)
+		assert.FileExists(t, ".work/1_todo/001-first.task.md")
+	})
+
+	t.Run("rejects unknown statuses", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		_, err := sweepWorkItems(newTestConfig(), "finished", "archived")
+		require.EqualError(t, err, "invalid source status: finished")
+
+		_, err = sweepWorkItems(newTestConfig(), "done", "attic")
+		require.EqualError(t, err, "invalid target status: attic")
+	})
+}
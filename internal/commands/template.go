@@ -0,0 +1,224 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+	"kira/internal/fsutil"
+	"kira/internal/templates"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage work item templates",
+}
+
+var templateNewCmd = &cobra.Command{
+	Use:   "new <name>",
+	Short: "Scaffold a new template",
+	Long: `Writes a starter template with example input declarations to
+templates/template.<name>.md under the template directory, and registers it
+under templates in kira.yml. Existing templates are never overwritten.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		path, err := scaffoldTemplate(cfg, args[0])
+		if err != nil {
+			return err
+		}
+		infof("Created template %s at %s", args[0], path)
+		return nil
+	},
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List templates",
+	Long: `Lists the configured templates and where each is read from. A template whose
+file is missing falls back to the built-in template of the same name, if any.
+With --builtin, lists the templates built into kira instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		builtin, _ := cmd.Flags().GetBool("builtin")
+		return listTemplates(os.Stdout, cfg, builtin)
+	},
+}
+
+var templateValidateCmd = &cobra.Command{
+	Use:   "validate <name>",
+	Short: "Check that a template parses",
+	Long: `Loads a configured template and reports every problem found: includes that cannot
+be read, inputs with unknown types or attributes, options declared on number
+inputs, an input name declared more than once with different definitions, and an
+invalid schema.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		return validateTemplate(os.Stdout, cfg, args[0])
+	},
+}
+
+var templateRenderCmd = &cobra.Command{
+	Use:   "render <name>",
+	Short: "Preview a template rendered with sample inputs",
+	Long: `Renders a configured template with the given --input values and prints the
+result, as kira new would write it. Derived variables such as slug are filled
+in and inputs without a value get their defaults. No file is written and no
+ID is used up.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		inputFlags, _ := cmd.Flags().GetStringArray("input")
+		inputs, err := parseInputFlags(inputFlags)
+		if err != nil {
+			return err
+		}
+		return renderTemplate(os.Stdout, cfg, args[0], inputs)
+	},
+}
+
+func init() {
+	templateListCmd.Flags().Bool("builtin", false, "List the templates built into kira")
+	templateRenderCmd.Flags().StringArrayP("input", "i", nil, "Provide an input value (e.g., --input title=Login); repeatable")
+	templateCmd.AddCommand(templateNewCmd)
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateValidateCmd)
+	templateCmd.AddCommand(templateRenderCmd)
+}
+
+// listTemplates writes each configured template with the file it is read
+// from, or the built-in template names when builtin is set.
+func listTemplates(w io.Writer, cfg *config.Config, builtin bool) error {
+	if builtin {
+		for _, name := range templates.BuiltinNames() {
+			if _, err := fmt.Fprintln(w, name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	names := make([]string, 0, len(cfg.Templates))
+	for name := range cfg.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		source := templateFilePath(cfg, name)
+		if _, err := os.Stat(source); os.IsNotExist(err) {
+			source = "missing"
+			if _, ok := templates.BuiltinForPath(templateFilePath(cfg, name)); ok {
+				source = "built-in"
+			}
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\n", name, source); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+var templateNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// scaffoldTemplate writes a starter template for name and registers it in the
+// config file, returning the template file's path.
+func scaffoldTemplate(cfg *config.Config, name string) (string, error) {
+	if !templateNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid template name '%s': use lowercase letters, digits, '-' and '_'", name)
+	}
+	if existing, exists := cfg.Templates[name]; exists {
+		return "", fmt.Errorf("template '%s' is already configured (%s)", name, existing)
+	}
+
+	relPath := filepath.ToSlash(filepath.Join("templates", "template."+name+".md"))
+	path := filepath.Join(cfg.TemplateDir, relPath)
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("template file %s already exists", path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), cfg.DirPerm()); err != nil {
+		return "", fmt.Errorf("failed to create template directory: %w", err)
+	}
+	if err := fsutil.WriteFile(path, []byte(templates.StarterTemplate(name)), cfg.FilePerm()); err != nil {
+		return "", fmt.Errorf("failed to write template: %w", err)
+	}
+
+	if err := config.SetValue("templates."+name, relPath); err != nil {
+		return "", fmt.Errorf("failed to register template: %w", err)
+	}
+	cfg.Templates[name] = relPath
+	return path, nil
+}
+
+// renderTemplate writes the named template rendered with inputs to w. Values
+// are checked against the template's input declarations first.
+func renderTemplate(w io.Writer, cfg *config.Config, name string, inputs map[string]string) error {
+	if _, exists := cfg.Templates[name]; !exists {
+		return fmt.Errorf("template '%s' is not configured", name)
+	}
+	if err := validateInputs(cfg, name, inputs); err != nil {
+		return err
+	}
+
+	content, err := templates.ProcessTemplateWithOptions(templateFilePath(cfg, name), inputs, templateOptions(cfg))
+	if err != nil {
+		return fmt.Errorf("failed to process template: %w", err)
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
+
+// validateTemplate writes each problem found in the named template to w,
+// returning an error when there are any.
+func validateTemplate(w io.Writer, cfg *config.Config, name string) error {
+	if _, exists := cfg.Templates[name]; !exists {
+		return fmt.Errorf("template '%s' is not configured", name)
+	}
+
+	path := templateFilePath(cfg, name)
+	problems := templates.CheckTemplate(path, templateOptions(cfg))
+	if len(problems) == 0 {
+		if _, err := templateDefaultStatus(cfg, name); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if len(problems) == 0 {
+		infof("Template %s is valid", name)
+		return nil
+	}
+
+	for _, problem := range problems {
+		if _, err := fmt.Fprintf(w, "  %s: %v\n", path, problem); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("template %s has %d problem(s)", name, len(problems))
+}
@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kira/internal/config"
+)
+
+func TestScaffoldTemplate(t *testing.T) {
+	t.Run("writes a starter template and registers it", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+		require.NoError(t, os.WriteFile("kira.yml", []byte("# workspace config\ndefault_status: todo\n"), 0o600))
+		cfg, err := config.LoadConfig()
+		require.NoError(t, err)
+
+		path, err := scaffoldTemplate(cfg, "bug")
+		require.NoError(t, err)
+		assert.Equal(t, ".work/templates/template.bug.md", path)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "kind: bug")
+		assert.Contains(t, string(content), "<!--input-string:title:")
+
+		raw, err := os.ReadFile("kira.yml")
+		require.NoError(t, err)
+		assert.Contains(t, string(raw), "# workspace config")
+
+		reloaded, err := config.LoadConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "templates/template.bug.md", reloaded.Templates["bug"])
+		assert.Equal(t, "templates/template.prd.md", reloaded.Templates["prd"])
+
+		require.NoError(t, createWorkItem(reloaded, []string{"bug", "Crash on save"}, false, map[string]string{}, false))
+		assert.FileExists(t, ".work/1_todo/001-crash-on-save.bug.md")
+	})
+
+	t.Run("refuses to overwrite an existing template", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/templates", 0o700))
+		require.NoError(t, os.WriteFile(".work/templates/template.bug.md", []byte("custom"), 0o600))
+
+		_, err := scaffoldTemplate(newTestConfig(), "prd")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already configured")
+
+		_, err = scaffoldTemplate(newTestConfig(), "bug")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+
+		content, err := os.ReadFile(".work/templates/template.bug.md")
+		require.NoError(t, err)
+		assert.Equal(t, "custom", string(content))
+		assert.NoFileExists(t, "kira.yml")
+	})
+
+	t.Run("rejects invalid names", func(t *testing.T) {
+		_, err := scaffoldTemplate(newTestConfig(), "../evil")
+		require.Error(t, err)
+	})
+}
+
+func TestListTemplates(t *testing.T) {
+	t.Run("lists built-in templates", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, listTemplates(&buf, newTestConfig(), true))
+		assert.Equal(t, "issue\nprd\nspike\ntask\n", buf.String())
+	})
+
+	t.Run("shows where each configured template is read from", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/templates", 0o700))
+		require.NoError(t, os.WriteFile(".work/templates/template.task.md", []byte("kind: task\n"), 0o600))
+		cfg := newTestConfig()
+		cfg.Templates["custom"] = "templates/template.custom.md"
+
+		var buf bytes.Buffer
+		require.NoError(t, listTemplates(&buf, cfg, false))
+		assert.Contains(t, buf.String(), "custom  missing\n")
+		assert.Contains(t, buf.String(), "prd     built-in\n")
+		assert.Contains(t, buf.String(), "task    .work/templates/template.task.md\n")
+	})
+}
+
+func TestCreateWorkItemBuiltinTemplate(t *testing.T) {
+	t.Run("creates an item from a built-in template", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/templates", 0o700))
+		require.NoError(t, createWorkItem(newTestConfig(), []string{"task", "todo", "Built in"}, false, map[string]string{}, false))
+
+		content, err := os.ReadFile(".work/1_todo/001-built-in.task.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "kind: task")
+		assert.Contains(t, string(content), "title: Built in")
+	})
+
+	t.Run("prefers an on-disk template of the same name", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/templates", 0o700))
+		local := "---\nid: <!--input-number:id:\"ID\"-->\ntitle: <!--input-string:title:\"Title\"-->\nkind: task\nsource: local\n---\n"
+		require.NoError(t, os.WriteFile(".work/templates/template.task.md", []byte(local), 0o600))
+		require.NoError(t, createWorkItem(newTestConfig(), []string{"task", "todo", "Local"}, false, map[string]string{}, false))
+
+		content, err := os.ReadFile(".work/1_todo/001-local.task.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "source: local")
+	})
+}
+
+func TestValidateTemplate(t *testing.T) {
+	t.Run("accepts a clean template", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, "---\ntitle: <!--input-string:title:\"Title\"-->\n---\n")
+
+		var buf bytes.Buffer
+		require.NoError(t, validateTemplate(&buf, cfg, "custom"))
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("reports a duplicate input name", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, "---\nowner: <!--input-string:owner:\"Owner\"-->\nreviewer: <!--input-string[a,b]:owner:\"Owner\"-->\n---\n")
+
+		var buf bytes.Buffer
+		err := validateTemplate(&buf, cfg, "custom")
+		require.EqualError(t, err, "template custom has 1 problem(s)")
+		assert.Contains(t, buf.String(), "input owner is declared more than once with different definitions")
+	})
+
+	t.Run("rejects an unconfigured template", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.Error(t, validateTemplate(&buf, newTestConfig(), "nope"))
+	})
+}
+
+func TestRenderTemplate(t *testing.T) {
+	t.Run("renders inputs and derived values without writing a file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		cfg := setupCustomTemplate(t, "---\nid: <!--input-number:id:\"ID\"-->\ntitle: <!--input-string:title:\"Title\"-->\nslug: <!--input-string:slug:\"Slug\"-->\n---\n# <!--input-string:title:\"Title\"-->\n")
+
+		var buf bytes.Buffer
+		require.NoError(t, renderTemplate(&buf, cfg, "custom", map[string]string{"title": "Fix Login Bug"}))
+		assert.Equal(t, "---\nid: 0\ntitle: Fix Login Bug\nslug: fix-login-bug\n---\n# Fix Login Bug\n", buf.String())
+
+		entries, err := os.ReadDir(filepath.Join(".work", "1_todo"))
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("rejects an unconfigured template", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.EqualError(t, renderTemplate(&buf, newTestConfig(), "nope", nil), "template 'nope' is not configured")
+	})
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+
+	"kira/internal/validation"
+)
+
+// writeWorkItemTree writes items as a forest along their depends_on edges:
+// each item is indented under the items it depends on, so an item with
+// several prerequisites appears under each of them. Items whose dependencies
+// are all outside items are roots. An edge that leads back into the current
+// branch is marked "(cycle)" and not followed.
+func writeWorkItemTree(w io.Writer, items []*validation.WorkItem) error {
+	byID := make(map[string]*validation.WorkItem, len(items))
+	for _, item := range items {
+		if _, exists := byID[item.ID]; !exists {
+			byID[item.ID] = item
+		}
+	}
+
+	dependents := make(map[string][]*validation.WorkItem)
+	hasPrerequisite := make(map[*validation.WorkItem]bool)
+	for _, item := range items {
+		for _, dep := range item.DependsOn {
+			if _, listed := byID[dep]; listed && dep != item.ID {
+				dependents[dep] = append(dependents[dep], item)
+				hasPrerequisite[item] = true
+			}
+		}
+	}
+
+	tree := workItemTree{w: w, dependents: dependents, visited: make(map[*validation.WorkItem]bool), onBranch: make(map[*validation.WorkItem]bool)}
+	for _, item := range items {
+		if !hasPrerequisite[item] {
+			if err := tree.write(item, "", ""); err != nil {
+				return err
+			}
+		}
+	}
+	// Items caught in a cycle have no root above them; start from the first
+	// one not yet shown so the cycle is still listed and flagged.
+	for _, item := range items {
+		if !tree.visited[item] {
+			if err := tree.write(item, "", ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type workItemTree struct {
+	w          io.Writer
+	dependents map[string][]*validation.WorkItem
+	visited    map[*validation.WorkItem]bool
+	onBranch   map[*validation.WorkItem]bool
+}
+
+// write writes item after prefix, then its dependents below it, indented by
+// indent plus the branch lines.
+func (t *workItemTree) write(item *validation.WorkItem, prefix, indent string) error {
+	line := fmt.Sprintf("%s%s %s [%s]", prefix, item.ID, item.Title, item.Status)
+	if t.onBranch[item] {
+		_, err := fmt.Fprintln(t.w, line+" (cycle)")
+		return err
+	}
+	if _, err := fmt.Fprintln(t.w, line); err != nil {
+		return err
+	}
+
+	t.visited[item] = true
+	t.onBranch[item] = true
+	defer delete(t.onBranch, item)
+
+	children := t.dependents[item.ID]
+	for i, child := range children {
+		branch, next := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, next = "└── ", "    "
+		}
+		if err := t.write(child, indent+branch, indent+next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
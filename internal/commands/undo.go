@@ -0,0 +1,205 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+
+	"kira/internal/config"
+	"kira/internal/fsutil"
+)
+
+// operationLogPath records recent file operations so they can be undone.
+var operationLogPath = filepath.Join(".work", ".kira-ops")
+
+// maxLoggedOperations is the number of operations kept in the log.
+const maxLoggedOperations = 20
+
+const (
+	operationMove   = "move"
+	operationDelete = "delete"
+	operationMerge  = "merge"
+)
+
+// operation is a single file change recorded in the operation log. Content
+// holds the file as it was before the change, so both the location and the
+// front matter edits made along with a move can be reverted. A merge deletes
+// Source after merging it into Dest; Edits holds every file it rewrote.
+type operation struct {
+	Kind    string          `yaml:"kind"`
+	Source  string          `yaml:"source"`
+	Dest    string          `yaml:"dest,omitempty"`
+	Content string          `yaml:"content"`
+	Edits   []operationEdit `yaml:"edits,omitempty"`
+	Time    string          `yaml:"time"`
+}
+
+// operationEdit is a file rewritten in place, with its content before the
+// rewrite.
+type operationEdit struct {
+	Path    string `yaml:"path"`
+	Content string `yaml:"content"`
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the most recent move, delete, or merge",
+	Long: `Reverses the most recent work item move, delete, or merge recorded in
+.work/.kira-ops. A moved item is put back in its original folder with its
+original content, a deleted item is restored, and a merge is reverted by
+restoring its source and every item it rewrote. Run it again to undo earlier operations; the last 20
+are kept.`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		op, err := undoLastOperation(cfg)
+		if err != nil {
+			return err
+		}
+		switch op.Kind {
+		case operationMove:
+			infof("Undid move: %s is back at %s", op.Dest, op.Source)
+		case operationMerge:
+			infof("Undid merge: restored %s and %d rewritten work items", op.Source, len(op.Edits))
+		default:
+			infof("Undid delete: restored %s", op.Source)
+		}
+		return nil
+	},
+}
+
+func loadOperations() ([]operation, error) {
+	content, err := safeReadFile(operationLogPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operation log: %w", err)
+	}
+
+	var ops []operation
+	if err := yaml.Unmarshal(content, &ops); err != nil {
+		return nil, fmt.Errorf("failed to parse operation log %s: %w", operationLogPath, err)
+	}
+	return ops, nil
+}
+
+func saveOperations(cfg *config.Config, ops []operation) error {
+	if len(ops) > maxLoggedOperations {
+		ops = ops[len(ops)-maxLoggedOperations:]
+	}
+	data, err := yaml.Marshal(ops)
+	if err != nil {
+		return fmt.Errorf("failed to encode operation log: %w", err)
+	}
+	if err := fsutil.WriteFile(operationLogPath, data, cfg.FilePerm()); err != nil {
+		return fmt.Errorf("failed to write operation log: %w", err)
+	}
+	return nil
+}
+
+// recordOperation appends op to the operation log. The change it describes has
+// already happened, so a failure to record it is only reported as a warning.
+func recordOperation(cfg *config.Config, op operation) {
+	op.Time = time.Now().Format(time.RFC3339)
+	ops, err := loadOperations()
+	if err == nil {
+		err = saveOperations(cfg, append(ops, op))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// removeWorkItemFile deletes a work item file, recording its content so the
+// delete can be undone.
+func removeWorkItemFile(cfg *config.Config, path string) error {
+	content, err := safeReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	recordOperation(cfg, operation{Kind: operationDelete, Source: path, Content: string(content)})
+	return nil
+}
+
+// undoLastOperation reverses the most recent logged operation and removes it
+// from the log. Nothing is changed if a file is in the way.
+func undoLastOperation(cfg *config.Config) (operation, error) {
+	ops, err := loadOperations()
+	if err != nil {
+		return operation{}, err
+	}
+	if len(ops) == 0 {
+		return operation{}, fmt.Errorf("nothing to undo")
+	}
+
+	op := ops[len(ops)-1]
+	if err := reverseOperation(cfg, op); err != nil {
+		return op, err
+	}
+	return op, saveOperations(cfg, ops[:len(ops)-1])
+}
+
+func reverseOperation(cfg *config.Config, op operation) error {
+	if _, err := os.Stat(op.Source); err == nil {
+		return fmt.Errorf("cannot undo %s: %s already exists", op.Kind, op.Source)
+	}
+
+	switch op.Kind {
+	case operationMove:
+		if _, err := os.Stat(op.Dest); err != nil {
+			return fmt.Errorf("cannot undo move: %s no longer exists", op.Dest)
+		}
+	case operationDelete:
+	case operationMerge:
+		for _, edit := range op.Edits {
+			if _, err := os.Stat(edit.Path); err != nil {
+				return fmt.Errorf("cannot undo merge: %s no longer exists", edit.Path)
+			}
+		}
+	default:
+		return fmt.Errorf("cannot undo unknown operation '%s'", op.Kind)
+	}
+
+	paths := []string{op.Source, op.Dest}
+	for _, edit := range op.Edits {
+		paths = append(paths, edit.Path)
+	}
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if err := validateWorkPath(path); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(op.Source), cfg.DirPerm()); err != nil {
+		return fmt.Errorf("failed to create folder: %w", err)
+	}
+	if err := fsutil.WriteFile(op.Source, []byte(op.Content), cfg.FilePerm()); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", op.Source, err)
+	}
+	for _, edit := range op.Edits {
+		if err := fsutil.WriteFile(edit.Path, []byte(edit.Content), cfg.FilePerm()); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", edit.Path, err)
+		}
+	}
+	if op.Kind == operationMove {
+		if err := os.Remove(op.Dest); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", op.Dest, err)
+		}
+	}
+	return nil
+}
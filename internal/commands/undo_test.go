@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndoLastOperation(t *testing.T) {
+	t.Run("undoes a move", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+		original, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+		require.NoError(t, moveWorkItem(newTestConfig(), "001", "doing", moveOptions{}))
+		require.NoFileExists(t, path)
+
+		op, err := undoLastOperation(newTestConfig())
+		require.NoError(t, err)
+		assert.Equal(t, operationMove, op.Kind)
+
+		restored, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, string(original), string(restored))
+		assert.NoFileExists(t, ".work/2_doing/001-first.task.md")
+	})
+
+	t.Run("undoes a delete", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+		original, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, removeWorkItemFile(newTestConfig(), path))
+		require.NoFileExists(t, path)
+
+		op, err := undoLastOperation(newTestConfig())
+		require.NoError(t, err)
+		assert.Equal(t, operationDelete, op.Kind)
+
+		restored, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, string(original), string(restored))
+	})
+
+	t.Run("undoes operations newest first", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+		require.NoError(t, os.MkdirAll(".work/2_doing", 0o700))
+		require.NoError(t, os.MkdirAll(".work/4_done", 0o700))
+		require.NoError(t, moveWorkItem(newTestConfig(), "001", "doing", moveOptions{}))
+		require.NoError(t, moveWorkItem(newTestConfig(), "001", "done", moveOptions{}))
+
+		_, err := undoLastOperation(newTestConfig())
+		require.NoError(t, err)
+		assert.FileExists(t, ".work/2_doing/001-first.task.md")
+
+		_, err = undoLastOperation(newTestConfig())
+		require.NoError(t, err)
+		assert.FileExists(t, ".work/1_todo/001-first.task.md")
+
+		_, err = undoLastOperation(newTestConfig())
+		assert.EqualError(t, err, "nothing to undo")
+	})
+
+	t.Run("refuses to overwrite a file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+		require.NoError(t, removeWorkItemFile(newTestConfig(), path))
+		writeTestWorkItem(t, "1_todo", "001", "First", "todo", "task")
+
+		_, err := undoLastOperation(newTestConfig())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+
+		ops, err := loadOperations()
+		require.NoError(t, err)
+		assert.Len(t, ops, 1)
+	})
+
+	t.Run("keeps only the most recent operations", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work", 0o700))
+		for i := 0; i < maxLoggedOperations+5; i++ {
+			recordOperation(newTestConfig(), operation{Kind: operationDelete, Source: ".work/1_todo/x.md"})
+		}
+
+		ops, err := loadOperations()
+		require.NoError(t, err)
+		assert.Len(t, ops, maxLoggedOperations)
+	})
+}
@@ -4,8 +4,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"kira/internal/config"
+	"kira/internal/fsutil"
+	"kira/internal/validation"
 )
 
 // validateWorkPath ensures a path is safe and within the .work directory
@@ -79,6 +86,19 @@ func safeReadProjectFile(filePath string) ([]byte, error) {
 
 // findWorkItemFile searches for a work item file by ID
 func findWorkItemFile(workItemID string) (string, error) {
+	foundPath, err := searchWorkItemFile(workItemID)
+	if err != nil {
+		return "", err
+	}
+	if foundPath == "" {
+		return "", fmt.Errorf("work item with ID %s not found", workItemID)
+	}
+	return foundPath, nil
+}
+
+// searchWorkItemFile returns the file of the work item with workItemID, or ""
+// when there is none.
+func searchWorkItemFile(workItemID string) (string, error) {
 	var foundPath string
 
 	err := filepath.Walk(".work", func(path string, info os.FileInfo, err error) error {
@@ -111,10 +131,6 @@ func findWorkItemFile(workItemID string) (string, error) {
 		return "", fmt.Errorf("failed to search for work item: %w", err)
 	}
 
-	if foundPath == "" {
-		return "", fmt.Errorf("work item with ID %s not found", workItemID)
-	}
-
 	return foundPath, nil
 }
 
@@ -133,7 +149,131 @@ func updateWorkItemStatus(filePath, newStatus string) error {
 		}
 	}
 
-	return os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0o600)
+	return fsutil.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0o600)
+}
+
+// frontMatterEnd returns the index of the closing --- line of the front matter,
+// or -1 if the lines do not start with a front matter block.
+func frontMatterEnd(lines []string) int {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return -1
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return i
+		}
+	}
+	return -1
+}
+
+// setFrontMatterField sets a field in a work item's front matter, replacing an
+// existing value or appending the field at the end of the front matter. The
+// value is written as YAML, see frontMatterScalar.
+func setFrontMatterField(filePath, key, value string) error {
+	content, err := safeReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	updated, err := setFrontMatterLine(string(content), key, value)
+	if err != nil {
+		return fmt.Errorf("%w in %s", err, filePath)
+	}
+	return fsutil.WriteFile(filePath, []byte(updated), 0o600)
+}
+
+// setFrontMatterLine is setFrontMatterField for content held in memory.
+func setFrontMatterLine(content, key, value string) (string, error) {
+	if value == "" {
+		return setFrontMatterYAML(content, key, "")
+	}
+	encoded, err := encodeYAMLNode(frontMatterScalar(key, value))
+	if err != nil {
+		return "", err
+	}
+	return setFrontMatterYAML(content, key, encoded)
+}
+
+// idValuePattern matches the IDs kira generates, such as 001 or PRD-001.
+var idValuePattern = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9_]*-)?[0-9]+$`)
+
+// frontMatterScalar returns the YAML node for a string value of key. Values are
+// quoted whenever a plain scalar would not read back as the same string, such
+// as @alice, a: b, x # y, 123, or true. IDs and dates are left plain, as in
+// id: 001 and created: 2024-01-01, because kira reads them back as written.
+func frontMatterScalar(key, value string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	switch {
+	case (key == "id" || key == "depends_on") && idValuePattern.MatchString(value):
+		node.Tag = ""
+	case isDateField(key):
+		if _, err := validation.ParseTimestamp(value); err == nil {
+			node.Tag = ""
+		}
+	}
+	if strings.Contains(value, "\n") {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+	return node
+}
+
+// isDateField reports whether key holds a date, matching the fields whose
+// format kira validates.
+func isDateField(key string) bool {
+	return key == "created" || key == "completed" || strings.Contains(key, "date") || strings.Contains(key, "due")
+}
+
+// encodeYAMLNode renders node as a single line of YAML.
+func encodeYAMLNode(node *yaml.Node) (string, error) {
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode front matter value: %w", err)
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// setFrontMatterYAML sets key to encoded, which must already be valid YAML.
+// An empty encoded value leaves the field present but null.
+func setFrontMatterYAML(content, key, encoded string) (string, error) {
+	lines := strings.Split(content, "\n")
+	end := frontMatterEnd(lines)
+	if end < 0 {
+		return "", fmt.Errorf("no front matter found")
+	}
+
+	newLine := strings.TrimRight(fmt.Sprintf("%s: %s", key, encoded), " ")
+	for i := 1; i < end; i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), key+":") {
+			lines[i] = newLine
+			return strings.Join(lines, "\n"), nil
+		}
+	}
+
+	lines = append(lines[:end], append([]string{newLine}, lines[end:]...)...)
+	return strings.Join(lines, "\n"), nil
+}
+
+// removeFrontMatterField deletes a field from a work item's front matter. It is
+// not an error if the field is absent.
+func removeFrontMatterField(filePath, key string) error {
+	content, err := safeReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	end := frontMatterEnd(lines)
+	if end < 0 {
+		return fmt.Errorf("no front matter found in %s", filePath)
+	}
+
+	for i := 1; i < end; i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), key+":") {
+			lines = append(lines[:i], lines[i+1:]...)
+			return fsutil.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0o600)
+		}
+	}
+	return nil
 }
 
 // getWorkItemFiles returns all work item files in a directory
@@ -159,13 +299,23 @@ func getWorkItemFiles(sourcePath string) ([]string, error) {
 	return files, err
 }
 
+// isWithinDir reports whether path is dir or lies beneath it.
+func isWithinDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // archiveWorkItems archives work items to the archive directory
-func archiveWorkItems(workItems []string, sourcePath string) (string, error) {
+func archiveWorkItems(cfg *config.Config, workItems []string, sourcePath string) (string, error) {
 	// Create archive directory
+	archiveFolder, err := config.FolderForStatus(cfg, "archived")
+	if err != nil {
+		return "", fmt.Errorf("no archive folder: %w", err)
+	}
 	date := time.Now().Format("2006-01-02")
-	archiveDir := filepath.Join(".work", "z_archive", date, filepath.Base(sourcePath))
+	archiveDir := filepath.Join(".work", archiveFolder, date, filepath.Base(sourcePath))
 
-	if err := os.MkdirAll(archiveDir, 0o700); err != nil {
+	if err := os.MkdirAll(archiveDir, cfg.DirPerm()); err != nil {
 		return "", fmt.Errorf("failed to create archive directory: %w", err)
 	}
 
@@ -179,7 +329,7 @@ func archiveWorkItems(workItems []string, sourcePath string) (string, error) {
 			return "", fmt.Errorf("failed to read work item: %w", err)
 		}
 
-		if err := os.WriteFile(archivePath, content, 0o600); err != nil {
+		if err := fsutil.WriteFile(archivePath, content, cfg.FilePerm()); err != nil {
 			return "", fmt.Errorf("failed to write to archive: %w", err)
 		}
 	}
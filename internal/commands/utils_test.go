@@ -3,10 +3,14 @@ package commands
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v3"
+
+	"kira/internal/validation"
 )
 
 func TestFindWorkItemFile(t *testing.T) {
@@ -153,7 +157,7 @@ title: Test Feature 2
 		workItems := []string{".work/work-item1.md", ".work/work-item2.md"}
 
 		// Archive work items
-		archivePath, err := archiveWorkItems(workItems, "source-dir")
+		archivePath, err := archiveWorkItems(newTestConfig(), workItems, "source-dir")
 		require.NoError(t, err)
 
 		// Check that archive directory was created
@@ -175,4 +179,112 @@ title: Test Feature 2
 		require.NoError(t, err)
 		assert.Contains(t, string(content2), "Test Feature 2")
 	})
+
+	t.Run("uses the configured archive folder", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := writeTestWorkItem(t, "1_todo", "001", "Shipped", "todo", "task")
+		cfg := newTestConfig()
+		cfg.StatusFolders["archived"] = "9_archive"
+
+		archivePath, err := archiveWorkItems(cfg, []string{path}, ".work/1_todo")
+		require.NoError(t, err)
+		assert.True(t, isWithinDir(archivePath, ".work/9_archive"), archivePath)
+		assert.FileExists(t, filepath.Join(archivePath, filepath.Base(path)))
+		assert.NoDirExists(t, ".work/z_archive")
+	})
+}
+
+func TestIsWithinDir(t *testing.T) {
+	assert.True(t, isWithinDir(".work/9_archive/2024-01-01/a.md", ".work/9_archive"))
+	assert.True(t, isWithinDir(".work/9_archive", ".work/9_archive"))
+	assert.False(t, isWithinDir(".work/9_archive_old/a.md", ".work/9_archive"))
+	assert.False(t, isWithinDir(".work/1_todo/z_archive-notes.md", ".work/9_archive"))
+}
+
+func TestSetFrontMatterField(t *testing.T) {
+	t.Run("replaces an existing field and appends a new one", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+		filePath := ".work/1_todo/001-item.md"
+		content := "---\nid: 001\nstatus: todo\n---\n\nstatus: in body\n"
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+
+		require.NoError(t, setFrontMatterField(filePath, "status", "doing"))
+		require.NoError(t, setFrontMatterField(filePath, "completed", "2024-01-02"))
+
+		updated, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, "---\nid: 001\nstatus: doing\ncompleted: 2024-01-02\n---\n\nstatus: in body\n", string(updated))
+	})
+
+	t.Run("encodes values that are not plain YAML strings", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := writeTestWorkItem(t, "1_todo", "001", "Item", "todo", "task")
+		values := map[string]string{
+			"assignee": "@alice",
+			"summary":  "a: b",
+			"note":     "x # y",
+			"estimate": "123",
+			"flag":     "true",
+			"reason":   "first line\nsecond line",
+		}
+		for key, value := range values {
+			require.NoError(t, setFrontMatterField(path, key, value))
+		}
+		require.NoError(t, setFrontMatterField(path, "due", "2024-03-01"))
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "\nassignee: '@alice'\n")
+		assert.Contains(t, string(content), "\nestimate: \"123\"\n")
+		assert.Contains(t, string(content), "\ndue: 2024-03-01\n")
+
+		var fields map[string]interface{}
+		lines := strings.Split(string(content), "\n")
+		require.NoError(t, yaml.Unmarshal([]byte(strings.Join(lines[1:frontMatterEnd(lines)], "\n")), &fields))
+		for key, value := range values {
+			assert.Equal(t, value, fields[key], key)
+		}
+	})
+
+	t.Run("encodes list elements", func(t *testing.T) {
+		content := "---\nid: 001\ntags:\n  - old\n---\n"
+
+		updated, err := setFrontMatterList(content, "tags", []string{"c#", "a,b", "@ops", "x # y"})
+		require.NoError(t, err)
+		updated, err = setFrontMatterList(updated, "depends_on", []string{"002", "PRD-003"})
+		require.NoError(t, err)
+		assert.Equal(t, "---\nid: 001\ntags: [c#, 'a,b', '@ops', 'x # y']\ndepends_on: [002, PRD-003]\n---\n", updated)
+
+		item, err := validation.ParseWorkItemContent([]byte(updated))
+		require.NoError(t, err)
+		assert.Equal(t, "c#, a,b, @ops, x # y", item.Field("tags"))
+		assert.Equal(t, validation.IDList{"002", "PRD-003"}, item.DependsOn)
+	})
+}
+
+func TestRemoveFrontMatterField(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	require.NoError(t, os.MkdirAll(".work/4_done", 0o700))
+	filePath := ".work/4_done/001-item.md"
+	require.NoError(t, os.WriteFile(filePath, []byte("---\nid: 001\ncompleted: 2024-01-01\n---\n\n# Body\n"), 0o600))
+
+	require.NoError(t, removeFrontMatterField(filePath, "completed"))
+	require.NoError(t, removeFrontMatterField(filePath, "missing"))
+
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "---\nid: 001\n---\n\n# Body\n", string(content))
 }
@@ -0,0 +1,163 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// velocityDateFormat is the format of the completed field and the --since and
+// --until flags.
+const velocityDateFormat = "2006-01-02"
+
+var velocityCmd = &cobra.Command{
+	Use:   "velocity",
+	Short: "Report estimate points completed per week",
+	Long: `Sums the estimate (or points) field of work items completed within a date window,
+using the completed date that kira done records, and reports the total per week.
+Weeks start on Monday. The window defaults to the four weeks ending today.
+Completed items without a numeric estimate count as zero and are listed in a note.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if _, err := loadWorkspaceConfig(); err != nil {
+			return err
+		}
+
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		untilFlag, _ := cmd.Flags().GetString("until")
+		since, until, err := velocityWindow(sinceFlag, untilFlag, time.Now())
+		if err != nil {
+			return err
+		}
+		return reportVelocity(os.Stdout, since, until)
+	},
+}
+
+func init() {
+	velocityCmd.Flags().String("since", "", "First completion date to count, YYYY-MM-DD (default: four weeks before --until)")
+	velocityCmd.Flags().String("until", "", "Last completion date to count, YYYY-MM-DD (default: today)")
+}
+
+// velocityWindow resolves the --since and --until flags into dates, defaulting
+// to the 28 days ending now.
+func velocityWindow(sinceFlag, untilFlag string, now time.Time) (time.Time, time.Time, error) {
+	until, err := time.Parse(velocityDateFormat, now.Format(velocityDateFormat))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if untilFlag != "" {
+		if until, err = time.Parse(velocityDateFormat, untilFlag); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --until date '%s': use YYYY-MM-DD", untilFlag)
+		}
+	}
+
+	since := until.AddDate(0, 0, -27)
+	if sinceFlag != "" {
+		if since, err = time.Parse(velocityDateFormat, sinceFlag); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --since date '%s': use YYYY-MM-DD", sinceFlag)
+		}
+	}
+
+	if since.After(until) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--since %s is after --until %s", since.Format(velocityDateFormat), until.Format(velocityDateFormat))
+	}
+	return since, until, nil
+}
+
+// velocityWeek totals the work items completed in the week starting on Start.
+type velocityWeek struct {
+	Start  time.Time
+	Items  int
+	Points float64
+}
+
+// reportVelocity writes the points completed each week between since and
+// until, inclusive, followed by the total.
+func reportVelocity(w io.Writer, since, until time.Time) error {
+	items, err := loadWorkItems(workItemFilter{})
+	if err != nil {
+		return err
+	}
+
+	var weeks []*velocityWeek
+	for start := weekStart(since); !start.After(until); start = start.AddDate(0, 0, 7) {
+		weeks = append(weeks, &velocityWeek{Start: start})
+	}
+
+	var totalItems int
+	var totalPoints float64
+	var unestimated []string
+	for _, item := range items {
+		completed, ok := completedDate(item.Field("completed"))
+		if !ok || completed.Before(since) || completed.After(until) {
+			continue
+		}
+
+		points, ok := workItemPoints(item.Field("estimate"), item.Field("points"))
+		if !ok {
+			unestimated = append(unestimated, item.ID)
+		}
+
+		week := weeks[int(completed.Sub(weeks[0].Start).Hours()/24)/7]
+		week.Items++
+		week.Points += points
+		totalItems++
+		totalPoints += points
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "WEEK OF\tITEMS\tPOINTS")
+	for _, week := range weeks {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", week.Start.Format(velocityDateFormat), week.Items, formatPoints(week.Points))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nTotal: %s points from %d items completed %s to %s\n",
+		formatPoints(totalPoints), totalItems, since.Format(velocityDateFormat), until.Format(velocityDateFormat))
+	if len(unestimated) > 0 {
+		fmt.Fprintf(w, "Note: %d items have no estimate and count as zero: %s\n", len(unestimated), strings.Join(unestimated, ", "))
+	}
+	return nil
+}
+
+// weekStart returns the Monday on or before day.
+func weekStart(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// completedDate parses the date part of a completed field.
+func completedDate(value string) (time.Time, bool) {
+	if len(value) < len(velocityDateFormat) {
+		return time.Time{}, false
+	}
+	date, err := time.Parse(velocityDateFormat, value[:len(velocityDateFormat)])
+	return date, err == nil
+}
+
+// workItemPoints reads an item's estimate, falling back to its points field.
+// It reports false when neither holds a number.
+func workItemPoints(estimate, points string) (float64, bool) {
+	for _, value := range []string{estimate, points} {
+		if value == "" {
+			continue
+		}
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed, true
+		}
+	}
+	return 0, false
+}
+
+func formatPoints(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64)
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportVelocity(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	complete := func(id, title, completed, field, value string) {
+		path := writeTestWorkItem(t, "4_done", id, title, "done", "task")
+		require.NoError(t, setFrontMatterField(path, "completed", completed))
+		if field != "" {
+			require.NoError(t, setFrontMatterField(path, field, value))
+		}
+	}
+	complete("001", "Monday", "2024-01-01", "estimate", "3")
+	complete("002", "Sunday", "2024-01-07", "points", "2.5")
+	complete("003", "Next week", "2024-01-10", "", "")
+	complete("004", "Too late", "2024-01-20", "estimate", "8")
+	complete("005", "Too early", "2023-12-31", "estimate", "5")
+	writeTestWorkItem(t, "1_todo", "006", "Not done", "todo", "task")
+
+	since, until, err := velocityWindow("2024-01-01", "2024-01-14", time.Now())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, reportVelocity(&buf, since, until))
+
+	expected := "WEEK OF     ITEMS  POINTS\n" +
+		"2024-01-01  2      5.5\n" +
+		"2024-01-08  1      0\n" +
+		"\nTotal: 5.5 points from 3 items completed 2024-01-01 to 2024-01-14\n" +
+		"Note: 1 items have no estimate and count as zero: 003\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestVelocityWindow(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	since, until, err := velocityWindow("", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-02-17", since.Format(velocityDateFormat))
+	assert.Equal(t, "2024-03-15", until.Format(velocityDateFormat))
+
+	_, _, err = velocityWindow("2024-03-20", "2024-03-01", now)
+	assert.Error(t, err)
+
+	_, _, err = velocityWindow("last week", "", now)
+	assert.Error(t, err)
+}
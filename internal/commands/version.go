@@ -2,7 +2,10 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -15,10 +18,45 @@ var (
 	Dirty     = "clean"
 )
 
+// formatText is the default, human-readable output of kira version.
+const formatText = "text"
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the kira version and build info",
-	Run: func(_ *cobra.Command, _ []string) {
-		fmt.Printf("Version: %s\nCommit: %s\nBuildDate: %s\nState: %s\n", Version, Commit, BuildDate, Dirty)
+	Long: `Prints the version, commit, build date, and working tree state kira was built
+from. --format json prints them as a JSON object for scripts and bug reports.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		return writeVersion(os.Stdout, format)
 	},
 }
+
+func init() {
+	versionCmd.Flags().String("format", formatText, "Output format: text or json")
+}
+
+// versionInfo is the JSON shape of kira version --format json.
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	State     string `json:"state"`
+}
+
+// writeVersion writes the build information to w in format.
+func writeVersion(w io.Writer, format string) error {
+	info := versionInfo{Version: Version, Commit: Commit, BuildDate: BuildDate, State: Dirty}
+	switch format {
+	case formatText, "":
+		_, err := fmt.Fprintf(w, "Version: %s\nCommit: %s\nBuildDate: %s\nState: %s\n", info.Version, info.Commit, info.BuildDate, info.State)
+		return err
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	default:
+		return fmt.Errorf("invalid format '%s' (valid: %s, %s)", format, formatText, formatJSON)
+	}
+}
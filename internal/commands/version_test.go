@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteVersion(t *testing.T) {
+	// Stand in for the values -ldflags "-X ..." injects at build time.
+	prevVersion, prevCommit, prevDate, prevDirty := Version, Commit, BuildDate, Dirty
+	Version, Commit, BuildDate, Dirty = "v1.4.2", "abc1234", "2025-01-02T03:04:05Z", "clean"
+	t.Cleanup(func() { Version, Commit, BuildDate, Dirty = prevVersion, prevCommit, prevDate, prevDirty })
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeVersion(&buf, formatText))
+		assert.Equal(t, "Version: v1.4.2\nCommit: abc1234\nBuildDate: 2025-01-02T03:04:05Z\nState: clean\n", buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeVersion(&buf, formatJSON))
+
+		var info map[string]string
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &info))
+		assert.Equal(t, map[string]string{"version": "v1.4.2", "commit": "abc1234", "build_date": "2025-01-02T03:04:05Z", "state": "clean"}, info)
+	})
+
+	t.Run("rejects an unknown format", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.EqualError(t, writeVersion(&buf, "xml"), "invalid format 'xml' (valid: text, json)")
+	})
+}
@@ -0,0 +1,177 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"kira/internal/config"
+	"kira/internal/validation"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Lint work items as they change",
+	Long: `Watches .work for changes to work items and lints each changed item as soon as
+the writes settle. Press Ctrl+C to stop.
+
+Changes are found by polling the work item files every --interval rather than
+through file system notifications, so watch works the same on every platform
+and on network and container-mounted folders, and needs no per-folder watches
+as status folders come and go.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+
+		interval, _ := cmd.Flags().GetDuration("interval")
+		debounce, _ := cmd.Flags().GetDuration("debounce")
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive, got %s", interval)
+		}
+		if debounce < 0 {
+			return fmt.Errorf("--debounce cannot be negative, got %s", debounce)
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		watcher := newWorkItemWatcher(interval, debounce, func(paths []string) {
+			reportWatchedChanges(cfg, paths)
+		})
+
+		infof("Watching .work for changes (Ctrl+C to stop)...")
+		if err := watcher.run(ctx); err != nil {
+			return err
+		}
+		infof("Stopped watching.")
+		return nil
+	},
+}
+
+func init() {
+	watchCmd.Flags().Duration("interval", 250*time.Millisecond, "How often to check for changes")
+	watchCmd.Flags().Duration("debounce", 300*time.Millisecond, "Quiet period to wait for after a change before linting")
+}
+
+// fileState is the part of a file's metadata used to detect changes.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// workItemWatcher polls the work item files under .work and calls onChange with
+// the changed paths once no further changes have been seen for the debounce period.
+type workItemWatcher struct {
+	interval time.Duration
+	debounce time.Duration
+	onChange func(paths []string)
+	files    map[string]fileState
+}
+
+func newWorkItemWatcher(interval, debounce time.Duration, onChange func(paths []string)) *workItemWatcher {
+	return &workItemWatcher{interval: interval, debounce: debounce, onChange: onChange}
+}
+
+// run polls until ctx is cancelled. Changes already on disk when it starts are
+// taken as the baseline and not reported.
+func (w *workItemWatcher) run(ctx context.Context) error {
+	if _, err := w.poll(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	pending := make(map[string]bool)
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			changed, err := w.poll()
+			if err != nil {
+				return err
+			}
+			for _, path := range changed {
+				pending[path] = true
+				lastChange = now
+			}
+			if len(pending) == 0 || now.Sub(lastChange) < w.debounce {
+				continue
+			}
+
+			paths := make([]string, 0, len(pending))
+			for path := range pending {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			pending = make(map[string]bool)
+			w.onChange(paths)
+		}
+	}
+}
+
+// poll refreshes the file snapshot and returns the paths that were added,
+// modified, or removed since the previous poll.
+func (w *workItemWatcher) poll() ([]string, error) {
+	files, err := validation.WorkItemFiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan work items: %w", err)
+	}
+
+	current := make(map[string]fileState, len(files))
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			// The file was removed between the walk and the stat.
+			continue
+		}
+		current[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	var changed []string
+	for path, state := range current {
+		if previous, exists := w.files[path]; !exists || previous != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range w.files {
+		if _, exists := current[path]; !exists {
+			changed = append(changed, path)
+		}
+	}
+
+	w.files = current
+	return changed, nil
+}
+
+// reportWatchedChanges lints each changed work item and prints the outcome.
+func reportWatchedChanges(cfg *config.Config, paths []string) {
+	timestamp := time.Now().Format("15:04:05")
+	for _, path := range paths {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			fmt.Printf("[%s] %s: removed\n", timestamp, path)
+			continue
+		}
+
+		result := validation.ValidateWorkItemFile(cfg, path)
+		if !result.HasErrors() {
+			fmt.Printf("[%s] %s: ok\n", timestamp, path)
+			continue
+		}
+		for _, verr := range result.Errors {
+			fmt.Printf("[%s] %s\n", timestamp, verr.Error())
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkItemWatcher(t *testing.T) {
+	t.Run("fires once for a burst of writes to a work item", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		path := writeTestWorkItem(t, "1_todo", "001", "Watched", "todo", "task")
+
+		changes := make(chan []string, 10)
+		watcher := newWorkItemWatcher(10*time.Millisecond, 50*time.Millisecond, func(paths []string) {
+			changes <- paths
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- watcher.run(ctx) }()
+
+		// Let the watcher take its baseline snapshot before writing.
+		time.Sleep(30 * time.Millisecond)
+		require.NoError(t, setFrontMatterField(path, "priority", "low"))
+		require.NoError(t, setFrontMatterField(path, "priority", "high"))
+
+		select {
+		case paths := <-changes:
+			assert.Equal(t, []string{path}, paths)
+		case <-time.After(2 * time.Second):
+			t.Fatal("validation callback did not fire")
+		}
+
+		select {
+		case paths := <-changes:
+			t.Fatalf("unexpected second callback for %v", paths)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+	t.Run("rejects a non-positive interval and a negative debounce", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+		writeTestWorkItem(t, "1_todo", "001", "Watched", "todo", "task")
+		t.Cleanup(func() {
+			_ = watchCmd.Flags().Set("interval", "250ms")
+			_ = watchCmd.Flags().Set("debounce", "300ms")
+		})
+
+		require.NoError(t, watchCmd.Flags().Set("interval", "0s"))
+		assert.EqualError(t, watchCmd.RunE(watchCmd, nil), "--interval must be positive, got 0s")
+
+		require.NoError(t, watchCmd.Flags().Set("interval", "250ms"))
+		require.NoError(t, watchCmd.Flags().Set("debounce", "-1s"))
+		assert.EqualError(t, watchCmd.RunE(watchCmd, nil), "--debounce cannot be negative, got -1s")
+	})
+}
@@ -0,0 +1,148 @@
+// Package commands implements the CLI commands for the kira tool.
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"kira/internal/validation"
+)
+
+// whereExpr is a parsed --where expression: alternatives joined by ||, each a
+// list of conditions joined by &&. A nil whereExpr matches every item.
+type whereExpr [][]whereCondition
+
+// whereCondition compares one front matter field with a value.
+type whereCondition struct {
+	Field string
+	Op    string
+	Value string
+}
+
+const (
+	whereEquals    = "=="
+	whereNotEquals = "!="
+	whereContains  = "contains"
+)
+
+func (e whereExpr) matches(item *validation.WorkItem) bool {
+	if e == nil {
+		return true
+	}
+	for _, conditions := range e {
+		matched := true
+		for _, condition := range conditions {
+			if !condition.matches(item) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (c whereCondition) matches(item *validation.WorkItem) bool {
+	value := item.Field(c.Field)
+	switch c.Op {
+	case whereEquals:
+		return value == c.Value
+	case whereNotEquals:
+		return value != c.Value
+	default:
+		return strings.Contains(value, c.Value)
+	}
+}
+
+// whereToken is a lexical token of a --where expression.
+type whereToken struct {
+	text string
+	// quoted marks a value written in quotes, which is never an operator.
+	quoted bool
+}
+
+// tokenizeWhere splits a --where expression into fields, operators, values,
+// and && / || connectives. Values may be quoted with ' or " to include spaces
+// or operator characters.
+func tokenizeWhere(expr string) ([]whereToken, error) {
+	var tokens []whereToken
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case strings.HasPrefix(expr[i:], "&&"), strings.HasPrefix(expr[i:], "||"),
+			strings.HasPrefix(expr[i:], whereEquals), strings.HasPrefix(expr[i:], whereNotEquals):
+			tokens = append(tokens, whereToken{text: expr[i : i+2]})
+			i += 2
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote in '%s'", expr[i:])
+			}
+			tokens = append(tokens, whereToken{text: expr[i+1 : i+1+end], quoted: true})
+			i += end + 2
+		default:
+			end := i
+			for end < len(expr) && !strings.ContainsRune(" \t=!&|\"'", rune(expr[end])) {
+				end++
+			}
+			if end == i {
+				return nil, fmt.Errorf("unexpected '%s'", expr[i:])
+			}
+			tokens = append(tokens, whereToken{text: expr[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// parseWhere parses a --where expression of "field op value" conditions, where
+// op is ==, !=, or contains, joined by && and ||. && binds tighter than ||.
+func parseWhere(expr string) (whereExpr, error) {
+	tokens, err := tokenizeWhere(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --where: %w", err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("invalid --where: empty expression")
+	}
+
+	isConnective := func(t whereToken) bool { return !t.quoted && (t.text == "&&" || t.text == "||") }
+	isOperator := func(t whereToken) bool {
+		return !t.quoted && (t.text == whereEquals || t.text == whereNotEquals || t.text == whereContains)
+	}
+
+	result := whereExpr{nil}
+	for i := 0; ; {
+		if i+3 > len(tokens) {
+			return nil, fmt.Errorf("invalid --where: incomplete condition at the end of '%s'", expr)
+		}
+		field, op, value := tokens[i], tokens[i+1], tokens[i+2]
+		if field.quoted || isConnective(field) || isOperator(field) {
+			return nil, fmt.Errorf("invalid --where: expected a field name, got '%s'", field.text)
+		}
+		if !isOperator(op) {
+			return nil, fmt.Errorf("invalid --where: expected ==, !=, or contains after '%s', got '%s'", field.text, op.text)
+		}
+		if !value.quoted && (isConnective(value) || isOperator(value)) {
+			return nil, fmt.Errorf("invalid --where: expected a value after '%s %s'", field.text, op.text)
+		}
+		last := len(result) - 1
+		result[last] = append(result[last], whereCondition{Field: field.text, Op: op.text, Value: value.text})
+
+		i += 3
+		if i == len(tokens) {
+			return result, nil
+		}
+		switch next := tokens[i]; {
+		case next.text == "&&" && !next.quoted:
+		case next.text == "||" && !next.quoted:
+			result = append(result, nil)
+		default:
+			return nil, fmt.Errorf("invalid --where: expected && or || before '%s'", next.text)
+		}
+		i++
+	}
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWhere(t *testing.T) {
+	t.Run("parses conditions joined by && and ||", func(t *testing.T) {
+		expr, err := parseWhere(`kind==bug || kind == task && title contains "login page"`)
+		require.NoError(t, err)
+		assert.Equal(t, whereExpr{
+			{{Field: "kind", Op: "==", Value: "bug"}},
+			{{Field: "kind", Op: "==", Value: "task"}, {Field: "title", Op: "contains", Value: "login page"}},
+		}, expr)
+	})
+
+	t.Run("accepts an empty quoted value", func(t *testing.T) {
+		expr, err := parseWhere(`assignee==''`)
+		require.NoError(t, err)
+		assert.Equal(t, whereExpr{{{Field: "assignee", Op: "==", Value: ""}}}, expr)
+	})
+
+	t.Run("rejects malformed expressions", func(t *testing.T) {
+		for _, expr := range []string{
+			"",
+			"status",
+			"status todo",
+			"status==",
+			"status==todo &&",
+			"status==todo kind==task",
+			"==todo",
+			`title=="unterminated`,
+			"status=todo",
+		} {
+			_, err := parseWhere(expr)
+			assert.Error(t, err, expr)
+		}
+	})
+}
+
+func TestListWorkItemsWhere(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir("/") }()
+
+	writeTestWorkItem(t, "1_todo", "001", "Fix login page", "todo", "bug")
+	writeTestWorkItem(t, "1_todo", "002", "Add export", "todo", "task")
+	writeTestWorkItem(t, "2_doing", "003", "Speed up login", "doing", "task")
+	path := writeTestWorkItem(t, "1_todo", "004", "Write docs", "todo", "task")
+	require.NoError(t, setFrontMatterField(path, "priority", "high"))
+
+	list := func(t *testing.T, where string) []string {
+		t.Helper()
+		expr, err := parseWhere(where)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, newTestConfig(), listOptions{Filter: workItemFilter{Where: expr}}))
+		return listedIDs(buf.String())
+	}
+
+	assert.Equal(t, []string{"002", "004"}, list(t, "status==todo && kind==task"), "&&")
+	assert.Equal(t, []string{"001", "002", "003"}, list(t, "priority!=high"), "!=")
+	assert.Equal(t, []string{"001", "003"}, list(t, "title contains login"), "contains")
+	assert.Equal(t, []string{"003", "004"}, list(t, "status==doing || priority==high"), "||")
+	assert.Equal(t, []string{"001", "004"}, list(t, "kind==bug || status==todo && priority==high"), "precedence")
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"kira/internal/config"
+)
+
+// workDirEnv names the environment variable that plays the part of --work-dir.
+const workDirEnv = "KIRA_WORK_DIR"
+
+// workDir is the --work-dir flag: the project to run against instead of the
+// current directory.
+var workDir string
+
+// pathArgsAnnotation marks a command whose positional arguments are file
+// paths given relative to the directory kira was started in.
+const pathArgsAnnotation = "kira_path_args"
+
+// enterWorkDir switches to the project chosen by --work-dir or KIRA_WORK_DIR,
+// if any, before a command runs. File paths given on the command line are made
+// absolute first so they still point where the user meant.
+func enterWorkDir(cmd *cobra.Command, args []string) error {
+	dir := workDir
+	if dir == "" {
+		dir = os.Getenv(workDirEnv)
+	}
+	if dir == "" {
+		return nil
+	}
+
+	if err := absPathArgs(cmd, args); err != nil {
+		return err
+	}
+	return useWorkDir(dir, cmd != initCmd)
+}
+
+// absPathArgs makes the filename flags that were set on cmd absolute, along
+// with its positional arguments when it is annotated with pathArgsAnnotation.
+// Cobra hands the same args slice to RunE, so they are rewritten in place.
+func absPathArgs(cmd *cobra.Command, args []string) error {
+	var err error
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if _, isPath := flag.Annotations[cobra.BashCompFilenameExt]; !isPath || err != nil {
+			return
+		}
+		var path string
+		if path, err = absPath(flag.Value.String()); err == nil {
+			err = flag.Value.Set(path)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, ok := cmd.Annotations[pathArgsAnnotation]; !ok {
+		return nil
+	}
+	for i, arg := range args {
+		if args[i], err = absPath(arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// absPath returns path made absolute against the current directory. "-" and
+// the empty string are kept, as they do not name a file.
+func absPath(path string) (string, error) {
+	if path == "" || path == "-" {
+		return path, nil
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	return abs, nil
+}
+
+// useWorkDir makes dir the working directory. dir may be the project root or
+// its .work directory. Unless the project is about to be initialized, it must
+// already hold a .work directory and a kira.yml.
+func useWorkDir(dir string, requireWorkspace bool) error {
+	root := filepath.Clean(dir)
+	if filepath.Base(root) == ".work" {
+		root = filepath.Dir(root)
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return fmt.Errorf("work dir %s does not exist", dir)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("work dir %s is not a directory", dir)
+	}
+
+	if requireWorkspace {
+		if info, err := os.Stat(filepath.Join(root, ".work")); err != nil || !info.IsDir() {
+			return fmt.Errorf("work dir %s is not a kira workspace (no .work directory found)", dir)
+		}
+		_, rootErr := os.Stat(filepath.Join(root, "kira.yml"))
+		_, legacyErr := os.Stat(filepath.Join(root, ".work", "kira.yml"))
+		if rootErr != nil && legacyErr != nil {
+			return fmt.Errorf("work dir %s has no kira.yml config", dir)
+		}
+	}
+
+	if err := os.Chdir(root); err != nil {
+		return fmt.Errorf("failed to use work dir %s: %w", dir, err)
+	}
+	debugf("Using work dir %s (config %s)", root, config.FilePath())
+	return nil
+}
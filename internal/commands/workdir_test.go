@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseWorkDir(t *testing.T) {
+	// setup creates a project with one work item and starts in another directory.
+	setup := func(t *testing.T) string {
+		t.Helper()
+		project := t.TempDir()
+		require.NoError(t, os.Chdir(project))
+		t.Cleanup(func() { _ = os.Chdir("/") })
+		writeTestWorkItem(t, "1_todo", "001", "Elsewhere", "todo", "task")
+		require.NoError(t, os.WriteFile("kira.yml", []byte("version: \"1.0\"\n"), 0o600))
+		require.NoError(t, os.Chdir(t.TempDir()))
+		return project
+	}
+
+	t.Run("runs commands against the project", func(t *testing.T) {
+		project := setup(t)
+
+		require.NoError(t, useWorkDir(project, true))
+		cfg, err := loadWorkspaceConfig()
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, listWorkItems(&buf, cfg, listOptions{}))
+		assert.Equal(t, []string{"001"}, listedIDs(buf.String()))
+
+		require.NoError(t, moveWorkItem(cfg, "001", "doing", moveOptions{}))
+		assert.FileExists(t, filepath.Join(project, ".work", "2_doing", "001-elsewhere.task.md"))
+	})
+
+	t.Run("accepts the .work directory itself", func(t *testing.T) {
+		project := setup(t)
+
+		require.NoError(t, useWorkDir(filepath.Join(project, ".work"), true))
+		path, err := findWorkItemFile("001")
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(project, path))
+	})
+
+	t.Run("reads KIRA_WORK_DIR when the flag is not set", func(t *testing.T) {
+		project := setup(t)
+		t.Setenv(workDirEnv, project)
+
+		require.NoError(t, enterWorkDir(listCmd, nil))
+		_, err := findWorkItemFile("001")
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects a missing directory", func(t *testing.T) {
+		project := setup(t)
+
+		err := useWorkDir(filepath.Join(project, "nope"), true)
+		assert.EqualError(t, err, "work dir "+filepath.Join(project, "nope")+" does not exist")
+	})
+
+	t.Run("rejects a directory that is not a workspace", func(t *testing.T) {
+		setup(t)
+		empty := t.TempDir()
+
+		err := useWorkDir(empty, true)
+		assert.EqualError(t, err, "work dir "+empty+" is not a kira workspace (no .work directory found)")
+	})
+
+	t.Run("rejects a workspace without a config", func(t *testing.T) {
+		project := setup(t)
+		require.NoError(t, os.Remove(filepath.Join(project, "kira.yml")))
+
+		err := useWorkDir(project, true)
+		assert.EqualError(t, err, "work dir "+project+" has no kira.yml config")
+	})
+	t.Run("keeps file paths relative to the starting directory", func(t *testing.T) {
+		project := setup(t)
+		t.Setenv(workDirEnv, project)
+		start, err := os.Getwd()
+		require.NoError(t, err)
+
+		require.NoError(t, exportCmd.Flags().Set("out", "report.json"))
+		t.Cleanup(func() { _ = exportCmd.Flags().Set("out", "") })
+		args := []string{"items.json"}
+
+		require.NoError(t, enterWorkDir(exportCmd, nil))
+		require.NoError(t, exportCmd.RunE(exportCmd, nil))
+		assert.FileExists(t, filepath.Join(start, "report.json"))
+		assert.NoFileExists(t, filepath.Join(project, "report.json"))
+
+		require.NoError(t, os.Chdir(start))
+		require.NoError(t, enterWorkDir(importCmd, args))
+		assert.Equal(t, filepath.Join(start, "items.json"), args[0])
+	})
+}
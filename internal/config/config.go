@@ -3,31 +3,159 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	yaml "gopkg.in/yaml.v3"
+
+	"kira/internal/fsutil"
 )
 
 // Config represents the kira configuration structure.
 type Config struct {
 	Version       string            `yaml:"version"`
 	Templates     map[string]string `yaml:"templates"`
+	TemplateDir   string            `yaml:"template_dir"`
 	StatusFolders map[string]string `yaml:"status_folders"`
 	Validation    ValidationConfig  `yaml:"validation"`
 	Commit        CommitConfig      `yaml:"commit"`
 	Release       ReleaseConfig     `yaml:"release"`
 	DefaultStatus string            `yaml:"default_status"`
+	DoneStatus    string            `yaml:"done_status"`
+	Priorities    []string          `yaml:"priorities"`
+	// TrackHistory appends a line to a work item's ## History section on each status change.
+	TrackHistory bool `yaml:"track_history"`
+	// StatusTemplates limits which templates may be created into a status.
+	// Statuses without an entry accept every template.
+	StatusTemplates map[string][]string `yaml:"status_templates,omitempty"`
+	// FilenameFormat names new work item files using {id}, {slug}, {kind}
+	// (or {template}), {status}, and {date} placeholders. Empty means DefaultFilenameFormat.
+	FilenameFormat string `yaml:"filename_format,omitempty"`
+	// StatusOrder lists statuses in workflow order. When empty, statuses are
+	// ordered by their folder names.
+	StatusOrder []string    `yaml:"status_order,omitempty"`
+	Hooks       HooksConfig `yaml:"hooks,omitempty"`
+	// RememberInputs offers the last value entered for each template input as
+	// the default in interactive prompts.
+	RememberInputs bool `yaml:"remember_inputs"`
+	// AutoGitAdd stages each new work item with git add, as kira new --git-add does.
+	AutoGitAdd bool `yaml:"auto_git_add,omitempty"`
+	// UseGitMv moves work items between status folders with git mv when
+	// their file is tracked in a git repository.
+	UseGitMv bool `yaml:"use_git_mv,omitempty"`
+	// IDPrefixPerKind maps a template or kind to an ID prefix. Items of that
+	// kind get IDs such as PRD-001, numbered separately for each prefix.
+	IDPrefixPerKind map[string]string `yaml:"id_prefix_per_kind,omitempty"`
+	// TemplateDescriptions holds a one-line description per template, shown
+	// next to its name when kira new asks which template to use.
+	TemplateDescriptions map[string]string `yaml:"template_descriptions,omitempty"`
+	// StrictTemplates makes kira new fail when a template input has no value
+	// instead of filling in the input type's default.
+	StrictTemplates bool `yaml:"strict_templates,omitempty"`
+	// TemplateDelimiters replaces the {{ and }} around include directives,
+	// for templates whose content uses {{ literally.
+	TemplateDelimiters []string `yaml:"template_delimiters,omitempty"`
+	// Transitions maps a status to the status kira advance moves items on to.
+	Transitions map[string]string `yaml:"transitions,omitempty"`
+	// AllowedTransitions limits the statuses kira move may take an item to
+	// from each status. Statuses without an entry may move anywhere.
+	AllowedTransitions map[string][]string `yaml:"allowed_transitions,omitempty"`
+	// PositionalDescription lets kira new take the description as its last
+	// positional argument. Unset means true; use DescriptionFromArgs to read it.
+	PositionalDescription *bool `yaml:"positional_description,omitempty"`
+	// FileMode and DirMode are the permissions, as octal strings such as
+	// "0644", given to the work item files and folders kira creates. Empty
+	// means DefaultFileMode and DefaultDirMode.
+	FileMode string `yaml:"file_mode,omitempty"`
+	DirMode  string `yaml:"dir_mode,omitempty"`
+	// TimestampFormat is how kira records the created field of new work
+	// items: TimestampDate (the default when empty) or TimestampDateTime.
+	TimestampFormat string `yaml:"timestamp_format,omitempty"`
+}
+
+// Timestamp formats for timestamp_format. TimestampDate records the day only,
+// as 2006-01-02; TimestampDateTime records an RFC 3339 timestamp, so items
+// created on the same day keep their order.
+const (
+	TimestampDate     = "date"
+	TimestampDateTime = "datetime"
+)
+
+// FormatTimestamp formats t for a work item's created field according to
+// timestamp_format.
+func (c *Config) FormatTimestamp(t time.Time) string {
+	if c.TimestampFormat == TimestampDateTime {
+		return t.Format(time.RFC3339)
+	}
+	return t.Format("2006-01-02")
+}
+
+// DefaultFileMode and DefaultDirMode are the permissions of created files and
+// folders when file_mode and dir_mode are not configured.
+const (
+	DefaultFileMode os.FileMode = 0o600
+	DefaultDirMode  os.FileMode = 0o700
+)
+
+// FilePerm returns the permissions for new files: file_mode, or
+// DefaultFileMode when it is unset or invalid.
+func (c *Config) FilePerm() os.FileMode {
+	if mode, err := ParseMode(c.FileMode, 0o600); err == nil {
+		return mode
+	}
+	return DefaultFileMode
+}
+
+// DirPerm returns the permissions for new folders: dir_mode, or
+// DefaultDirMode when it is unset or invalid.
+func (c *Config) DirPerm() os.FileMode {
+	if mode, err := ParseMode(c.DirMode, 0o700); err == nil {
+		return mode
+	}
+	return DefaultDirMode
+}
+
+// DescriptionFromArgs reports whether kira new reads a description from its
+// positional arguments, which it does unless positional_description is false.
+func (c *Config) DescriptionFromArgs() bool {
+	return c.PositionalDescription == nil || *c.PositionalDescription
 }
 
+// HooksConfig contains shell commands run around work item creation. Each
+// command runs with sh -c and receives the item's details as KIRA_*
+// environment variables.
+type HooksConfig struct {
+	// PreCreate runs before the work item file is written; a non-zero exit
+	// aborts creation.
+	PreCreate string `yaml:"pre_create,omitempty"`
+	// PostCreate runs after the work item file is written.
+	PostCreate string `yaml:"post_create,omitempty"`
+}
+
+// DefaultFilenameFormat is the work item filename format used when
+// filename_format is not configured.
+const DefaultFilenameFormat = "{id}-{slug}.{kind}.md"
+
 // ValidationConfig contains validation settings for work items.
 type ValidationConfig struct {
 	RequiredFields []string `yaml:"required_fields"`
 	IDFormat       string   `yaml:"id_format"`
 	StatusValues   []string `yaml:"status_values"`
+	// RequiredSections maps a kind to the body headings its work items must
+	// contain. Sections listed under "*" are required for every kind.
+	RequiredSections map[string][]string `yaml:"required_sections,omitempty"`
 }
 
+// AllKinds is the RequiredSections key whose sections apply to every kind.
+const AllKinds = "*"
+
 // CommitConfig contains git commit settings.
 type CommitConfig struct {
 	DefaultMessage string `yaml:"default_message"`
@@ -48,6 +176,7 @@ var DefaultConfig = Config{
 		"spike": "templates/template.spike.md",
 		"task":  "templates/template.task.md",
 	},
+	TemplateDir: ".work",
 	StatusFolders: map[string]string{
 		"backlog":  "0_backlog",
 		"todo":     "1_todo",
@@ -57,6 +186,8 @@ var DefaultConfig = Config{
 		"archived": "z_archive",
 	},
 	DefaultStatus: "backlog",
+	DoneStatus:    "done",
+	Priorities:    []string{"high", "medium", "low"},
 	Validation: ValidationConfig{
 		RequiredFields: []string{"id", "title", "status", "kind", "created"},
 		IDFormat:       "^\\d{3}$",
@@ -71,19 +202,48 @@ var DefaultConfig = Config{
 	},
 }
 
-// LoadConfig loads the configuration from kira.yml file or returns defaults.
+// Clone returns a deep copy of c, so that changes to the copy's maps and
+// slices leave c alone.
+func (c *Config) Clone() *Config {
+	clone := *c
+	clone.Templates = maps.Clone(c.Templates)
+	clone.StatusFolders = maps.Clone(c.StatusFolders)
+	clone.Priorities = slices.Clone(c.Priorities)
+	clone.StatusTemplates = cloneListMap(c.StatusTemplates)
+	clone.StatusOrder = slices.Clone(c.StatusOrder)
+	clone.IDPrefixPerKind = maps.Clone(c.IDPrefixPerKind)
+	clone.TemplateDescriptions = maps.Clone(c.TemplateDescriptions)
+	clone.TemplateDelimiters = slices.Clone(c.TemplateDelimiters)
+	clone.Transitions = maps.Clone(c.Transitions)
+	clone.AllowedTransitions = cloneListMap(c.AllowedTransitions)
+	if c.PositionalDescription != nil {
+		positional := *c.PositionalDescription
+		clone.PositionalDescription = &positional
+	}
+	clone.Validation.RequiredFields = slices.Clone(c.Validation.RequiredFields)
+	clone.Validation.StatusValues = slices.Clone(c.Validation.StatusValues)
+	clone.Validation.RequiredSections = cloneListMap(c.Validation.RequiredSections)
+	return &clone
+}
+
+func cloneListMap(m map[string][]string) map[string][]string {
+	if m == nil {
+		return nil
+	}
+	clone := make(map[string][]string, len(m))
+	for k, v := range m {
+		clone[k] = slices.Clone(v)
+	}
+	return clone
+}
+
+// LoadConfig loads the configuration from kira.yml file or returns a copy of
+// the defaults, which callers are free to modify.
 func LoadConfig() (*Config, error) {
 	// Prefer root-level kira.yml; fall back to legacy .work/kira.yml if present
-	rootPath := "kira.yml"
-	legacyPath := filepath.Join(".work", "kira.yml")
-
-	configPath := ""
-	if _, err := os.Stat(rootPath); err == nil {
-		configPath = rootPath
-	} else if _, err := os.Stat(legacyPath); err == nil {
-		configPath = legacyPath
-	} else {
-		return &DefaultConfig, nil
+	configPath := FilePath()
+	if _, err := os.Stat(configPath); err != nil {
+		return DefaultConfig.Clone(), nil
 	}
 
 	// Validate config path is safe (no path traversal)
@@ -118,6 +278,10 @@ func mergeWithDefaults(config *Config) {
 		}
 	}
 
+	if config.TemplateDir == "" {
+		config.TemplateDir = DefaultConfig.TemplateDir
+	}
+
 	if config.StatusFolders == nil {
 		config.StatusFolders = make(map[string]string)
 	}
@@ -128,13 +292,13 @@ func mergeWithDefaults(config *Config) {
 	}
 
 	if config.Validation.RequiredFields == nil {
-		config.Validation.RequiredFields = DefaultConfig.Validation.RequiredFields
+		config.Validation.RequiredFields = slices.Clone(DefaultConfig.Validation.RequiredFields)
 	}
 	if config.Validation.IDFormat == "" {
 		config.Validation.IDFormat = DefaultConfig.Validation.IDFormat
 	}
 	if config.Validation.StatusValues == nil {
-		config.Validation.StatusValues = DefaultConfig.Validation.StatusValues
+		config.Validation.StatusValues = slices.Clone(DefaultConfig.Validation.StatusValues)
 	}
 
 	if config.Commit.DefaultMessage == "" {
@@ -151,6 +315,12 @@ func mergeWithDefaults(config *Config) {
 	if config.DefaultStatus == "" {
 		config.DefaultStatus = DefaultConfig.DefaultStatus
 	}
+	if config.DoneStatus == "" {
+		config.DoneStatus = DefaultConfig.DoneStatus
+	}
+	if config.Priorities == nil {
+		config.Priorities = slices.Clone(DefaultConfig.Priorities)
+	}
 }
 
 // SaveConfig saves the configuration to kira.yml in the current directory.
@@ -172,9 +342,217 @@ func SaveConfigToDir(config *Config, targetDir string) error {
 		return fmt.Errorf("failed to ensure target directory: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0o600); err != nil {
+	if err := fsutil.WriteFile(configPath, data, 0o600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
 	return nil
 }
+
+var idPrefixPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// ValidateConfig checks that the configuration is internally consistent.
+func ValidateConfig(config *Config) error {
+	if len(config.StatusFolders) == 0 {
+		return fmt.Errorf("no status folders configured")
+	}
+	if _, exists := config.StatusFolders[config.DefaultStatus]; !exists {
+		return fmt.Errorf("default_status '%s' is not a configured status folder", config.DefaultStatus)
+	}
+	if _, err := regexp.Compile(config.Validation.IDFormat); err != nil {
+		return fmt.Errorf("invalid validation.id_format: %w", err)
+	}
+	if err := ValidateFilenameFormat(config.FilenameFormat); err != nil {
+		return err
+	}
+	for status, folder := range config.StatusFolders {
+		if err := validateStatusFolder(status, folder); err != nil {
+			return err
+		}
+	}
+	for _, status := range config.StatusOrder {
+		if _, exists := config.StatusFolders[status]; !exists {
+			return fmt.Errorf("status_order entry '%s' is not a configured status folder", status)
+		}
+	}
+	for kind, prefix := range config.IDPrefixPerKind {
+		if !idPrefixPattern.MatchString(prefix) {
+			return fmt.Errorf("id_prefix_per_kind entry '%s' has invalid prefix '%s': use letters, digits, and '_', starting with a letter", kind, prefix)
+		}
+	}
+	for from, to := range config.Transitions {
+		if _, exists := config.StatusFolders[from]; !exists {
+			return fmt.Errorf("transitions entry '%s' is not a configured status folder", from)
+		}
+		if _, exists := config.StatusFolders[to]; !exists {
+			return fmt.Errorf("transitions entry '%s' leads to '%s', which is not a configured status folder", from, to)
+		}
+	}
+	for from, targets := range config.AllowedTransitions {
+		if _, exists := config.StatusFolders[from]; !exists {
+			return fmt.Errorf("allowed_transitions entry '%s' is not a configured status folder", from)
+		}
+		for _, to := range targets {
+			if _, exists := config.StatusFolders[to]; !exists {
+				return fmt.Errorf("allowed_transitions entry '%s' allows '%s', which is not a configured status folder", from, to)
+			}
+		}
+	}
+	for name := range config.TemplateDescriptions {
+		if _, exists := config.Templates[name]; !exists {
+			return fmt.Errorf("template_descriptions entry '%s' is not a configured template", name)
+		}
+	}
+	if config.TemplateDelimiters != nil {
+		if len(config.TemplateDelimiters) != 2 || config.TemplateDelimiters[0] == "" || config.TemplateDelimiters[1] == "" {
+			return fmt.Errorf("template_delimiters must be a left and a right delimiter, such as [\"<<\", \">>\"]")
+		}
+	}
+	switch config.TimestampFormat {
+	case "", TimestampDate, TimestampDateTime:
+	default:
+		return fmt.Errorf("invalid timestamp_format '%s' (valid: %s, %s)", config.TimestampFormat, TimestampDate, TimestampDateTime)
+	}
+	for status := range config.StatusTemplates {
+		if _, exists := config.StatusFolders[status]; !exists {
+			return fmt.Errorf("status_templates entry '%s' is not a configured status folder", status)
+		}
+	}
+	return ValidateModes(config)
+}
+
+// ParseMode parses an octal permission string such as "0644" or "0o644". The
+// mode must keep the owner bits in required, so kira can still use what it
+// creates.
+func ParseMode(value string, required os.FileMode) (os.FileMode, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(value, "0o"), "0O")
+	mode, err := strconv.ParseUint(digits, 8, 32)
+	if err != nil || digits == "" || mode > 0o777 {
+		return 0, fmt.Errorf("'%s' is not an octal permission mode such as 0644", value)
+	}
+	if os.FileMode(mode)&required != required {
+		return 0, fmt.Errorf("'%s' must include %#o so kira can use what it creates", value, required)
+	}
+	return os.FileMode(mode), nil
+}
+
+// ValidateModes checks file_mode and dir_mode. Empty values are valid and mean
+// the defaults.
+func ValidateModes(config *Config) error {
+	if config.FileMode != "" {
+		if _, err := ParseMode(config.FileMode, 0o600); err != nil {
+			return fmt.Errorf("invalid file_mode: %w", err)
+		}
+	}
+	if config.DirMode != "" {
+		if _, err := ParseMode(config.DirMode, 0o700); err != nil {
+			return fmt.Errorf("invalid dir_mode: %w", err)
+		}
+	}
+	return nil
+}
+
+// ValidateFilenameFormat checks that a filename_format keeps work items
+// findable: it must include {id} and produce a markdown file. An empty format
+// is valid and means DefaultFilenameFormat.
+func ValidateFilenameFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	if !strings.Contains(format, "{id}") {
+		return fmt.Errorf("filename_format '%s' must contain {id}", format)
+	}
+	if !strings.HasSuffix(format, ".md") {
+		return fmt.Errorf("filename_format '%s' must end with .md", format)
+	}
+	if strings.ContainsAny(strings.NewReplacer("{id}", "", "{slug}", "", "{kind}", "", "{template}", "", "{status}", "", "{date}", "").Replace(format), `/\`) {
+		return fmt.Errorf("filename_format '%s' must not contain path separators", format)
+	}
+	return nil
+}
+
+// EnsureStatusFolders creates any status folders declared in the config that
+// are missing under .work. Existing folders are left untouched, and nothing is
+// created when any folder lies outside .work.
+func EnsureStatusFolders(config *Config) error {
+	for status, folder := range config.StatusFolders {
+		if err := validateStatusFolder(status, folder); err != nil {
+			return err
+		}
+	}
+	for status, folder := range config.StatusFolders {
+		if folder == "" {
+			continue
+		}
+		folderPath := filepath.Join(".work", folder)
+		if err := os.MkdirAll(folderPath, config.DirPerm()); err != nil {
+			return fmt.Errorf("failed to create status folder for '%s': %w", status, err)
+		}
+	}
+	return nil
+}
+
+// validateStatusFolder rejects a folder for status that would lie outside
+// .work, so that no command creates or moves files elsewhere.
+func validateStatusFolder(status, folder string) error {
+	clean := filepath.ToSlash(filepath.Clean(folder))
+	if folder != "" && (filepath.IsAbs(folder) || clean == ".." || strings.HasPrefix(clean, "../")) {
+		return fmt.Errorf("status folder '%s' for '%s' must be a path inside .work", folder, status)
+	}
+	return nil
+}
+
+// FolderForStatus returns the folder under .work configured for status. Folder
+// names are used exactly as configured, so they need not carry a numeric prefix.
+func FolderForStatus(config *Config, status string) (string, error) {
+	folder, exists := config.StatusFolders[status]
+	if !exists || folder == "" {
+		return "", fmt.Errorf("invalid status: %s", status)
+	}
+	return folder, nil
+}
+
+// OrderedStatuses returns the workflow order of statuses: status_order when
+// configured, otherwise every status sorted by folder name.
+func OrderedStatuses(config *Config) []string {
+	if len(config.StatusOrder) > 0 {
+		return config.StatusOrder
+	}
+
+	statuses := make([]string, 0, len(config.StatusFolders))
+	for status := range config.StatusFolders {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return config.StatusFolders[statuses[i]] < config.StatusFolders[statuses[j]]
+	})
+	return statuses
+}
+
+// StatusSeparator separates the parts of a nested status such as
+// "doing/blocked", whose folder is usually nested the same way.
+const StatusSeparator = "/"
+
+// StatusForFolder returns the status whose configured folder contains path.
+// path may be a bare folder name, a path relative to .work, or a path starting
+// with .work. Status folders may be nested, as in "2_doing/blocked"; the
+// deepest configured folder containing path wins.
+func StatusForFolder(config *Config, path string) (string, bool) {
+	rel := filepath.ToSlash(filepath.Clean(path))
+	rel = strings.TrimPrefix(rel, ".work/")
+
+	match, matchLen := "", -1
+	for status, configured := range config.StatusFolders {
+		if configured == "" {
+			continue
+		}
+		folder := filepath.ToSlash(filepath.Clean(configured))
+		if rel != folder && !strings.HasPrefix(rel, folder+"/") {
+			continue
+		}
+		if len(folder) > matchLen || (len(folder) == matchLen && status < match) {
+			match, matchLen = status, len(folder)
+		}
+	}
+	return match, matchLen >= 0
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,6 +23,23 @@ func TestLoadConfig(t *testing.T) {
 		assert.NotEmpty(t, config.StatusFolders)
 	})
 
+	t.Run("returns defaults that can be modified without changing DefaultConfig", func(t *testing.T) {
+		_ = os.Remove("kira.yml")
+		_ = os.Remove(".work/kira.yml")
+
+		config, err := LoadConfig()
+		require.NoError(t, err)
+		config.Templates["custom"] = "templates/template.custom.md"
+		config.StatusFolders["blocked"] = "5_blocked"
+		config.Priorities[0] = "urgent"
+		config.StrictTemplates = true
+
+		assert.NotContains(t, DefaultConfig.Templates, "custom")
+		assert.NotContains(t, DefaultConfig.StatusFolders, "blocked")
+		assert.Equal(t, "high", DefaultConfig.Priorities[0])
+		assert.False(t, DefaultConfig.StrictTemplates)
+	})
+
 	t.Run("loads config from file when exists", func(t *testing.T) {
 		// Create a test config file at root-level
 		testConfig := `version: "2.0"
@@ -39,6 +58,20 @@ status_folders:
 		assert.Equal(t, "custom/prd.md", config.Templates["prd"])
 		assert.Equal(t, "custom_todo", config.StatusFolders["todo"])
 	})
+
+	t.Run("reads the positional description by default", func(t *testing.T) {
+		require.NoError(t, os.WriteFile("kira.yml", []byte("version: \"1.0\"\n"), 0o600))
+		defer func() { _ = os.Remove("kira.yml") }()
+
+		config, err := LoadConfig()
+		require.NoError(t, err)
+		assert.True(t, config.DescriptionFromArgs())
+
+		require.NoError(t, os.WriteFile("kira.yml", []byte("positional_description: false\n"), 0o600))
+		config, err = LoadConfig()
+		require.NoError(t, err)
+		assert.False(t, config.DescriptionFromArgs())
+	})
 }
 
 func TestSaveConfig(t *testing.T) {
@@ -60,3 +93,221 @@ func TestSaveConfig(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestEnsureStatusFolders(t *testing.T) {
+	t.Run("recreates a deleted status folder", func(t *testing.T) {
+		originalDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(t.TempDir()))
+		defer func() { _ = os.Chdir(originalDir) }()
+
+		cfg := DefaultConfig
+		require.NoError(t, EnsureStatusFolders(&cfg))
+		for _, folder := range cfg.StatusFolders {
+			assert.DirExists(t, filepath.Join(".work", folder))
+		}
+
+		require.NoError(t, os.WriteFile(".work/1_todo/001-keep.md", []byte("keep"), 0o600))
+		require.NoError(t, os.RemoveAll(".work/2_doing"))
+
+		require.NoError(t, EnsureStatusFolders(&cfg))
+		assert.DirExists(t, ".work/2_doing")
+		// Existing folders and their contents are untouched
+		assert.FileExists(t, ".work/1_todo/001-keep.md")
+	})
+
+	t.Run("refuses folders outside .work", func(t *testing.T) {
+		originalDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(t.TempDir()))
+		defer func() { _ = os.Chdir(originalDir) }()
+
+		for _, folder := range []string{"../escaped", "/tmp/escaped"} {
+			cfg := Config{StatusFolders: map[string]string{"todo": "1_todo", "blocked": folder}}
+			err := EnsureStatusFolders(&cfg)
+			assert.EqualError(t, err, "status folder '"+folder+"' for 'blocked' must be a path inside .work")
+		}
+		assert.NoDirExists(t, "escaped")
+		assert.NoDirExists(t, ".work")
+	})
+}
+
+func TestStatusFolderMapping(t *testing.T) {
+	cfg := &Config{StatusFolders: map[string]string{
+		"inbox":   "Inbox",
+		"doing":   "in progress",
+		"shipped": "99-shipped.v2",
+	}}
+
+	t.Run("finds folders for statuses without numeric prefixes", func(t *testing.T) {
+		folder, err := FolderForStatus(cfg, "inbox")
+		require.NoError(t, err)
+		assert.Equal(t, "Inbox", folder)
+
+		folder, err = FolderForStatus(cfg, "doing")
+		require.NoError(t, err)
+		assert.Equal(t, "in progress", folder)
+
+		_, err = FolderForStatus(cfg, "todo")
+		assert.EqualError(t, err, "invalid status: todo")
+	})
+
+	t.Run("finds statuses for unusually named folders and paths", func(t *testing.T) {
+		for path, want := range map[string]string{
+			"Inbox":                          "inbox",
+			"in progress":                    "doing",
+			".work/99-shipped.v2":            "shipped",
+			".work/99-shipped.v2/v2/item.md": "shipped",
+			"in progress/001-item.task.md":   "doing",
+		} {
+			status, ok := StatusForFolder(cfg, path)
+			assert.True(t, ok, path)
+			assert.Equal(t, want, status, path)
+		}
+	})
+
+	t.Run("does not strip prefixes to guess a status", func(t *testing.T) {
+		_, ok := StatusForFolder(cfg, "1_inbox")
+		assert.False(t, ok)
+		_, ok = StatusForFolder(cfg, "99-shipped")
+		assert.False(t, ok)
+	})
+
+	t.Run("prefers the deepest nested status folder", func(t *testing.T) {
+		nested := &Config{StatusFolders: map[string]string{
+			"doing":         "2_doing",
+			"doing/blocked": "2_doing/blocked",
+		}}
+		for path, want := range map[string]string{
+			".work/2_doing/001-item.task.md":         "doing",
+			".work/2_doing/blocked":                  "doing/blocked",
+			".work/2_doing/blocked/001-item.task.md": "doing/blocked",
+			"2_doing/blocked-ish/001-item.task.md":   "doing",
+		} {
+			status, ok := StatusForFolder(nested, path)
+			assert.True(t, ok, path)
+			assert.Equal(t, want, status, path)
+		}
+	})
+}
+
+func TestValidateConfigStatusFolders(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.StatusFolders = map[string]string{"backlog": "0_backlog", "doing/blocked": "2_doing/blocked"}
+	require.NoError(t, ValidateConfig(&cfg))
+
+	cfg.StatusFolders = map[string]string{"backlog": "0_backlog", "outside": "../elsewhere"}
+	require.Error(t, ValidateConfig(&cfg))
+}
+
+func TestValidateConfigFilenameFormat(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.FilenameFormat = "{date}-{id}.md"
+	require.NoError(t, ValidateConfig(&cfg))
+
+	cfg.FilenameFormat = "{slug}.md"
+	require.EqualError(t, ValidateConfig(&cfg), "filename_format '{slug}.md' must contain {id}")
+}
+
+func TestValidateConfigIDPrefixes(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.IDPrefixPerKind = map[string]string{"prd": "PRD", "issue": "BUG"}
+	require.NoError(t, ValidateConfig(&cfg))
+
+	cfg.IDPrefixPerKind = map[string]string{"prd": "PRD-"}
+	require.Error(t, ValidateConfig(&cfg))
+}
+
+func TestValidateConfigTransitions(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Transitions = map[string]string{"todo": "doing", "doing": "done"}
+	require.NoError(t, ValidateConfig(&cfg))
+
+	cfg.Transitions = map[string]string{"todo": "shipping"}
+	require.Error(t, ValidateConfig(&cfg))
+}
+
+func TestValidateConfigAllowedTransitions(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.AllowedTransitions = map[string][]string{"todo": {"doing", "backlog"}}
+	require.NoError(t, ValidateConfig(&cfg))
+
+	cfg.AllowedTransitions = map[string][]string{"todo": {"shipping"}}
+	require.Error(t, ValidateConfig(&cfg))
+}
+
+func TestValidateConfigTemplateDescriptions(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.TemplateDescriptions = map[string]string{"prd": "A feature to design"}
+	require.NoError(t, ValidateConfig(&cfg))
+
+	cfg.TemplateDescriptions = map[string]string{"epic": "Not configured"}
+	require.Error(t, ValidateConfig(&cfg))
+}
+
+func TestValidateConfigTemplateDelimiters(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.TemplateDelimiters = []string{"<<", ">>"}
+	require.NoError(t, ValidateConfig(&cfg))
+
+	cfg.TemplateDelimiters = []string{"<<"}
+	require.Error(t, ValidateConfig(&cfg))
+
+	cfg.TemplateDelimiters = []string{"<<", ""}
+	require.Error(t, ValidateConfig(&cfg))
+}
+
+func TestValidateConfigModes(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.FileMode = "0644"
+	cfg.DirMode = "0o755"
+	require.NoError(t, ValidateConfig(&cfg))
+	assert.Equal(t, os.FileMode(0o644), cfg.FilePerm())
+	assert.Equal(t, os.FileMode(0o755), cfg.DirPerm())
+
+	for _, mode := range []string{"rw-r--r--", "0999", "1777", ""} {
+		cfg.FileMode = mode
+		if mode == "" {
+			require.NoError(t, ValidateConfig(&cfg))
+			assert.Equal(t, DefaultFileMode, cfg.FilePerm())
+			continue
+		}
+		assert.Error(t, ValidateConfig(&cfg), mode)
+	}
+
+	cfg.FileMode = "0444"
+	assert.ErrorContains(t, ValidateConfig(&cfg), "must include 0600")
+	cfg.FileMode = ""
+	cfg.DirMode = "0644"
+	assert.ErrorContains(t, ValidateConfig(&cfg), "invalid dir_mode")
+}
+
+func TestOrderedStatuses(t *testing.T) {
+	cfg := DefaultConfig
+	assert.Equal(t, []string{"backlog", "todo", "doing", "review", "done", "archived"}, OrderedStatuses(&cfg))
+
+	cfg.StatusOrder = []string{"todo", "done"}
+	assert.Equal(t, []string{"todo", "done"}, OrderedStatuses(&cfg))
+
+	cfg.StatusOrder = []string{"todo", "shipped"}
+	require.EqualError(t, ValidateConfig(&cfg), "status_order entry 'shipped' is not a configured status folder")
+}
+
+func TestValidateConfigTimestampFormat(t *testing.T) {
+	created := time.Date(2024, 3, 1, 14, 5, 9, 0, time.FixedZone("", 3600))
+
+	cfg := DefaultConfig
+	require.NoError(t, ValidateConfig(&cfg))
+	assert.Equal(t, "2024-03-01", cfg.FormatTimestamp(created))
+
+	cfg.TimestampFormat = TimestampDate
+	require.NoError(t, ValidateConfig(&cfg))
+	assert.Equal(t, "2024-03-01", cfg.FormatTimestamp(created))
+
+	cfg.TimestampFormat = TimestampDateTime
+	require.NoError(t, ValidateConfig(&cfg))
+	assert.Equal(t, "2024-03-01T14:05:09+01:00", cfg.FormatTimestamp(created))
+
+	cfg.TimestampFormat = "2006-01-02 15:04"
+	require.Error(t, ValidateConfig(&cfg))
+}
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"kira/internal/fsutil"
+)
+
+// mapKeys are config keys whose values are maps with user-chosen keys, so
+// any child key (e.g. status_folders.blocked) may be set.
+var mapKeys = map[string]bool{
+	"templates":      true,
+	"status_folders": true,
+}
+
+// FilePath returns the config file in use: kira.yml, or the legacy
+// .work/kira.yml if only that exists. It returns kira.yml when neither exists.
+func FilePath() string {
+	legacyPath := filepath.Join(".work", "kira.yml")
+	if _, err := os.Stat("kira.yml"); err != nil {
+		if _, err := os.Stat(legacyPath); err == nil {
+			return legacyPath
+		}
+	}
+	return "kira.yml"
+}
+
+// SetValue updates a single key in the config file, addressed with dots for
+// nested keys (e.g. validation.id_format), and writes the file back keeping
+// other fields and comments. The resulting config is validated before writing.
+func SetValue(key, value string) error {
+	path := FilePath()
+	if err := checkKnownKey(key); err != nil {
+		return err
+	}
+
+	doc, err := readConfigNode(path)
+	if err != nil {
+		return err
+	}
+
+	setNodeValue(doc.Content[0], strings.Split(key, "."), valueNode(value))
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	var updated Config
+	if err := yaml.Unmarshal(buf.Bytes(), &updated); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	mergeWithDefaults(&updated)
+	if err := ValidateConfig(&updated); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	if err := fsutil.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// checkKnownKey rejects keys that do not correspond to a config field.
+func checkKnownKey(key string) error {
+	var defaults yaml.Node
+	if err := defaults.Encode(DefaultConfig); err != nil {
+		return fmt.Errorf("failed to encode default config: %w", err)
+	}
+
+	node := &defaults
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		if i > 0 && mapKeys[parts[i-1]] && i == len(parts)-1 {
+			return nil
+		}
+		node = mappingValue(node, part)
+		if node == nil {
+			return fmt.Errorf("unknown config key: %s", key)
+		}
+	}
+	if node.Kind == yaml.MappingNode {
+		return fmt.Errorf("config key %s is a section; set one of its keys instead", key)
+	}
+	return nil
+}
+
+func readConfigNode(path string) (*yaml.Node, error) {
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+
+	// #nosec G304 - path is kira.yml or .work/kira.yml, chosen by FilePath
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return doc, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return doc, nil
+	}
+
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config file %s is not a mapping", path)
+	}
+	return doc, nil
+}
+
+// valueNode converts a command-line value into a YAML node: [a, b] becomes a
+// list and anything else a plain scalar.
+func valueNode(value string) *yaml.Node {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+		var list yaml.Node
+		if err := yaml.Unmarshal([]byte(trimmed), &list); err == nil && len(list.Content) == 1 {
+			return list.Content[0]
+		}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+}
+
+// mappingValue returns the value node for key in a mapping (or document) node.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setNodeValue sets path within a mapping node, creating intermediate mappings.
+func setNodeValue(node *yaml.Node, path []string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			node.Content[i+1] = value
+			return
+		}
+		child := node.Content[i+1]
+		if child.Kind != yaml.MappingNode {
+			child = &yaml.Node{Kind: yaml.MappingNode}
+			node.Content[i+1] = child
+		}
+		setNodeValue(child, path[1:], value)
+		return
+	}
+
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: path[0]}
+	if len(path) == 1 {
+		node.Content = append(node.Content, keyNode, value)
+		return
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	node.Content = append(node.Content, keyNode, child)
+	setNodeValue(child, path[1:], value)
+}
+
+// GetValue returns the value of key in config, addressed the same way as
+// SetValue keys. Sections are returned as maps keyed by their kira.yml names
+// and lists as slices; an empty key returns the whole config.
+func GetValue(config *Config, key string) (interface{}, error) {
+	var root yaml.Node
+	if err := root.Encode(config); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	node := &root
+	if key != "" {
+		for _, part := range strings.Split(key, ".") {
+			node = mappingValue(node, part)
+			if node == nil {
+				return nil, fmt.Errorf("unknown config key: %s", key)
+			}
+		}
+	}
+
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	return value, nil
+}
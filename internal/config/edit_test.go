@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetValue(t *testing.T) {
+	original := `# Team settings
+version: "1.0"
+status_folders:
+    backlog: 0_backlog
+    todo: 1_todo
+default_status: backlog
+custom_note: keep me
+`
+
+	t.Run("updates a key and keeps other fields and comments", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.WriteFile("kira.yml", []byte(original), 0o600))
+
+		require.NoError(t, SetValue("default_status", "todo"))
+		require.NoError(t, SetValue("validation.id_format", `^\d{4}$`))
+
+		content, err := os.ReadFile("kira.yml")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "# Team settings")
+		assert.Contains(t, string(content), "default_status: todo")
+		assert.Contains(t, string(content), "custom_note: keep me")
+
+		cfg, err := LoadConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "todo", cfg.DefaultStatus)
+		assert.Equal(t, `^\d{4}$`, cfg.Validation.IDFormat)
+		assert.Equal(t, "1_todo", cfg.StatusFolders["todo"])
+	})
+
+	t.Run("sets map entries and lists", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.WriteFile("kira.yml", []byte(original), 0o600))
+
+		require.NoError(t, SetValue("status_folders.blocked", "5_blocked"))
+		require.NoError(t, SetValue("priorities", "[p1, p2]"))
+		require.NoError(t, SetValue("track_history", "true"))
+
+		cfg, err := LoadConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "5_blocked", cfg.StatusFolders["blocked"])
+		assert.Equal(t, []string{"p1", "p2"}, cfg.Priorities)
+		assert.True(t, cfg.TrackHistory)
+	})
+
+	t.Run("rejects an invalid value without writing", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.WriteFile("kira.yml", []byte(original), 0o600))
+
+		err := SetValue("default_status", "nowhere")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "default_status 'nowhere' is not a configured status folder")
+
+		err = SetValue("track_history", "maybe")
+		require.Error(t, err)
+
+		content, err := os.ReadFile("kira.yml")
+		require.NoError(t, err)
+		assert.Equal(t, original, string(content))
+	})
+
+	t.Run("rejects unknown keys and sections", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		assert.EqualError(t, SetValue("id_width", "4"), "unknown config key: id_width")
+		assert.Error(t, SetValue("validation", "x"))
+		assert.NoFileExists(t, "kira.yml")
+	})
+}
+
+func TestGetValue(t *testing.T) {
+	cfg := DefaultConfig
+
+	value, err := GetValue(&cfg, "default_status")
+	require.NoError(t, err)
+	assert.Equal(t, "backlog", value)
+
+	value, err = GetValue(&cfg, "status_folders.todo")
+	require.NoError(t, err)
+	assert.Equal(t, "1_todo", value)
+
+	value, err = GetValue(&cfg, "")
+	require.NoError(t, err)
+	full, ok := value.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "done", full["done_status"])
+	assert.Contains(t, full, "validation")
+
+	_, err = GetValue(&cfg, "validation.missing")
+	assert.EqualError(t, err, "unknown config key: validation.missing")
+}
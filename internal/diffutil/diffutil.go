@@ -0,0 +1,140 @@
+// Package diffutil renders line-based unified diffs.
+package diffutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change.
+const contextLines = 3
+
+// op is a single line of an edit script: ' ' for an unchanged line, '-' for a
+// line only in the old text, and '+' for a line only in the new text.
+type op struct {
+	kind byte
+	text string
+}
+
+// Unified returns a unified diff turning before into after, labelled with
+// fromName and toName. It returns an empty string when the texts are equal.
+func Unified(fromName, toName, before, after string) string {
+	if before == after {
+		return ""
+	}
+
+	ops := diffLines(splitLines(before), splitLines(after))
+
+	// oldLine and newLine hold the 0-based line number each op starts at.
+	oldLine := make([]int, len(ops)+1)
+	newLine := make([]int, len(ops)+1)
+	for i, o := range ops {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if o.kind != '+' {
+			oldLine[i+1]++
+		}
+		if o.kind != '-' {
+			newLine[i+1]++
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for start := 0; start < len(ops); {
+		first := nextChange(ops, start)
+		if first < 0 {
+			break
+		}
+		last := first
+		for i := first + 1; i < len(ops) && i-last <= 2*contextLines; i++ {
+			if ops[i].kind != ' ' {
+				last = i
+			}
+		}
+
+		from := max(first-contextLines, start)
+		to := min(last+contextLines+1, len(ops))
+		writeHunk(&sb, ops[from:to], oldLine[from], oldLine[to], newLine[from], newLine[to])
+		start = to
+	}
+	return sb.String()
+}
+
+// splitLines splits text into lines, ignoring a final newline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// nextChange returns the index of the first changed op at or after start, or
+// -1 when there is none.
+func nextChange(ops []op, start int) int {
+	for i := start; i < len(ops); i++ {
+		if ops[i].kind != ' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+func writeHunk(sb *strings.Builder, ops []op, oldFrom, oldTo, newFrom, newTo int) {
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(oldFrom, oldTo-oldFrom), hunkRange(newFrom, newTo-newFrom))
+	for _, o := range ops {
+		sb.WriteByte(o.kind)
+		sb.WriteString(o.text)
+		sb.WriteByte('\n')
+	}
+}
+
+// hunkRange formats a hunk's start line and length. An empty range names the
+// line before it, as in GNU diff.
+func hunkRange(from, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", from)
+	}
+	return fmt.Sprintf("%d,%d", from+1, count)
+}
+
+// diffLines returns an edit script turning a into b, built from their longest
+// common subsequence of lines. Deletions are listed before insertions.
+func diffLines(a, b []string) []op {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
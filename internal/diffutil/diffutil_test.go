@@ -0,0 +1,57 @@
+package diffutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnified(t *testing.T) {
+	t.Run("returns nothing for equal texts", func(t *testing.T) {
+		assert.Empty(t, Unified("a", "b", "same\n", "same\n"))
+	})
+
+	t.Run("shows a changed line with context", func(t *testing.T) {
+		before := "one\ntwo\nthree\nfour\nfive\nsix\n"
+		after := "one\ntwo\nthree\nFOUR\nfive\nsix\n"
+
+		expected := `--- a/item.md
++++ b/item.md
+@@ -1,6 +1,6 @@
+ one
+ two
+ three
+-four
++FOUR
+ five
+ six
+`
+		assert.Equal(t, expected, Unified("a/item.md", "b/item.md", before, after))
+	})
+
+	t.Run("splits distant changes into separate hunks", func(t *testing.T) {
+		before := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12"
+		after := "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve"
+
+		expected := `--- old
++++ new
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+ 4
+@@ -9,4 +9,4 @@
+ 9
+ 10
+ 11
+-12
++twelve
+`
+		assert.Equal(t, expected, Unified("old", "new", before, after))
+	})
+
+	t.Run("reports insertions into an empty range", func(t *testing.T) {
+		assert.Equal(t, "--- old\n+++ new\n@@ -0,0 +1,1 @@\n+added\n", Unified("old", "new", "", "added\n"))
+	})
+}
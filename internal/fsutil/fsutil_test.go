@@ -0,0 +1,66 @@
+package fsutil
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFile(t *testing.T) {
+	t.Run("creates a new file with the given permissions", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "item.md")
+
+		require.NoError(t, WriteFile(path, []byte("new"), 0o600))
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(content))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("keeps the permissions of an existing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "item.md")
+		require.NoError(t, os.WriteFile(path, []byte("old"), 0o600))
+		require.NoError(t, os.Chmod(path, 0o640))
+
+		require.NoError(t, WriteFile(path, []byte("updated"), 0o600))
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "updated", string(content))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+	})
+}
+
+func TestWriteAtomicInterrupted(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "item.md")
+	require.NoError(t, os.WriteFile(path, []byte("original content"), 0o600))
+
+	err := WriteAtomic(path, 0o600, func(w io.Writer) error {
+		if _, err := w.Write([]byte("partial")); err != nil {
+			return err
+		}
+		return errors.New("interrupted")
+	})
+	require.EqualError(t, err, "interrupted")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "original content", string(content))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary file should be cleaned up")
+}
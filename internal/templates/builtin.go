@@ -0,0 +1,54 @@
+package templates
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// builtinFS holds the default templates shipped in the binary. They are
+// written out by kira init and used whenever a template file is missing.
+//
+//go:embed builtin/template.*.md
+var builtinFS embed.FS
+
+// builtinFilename returns the embedded file name for the template name.
+func builtinFilename(name string) string {
+	return "template." + name + ".md"
+}
+
+// Builtin returns the built-in template with the given name, such as "prd".
+func Builtin(name string) (string, bool) {
+	content, err := fs.ReadFile(builtinFS, path.Join("builtin", builtinFilename(name)))
+	if err != nil {
+		return "", false
+	}
+	return string(content), true
+}
+
+// BuiltinNames returns the names of the built-in templates, sorted.
+func BuiltinNames() []string {
+	entries, err := fs.ReadDir(builtinFS, "builtin")
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(strings.TrimPrefix(entry.Name(), "template."), ".md"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuiltinForPath returns the built-in template a missing template file falls
+// back to, matched on its template.<name>.md filename.
+func BuiltinForPath(templatePath string) (string, bool) {
+	base := path.Base(strings.ReplaceAll(templatePath, "\\", "/"))
+	if !strings.HasPrefix(base, "template.") || !strings.HasSuffix(base, ".md") {
+		return "", false
+	}
+	return Builtin(strings.TrimSuffix(strings.TrimPrefix(base, "template."), ".md"))
+}
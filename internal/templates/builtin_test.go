@@ -0,0 +1,49 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltin(t *testing.T) {
+	assert.Equal(t, []string{"issue", "prd", "spike", "task"}, BuiltinNames())
+
+	content, ok := Builtin("task")
+	require.True(t, ok)
+	assert.Contains(t, content, "kind: task")
+
+	_, ok = Builtin("missing")
+	assert.False(t, ok)
+}
+
+func TestBuiltinFallback(t *testing.T) {
+	t.Run("uses the built-in template when the file is missing", func(t *testing.T) {
+		dir := t.TempDir()
+		templatePath := filepath.Join(dir, "templates", "template.task.md")
+
+		result, err := ProcessTemplateWithOptions(templatePath, map[string]string{"title": "Built in"}, Options{Dir: dir})
+		require.NoError(t, err)
+		assert.Contains(t, result, "title: Built in")
+		assert.Contains(t, result, "kind: task")
+	})
+
+	t.Run("prefers a template file of the same name", func(t *testing.T) {
+		dir := t.TempDir()
+		templatePath := filepath.Join(dir, "template.task.md")
+		require.NoError(t, os.WriteFile(templatePath, []byte("kind: local-task\n"), 0o600))
+
+		result, err := ProcessTemplateWithOptions(templatePath, nil, Options{Dir: dir})
+		require.NoError(t, err)
+		assert.Equal(t, "kind: local-task\n", result)
+	})
+
+	t.Run("fails for a missing template without a built-in", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := ProcessTemplateWithOptions(filepath.Join(dir, "template.custom.md"), nil, Options{Dir: dir})
+		assert.Error(t, err)
+	})
+}
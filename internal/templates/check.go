@@ -0,0 +1,83 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CheckTemplate reports every problem found in a template: unreadable
+// includes, inputs that fail to parse (such as unknown types or attributes),
+// options declared on number inputs, an input name declared more than once with
+// different definitions, and an invalid schema or meta block. An input may be
+// repeated with an identical declaration, which is how a value is reused in
+// several places.
+func CheckTemplate(templatePath string, opts Options) []error {
+	if err := validateTemplatePath(templatePath, opts.Dir); err != nil {
+		return []error{err}
+	}
+	// #nosec G304 - path has been validated by validateTemplatePath above
+	raw, err := os.ReadFile(templatePath)
+	if os.IsNotExist(err) {
+		builtin, ok := BuiltinForPath(templatePath)
+		if !ok {
+			return []error{fmt.Errorf("failed to read template: %w", err)}
+		}
+		raw, err = []byte(builtin), nil
+	}
+	if err != nil {
+		return []error{fmt.Errorf("failed to read template: %w", err)}
+	}
+
+	content, problems := checkIncludes(string(raw), templatePath, opts)
+	problems = append(problems, checkInputs(content)...)
+	if _, err := ParseSchema(content); err != nil {
+		problems = append(problems, err)
+	}
+	if _, err := ParseMeta(content); err != nil {
+		problems = append(problems, err)
+	}
+	return problems
+}
+
+// checkIncludes expands each include directive that can be read and reports
+// the ones that cannot, leaving them in place.
+func checkIncludes(content, templatePath string, opts Options) (string, []error) {
+	var problems []error
+	for _, match := range opts.includePattern().FindAllStringSubmatch(content, -1) {
+		included, err := readTemplateDepth(filepath.Join(filepath.Dir(templatePath), match[1]), opts, 1)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("include %q: %w", match[1], err))
+			continue
+		}
+		content = strings.Replace(content, match[0], included, 1)
+	}
+	return content, problems
+}
+
+// checkInputs parses every input declaration, reporting each one that is
+// invalid or that conflicts with an earlier declaration of the same name.
+func checkInputs(content string) []error {
+	var problems []error
+	declared := make(map[string]string)
+	for _, match := range inputRe.FindAllStringSubmatch(content, -1) {
+		input, err := parseInput(match)
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		if match[2] != "" && (input.Type == InputNumber || input.Type == InputFloat) {
+			problems = append(problems, fmt.Errorf("options are not supported on %s input %s", match[1], input.Name))
+		}
+
+		// The description is free text; everything else must match.
+		definition := match[1] + "[" + match[2] + "]" + match[5]
+		if previous, seen := declared[input.Name]; seen && previous != definition {
+			problems = append(problems, fmt.Errorf("input %s is declared more than once with different definitions", input.Name))
+			continue
+		}
+		declared[input.Name] = definition
+	}
+	return problems
+}
@@ -0,0 +1,56 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTemplate(t *testing.T) {
+	check := func(t *testing.T, files map[string]string) []error {
+		t.Helper()
+		dir := t.TempDir()
+		for name, content := range files {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+		}
+		return CheckTemplate(filepath.Join(dir, "template.check.md"), Options{Dir: dir})
+	}
+
+	t.Run("accepts a clean template", func(t *testing.T) {
+		problems := check(t, map[string]string{
+			"template.check.md": "---\ntitle: <!--input-string:title:\"Title\"-->\n---\n# <!--input-string:title:\"Title again\"-->\n{{include \"footer.md\"}}\n",
+			"footer.md":         "<!--input-number:points:\"Points\" min=\"0\"-->\n",
+		})
+		assert.Empty(t, problems)
+	})
+
+	t.Run("accepts the built-in templates", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range BuiltinNames() {
+			assert.Empty(t, CheckTemplate(filepath.Join(dir, "template."+name+".md"), Options{Dir: dir}), name)
+		}
+	})
+
+	t.Run("reports every problem", func(t *testing.T) {
+		problems := check(t, map[string]string{
+			"template.check.md": "<!--input-string:owner:\"Owner\"-->\n" +
+				"<!--input-number:owner:\"Owner again\"-->\n" +
+				"<!--input-color:shade:\"Shade\"-->\n" +
+				"<!--input-number[1,2]:points:\"Points\"-->\n" +
+				"{{include \"missing.md\"}}\n",
+		})
+
+		var messages []string
+		for _, problem := range problems {
+			messages = append(messages, problem.Error())
+		}
+		require.Len(t, messages, 4)
+		assert.Contains(t, messages[0], `include "missing.md"`)
+		assert.Equal(t, "input owner is declared more than once with different definitions", messages[1])
+		assert.Equal(t, "unknown input type: color", messages[2])
+		assert.Equal(t, "options are not supported on number input points", messages[3])
+	})
+}
@@ -0,0 +1,111 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Meta holds template-level settings declared in a meta block.
+type Meta struct {
+	// DefaultStatus is the status items created from the template start in
+	// when no status is given, in place of the configured default_status.
+	DefaultStatus string `yaml:"default_status"`
+	// Transforms maps an input name to a transform applied to its value
+	// before the template is rendered, such as title: titlecase.
+	Transforms map[string]string `yaml:"transforms"`
+}
+
+// transforms are the transforms a meta block may apply to an input value.
+var transforms = map[string]func(string) string{
+	"lowercase": strings.ToLower,
+	"uppercase": strings.ToUpper,
+	"titlecase": titleCase,
+}
+
+// titleCase upper-cases the first letter of each word and leaves the rest of
+// the word as written, so acronyms such as API keep their case.
+func titleCase(value string) string {
+	var b strings.Builder
+	startOfWord := true
+	for _, r := range value {
+		if startOfWord && unicode.IsLetter(r) {
+			r = unicode.ToUpper(r)
+		}
+		startOfWord = unicode.IsSpace(r)
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// applyTransforms returns a copy of inputs with meta's transforms applied.
+func (m Meta) applyTransforms(inputs map[string]string) map[string]string {
+	if len(m.Transforms) == 0 {
+		return inputs
+	}
+	transformed := make(map[string]string, len(inputs))
+	for name, value := range inputs {
+		if transform, ok := transforms[m.Transforms[name]]; ok {
+			value = transform(value)
+		}
+		transformed[name] = value
+	}
+	return transformed
+}
+
+// metaRe matches a meta block: an HTML comment starting with "meta" followed
+// by YAML lines of "setting: value".
+var metaRe = regexp.MustCompile(`(?s)<!--meta\s*\n(.*?)-->\n?`)
+
+// ParseMeta extracts the meta block from template content. It returns a zero
+// Meta when the template declares none.
+func ParseMeta(content string) (Meta, error) {
+	var meta Meta
+	match := metaRe.FindStringSubmatch(content)
+	if match == nil {
+		return meta, nil
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewBufferString(match[1]))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&meta); err != nil && !errors.Is(err, io.EOF) {
+		return meta, fmt.Errorf("invalid meta block: %w", err)
+	}
+	for name, transform := range meta.Transforms {
+		if _, ok := transforms[transform]; !ok {
+			return meta, fmt.Errorf("invalid meta block: unknown transform '%s' for input %s (valid: %s)", transform, name, strings.Join(transformNames(), ", "))
+		}
+	}
+	return meta, nil
+}
+
+// transformNames returns the names of the available transforms, sorted.
+func transformNames() []string {
+	names := make([]string, 0, len(transforms))
+	for name := range transforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// stripMeta removes the meta block so it does not appear in rendered items.
+func stripMeta(content string) string {
+	return metaRe.ReplaceAllLiteralString(content, "")
+}
+
+// GetTemplateMetaWithOptions reads the meta block of a template file resolved according to opts.
+func GetTemplateMetaWithOptions(templatePath string, opts Options) (Meta, error) {
+	content, err := readTemplate(templatePath, opts)
+	if err != nil {
+		return Meta{}, err
+	}
+	return ParseMeta(content)
+}
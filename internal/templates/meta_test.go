@@ -0,0 +1,80 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMeta(t *testing.T) {
+	t.Run("reads default_status", func(t *testing.T) {
+		meta, err := ParseMeta("<!--meta\ndefault_status: doing\n-->\n---\nid: 1\n---\n")
+		require.NoError(t, err)
+		assert.Equal(t, "doing", meta.DefaultStatus)
+	})
+
+	t.Run("returns a zero meta without a block", func(t *testing.T) {
+		meta, err := ParseMeta("---\nid: 1\n---\n")
+		require.NoError(t, err)
+		assert.Equal(t, Meta{}, meta)
+	})
+
+	t.Run("rejects unknown settings", func(t *testing.T) {
+		_, err := ParseMeta("<!--meta\ndefault_stats: doing\n-->\n")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid meta block")
+	})
+
+	t.Run("is stripped from rendered items", func(t *testing.T) {
+		dir := t.TempDir()
+		templatePath := filepath.Join(dir, "template.meta.md")
+		content := "<!--meta\ndefault_status: doing\n-->\ntitle: <!--input-string:title:\"Title\"-->\n"
+		require.NoError(t, os.WriteFile(templatePath, []byte(content), 0o600))
+
+		result, err := ProcessTemplateWithOptions(templatePath, map[string]string{"title": "Hi"}, Options{Dir: dir})
+		require.NoError(t, err)
+		assert.Equal(t, "title: Hi\n", result)
+	})
+}
+
+func TestMetaTransforms(t *testing.T) {
+	render := func(t *testing.T, content string, inputs map[string]string) string {
+		t.Helper()
+		dir := t.TempDir()
+		templatePath := filepath.Join(dir, "template.transform.md")
+		require.NoError(t, os.WriteFile(templatePath, []byte(content), 0o600))
+
+		result, err := ProcessTemplateWithOptions(templatePath, inputs, Options{Dir: dir})
+		require.NoError(t, err)
+		return result
+	}
+	content := "<!--meta\ntransforms:\n  title: titlecase\n  tags: lowercase\n-->\n" +
+		"title: <!--input-string:title:\"Title\"-->\ntags: <!--input-string:tags:\"Tags\"-->\nslug: <!--input-string:slug:\"Slug\"-->\nowner: <!--input-string:owner:\"Owner\"-->\n"
+
+	t.Run("titlecase capitalizes each word and keeps acronyms", func(t *testing.T) {
+		result := render(t, content, map[string]string{"title": "fix the API login-flow", "tags": "x", "owner": "ana"})
+		assert.Contains(t, result, "title: Fix The API Login-flow\n")
+		assert.Contains(t, result, "slug: fix-the-api-login-flow\n")
+	})
+
+	t.Run("lowercase lowers the value", func(t *testing.T) {
+		result := render(t, content, map[string]string{"title": "x", "tags": "Backend,UI", "owner": "Ana"})
+		assert.Contains(t, result, "tags: backend,ui\n")
+		assert.Contains(t, result, "owner: Ana\n")
+	})
+
+	t.Run("leaves the caller's inputs unchanged", func(t *testing.T) {
+		inputs := map[string]string{"title": "lower", "tags": "UP"}
+		render(t, content, inputs)
+		assert.Equal(t, map[string]string{"title": "lower", "tags": "UP"}, inputs)
+	})
+
+	t.Run("rejects an unknown transform", func(t *testing.T) {
+		_, err := ParseMeta("<!--meta\ntransforms:\n  title: shout\n-->\n")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown transform 'shout' for input title (valid: lowercase, titlecase, uppercase)")
+	})
+}
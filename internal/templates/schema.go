@@ -0,0 +1,134 @@
+package templates
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// FieldType is the expected type of a front matter field in a template schema.
+type FieldType string
+
+const (
+	// FieldString accepts any scalar value.
+	FieldString FieldType = "string"
+	// FieldNumber accepts integers and decimals.
+	FieldNumber FieldType = "number"
+	// FieldDate accepts YYYY-MM-DD dates and RFC3339 timestamps.
+	FieldDate FieldType = "date"
+	// FieldList accepts YAML lists.
+	FieldList FieldType = "list"
+)
+
+// Schema maps front matter keys to the type expected for items created from a template.
+type Schema map[string]FieldType
+
+// schemaRe matches a schema block: an HTML comment starting with "schema"
+// followed by YAML lines of "field: type".
+var schemaRe = regexp.MustCompile(`(?s)<!--schema\s*\n(.*?)-->\n?`)
+
+// ParseSchema extracts the schema block from template content. It returns nil
+// when the template declares no schema.
+func ParseSchema(content string) (Schema, error) {
+	match := schemaRe.FindStringSubmatch(content)
+	if match == nil {
+		return nil, nil
+	}
+
+	var raw map[string]string
+	if err := yaml.Unmarshal([]byte(match[1]), &raw); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	schema := make(Schema, len(raw))
+	for field, fieldType := range raw {
+		switch t := FieldType(fieldType); t {
+		case FieldString, FieldNumber, FieldDate, FieldList:
+			schema[field] = t
+		default:
+			return nil, fmt.Errorf("invalid schema type %q for field %s (valid: string, number, date, list)", fieldType, field)
+		}
+	}
+	return schema, nil
+}
+
+// stripSchema removes the schema block so it does not appear in rendered items.
+func stripSchema(content string) string {
+	return schemaRe.ReplaceAllLiteralString(content, "")
+}
+
+// GetTemplateSchemaWithOptions reads the schema declared in a template file resolved according to opts.
+func GetTemplateSchemaWithOptions(templatePath string, opts Options) (Schema, error) {
+	content, err := readTemplate(templatePath, opts)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSchema(content)
+}
+
+// Validate checks front matter values against the schema. Missing fields and
+// type mismatches are reported; fields left empty are accepted.
+func (s Schema) Validate(fields map[string]interface{}) []error {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		value, exists := fields[name]
+		if !exists {
+			errs = append(errs, fmt.Errorf("missing field %s required by schema", name))
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if !matchesFieldType(value, s[name]) {
+			errs = append(errs, fmt.Errorf("field %s should be a %s, got %v", name, s[name], value))
+		}
+	}
+	return errs
+}
+
+func matchesFieldType(value interface{}, fieldType FieldType) bool {
+	switch fieldType {
+	case FieldNumber:
+		switch value.(type) {
+		case int, int64, float64:
+			return true
+		}
+		return false
+	case FieldDate:
+		switch v := value.(type) {
+		case time.Time:
+			return true
+		case string:
+			return isDate(v)
+		}
+		return false
+	case FieldList:
+		_, ok := value.([]interface{})
+		return ok
+	default:
+		switch value.(type) {
+		case []interface{}, map[string]interface{}:
+			return false
+		}
+		return true
+	}
+}
+
+func isDate(value string) bool {
+	value = strings.TrimSpace(value)
+	if _, err := time.Parse("2006-01-02", value); err == nil {
+		return true
+	}
+	_, err := time.Parse(time.RFC3339, value)
+	return err == nil
+}
@@ -0,0 +1,79 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const schemaTemplate = `---
+title: <!--input-string:title:"Title"-->
+created: <!--input-datetime:created:"Created"-->
+---
+<!--schema
+created: date
+estimate: number
+tags: list
+-->
+# <!--input-string:title:"Title"-->
+`
+
+func TestParseSchema(t *testing.T) {
+	t.Run("reads field types from the schema block", func(t *testing.T) {
+		schema, err := ParseSchema(schemaTemplate)
+		require.NoError(t, err)
+		assert.Equal(t, Schema{"created": FieldDate, "estimate": FieldNumber, "tags": FieldList}, schema)
+	})
+
+	t.Run("returns nil without a schema block", func(t *testing.T) {
+		schema, err := ParseSchema("---\ntitle: x\n---\n")
+		require.NoError(t, err)
+		assert.Nil(t, schema)
+	})
+
+	t.Run("rejects an unknown type", func(t *testing.T) {
+		_, err := ParseSchema("<!--schema\ncreated: timestamp\n-->")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid schema type "timestamp"`)
+	})
+
+	t.Run("is removed from rendered output", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "template.prd.md")
+		require.NoError(t, os.WriteFile(path, []byte(schemaTemplate), 0o600))
+
+		result, err := ProcessTemplateWithOptions(path, map[string]string{"title": "T", "created": "2024-01-01"}, Options{Dir: dir})
+		require.NoError(t, err)
+		assert.Equal(t, "---\ntitle: T\ncreated: 2024-01-01\n---\n# T\n", result)
+	})
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema := Schema{"created": FieldDate, "estimate": FieldNumber, "tags": FieldList, "owner": FieldString}
+
+	t.Run("accepts conforming fields", func(t *testing.T) {
+		errs := schema.Validate(map[string]interface{}{
+			"created":  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			"estimate": 2.5,
+			"tags":     []interface{}{"ui"},
+			"owner":    nil,
+		})
+		assert.Empty(t, errs)
+	})
+
+	t.Run("reports type mismatches and missing fields", func(t *testing.T) {
+		errs := schema.Validate(map[string]interface{}{
+			"created":  "someday",
+			"estimate": "three",
+			"owner":    "alex",
+		})
+		require.Len(t, errs, 3)
+		assert.EqualError(t, errs[0], "field created should be a date, got someday")
+		assert.EqualError(t, errs[1], "field estimate should be a number, got three")
+		assert.EqualError(t, errs[2], "missing field tags required by schema")
+	})
+}
@@ -6,8 +6,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
+
+	"kira/internal/fsutil"
 )
 
 // InputType represents the type of input field in a template.
@@ -18,6 +21,8 @@ const (
 	InputString InputType = "string"
 	// InputNumber represents a number input type.
 	InputNumber InputType = "number"
+	// InputFloat represents a decimal number input type.
+	InputFloat InputType = "float"
 	// InputDateTime represents a datetime input type.
 	InputDateTime InputType = "datetime"
 )
@@ -29,127 +34,495 @@ type Input struct {
 	Description string
 	Options     []string
 	DateFormat  string
+	// Pattern is a regular expression the whole value must match, declared
+	// with a pattern="..." attribute after the description.
+	Pattern string
+	// Min and Max bound number inputs, declared with min="..." and max="..." attributes.
+	Min *float64
+	Max *float64
+	// ShowIf is a field==value condition, declared with show_if="...", that
+	// must hold for the input to be prompted for or validated.
+	ShowIf string
+	// Secret, declared with secret="true" on string inputs, keeps the value
+	// from being echoed while it is typed at a prompt.
+	Secret bool
 }
 
-// TemplateInput contains parsed input definitions from a template.
-type TemplateInput struct {
-	Inputs map[string]Input
+// Visible reports whether the input's ShowIf condition holds for values.
+// Inputs without a condition are always visible.
+func (i Input) Visible(values map[string]string) bool {
+	if i.ShowIf == "" {
+		return true
+	}
+	field, want, _ := strings.Cut(i.ShowIf, "==")
+	return values[strings.TrimSpace(field)] == strings.TrimSpace(want)
 }
 
-// ParseTemplateInputs parses input definitions from template content.
-func ParseTemplateInputs(content string) (*TemplateInput, error) {
-	inputs := make(map[string]Input)
+// Validate checks a value against the constraints declared for the input.
+func (i Input) Validate(value string) error {
+	if err := i.validateType(value); err != nil {
+		return err
+	}
+	if i.Pattern != "" {
+		re, err := regexp.Compile("^(?:" + i.Pattern + ")$")
+		if err != nil {
+			return fmt.Errorf("invalid pattern for %s: %w", i.Name, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("value %q for %s does not match pattern %s", value, i.Name, i.Pattern)
+		}
+	}
+	return i.validateRange(value)
+}
 
-	// Regex to match input comments: <!--input-type:variable-name:"description"-->
-	re := regexp.MustCompile(`<!--input-(\w+)(?:\[([^\]]+)\])?:([^:]+):"([^"]+)"-->`)
+func (i Input) validateType(value string) error {
+	switch i.Type {
+	case InputNumber:
+		if _, err := strconv.Atoi(strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("value %q for %s is not an integer", value, i.Name)
+		}
+	case InputFloat:
+		if _, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil {
+			return fmt.Errorf("value %q for %s is not a number", value, i.Name)
+		}
+	case InputDateTime:
+		if _, err := i.parseDate(value); err != nil {
+			return fmt.Errorf("value %q for %s does not match date format %s", value, i.Name, i.DateFormat)
+		}
+	}
+	return nil
+}
 
-	matches := re.FindAllStringSubmatch(content, -1)
-	for _, match := range matches {
-		if len(match) != 5 {
-			continue
+// Normalize returns value in the canonical form stored in work items. Datetime
+// values, including relative ones, are rendered in the input's date format,
+// except RFC 3339 timestamps, which keep their time of day; other values are
+// returned unchanged. It assumes value has passed Validate.
+func (i Input) Normalize(value string) string {
+	if i.Type != InputDateTime {
+		return value
+	}
+	if _, err := time.Parse(time.RFC3339, strings.TrimSpace(value)); err == nil {
+		return strings.TrimSpace(value)
+	}
+	parsed, err := i.parseDate(value)
+	if err != nil {
+		return value
+	}
+	return parsed.Format(i.dateLayout())
+}
+
+// parseDate reads a datetime value as a relative date, falling back to the
+// input's date format and then to an RFC 3339 timestamp.
+func (i Input) parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if date, ok := ParseRelativeDate(value, now()); ok {
+		return date, nil
+	}
+	date, err := time.Parse(i.dateLayout(), value)
+	if err != nil {
+		if timestamp, tsErr := time.Parse(time.RFC3339, value); tsErr == nil {
+			return timestamp, nil
 		}
+	}
+	return date, err
+}
 
-		inputType := match[1]
-		options := match[2]
-		name := match[3]
-		description := match[4]
+// now is the clock relative dates are resolved against.
+var now = time.Now
+
+var relativeDatePattern = regexp.MustCompile(`^([+-])(\d+)([dw])$`)
+
+// ParseRelativeDate resolves today, tomorrow, yesterday, and offsets such as
+// +7d, -1d, or +2w against from. It reports false for anything else.
+func ParseRelativeDate(value string, from time.Time) (time.Time, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "today":
+		return from, true
+	case "tomorrow":
+		return from.AddDate(0, 0, 1), true
+	case "yesterday":
+		return from.AddDate(0, 0, -1), true
+	}
 
-		var input Input
-		input.Name = name
-		input.Description = description
+	match := relativeDatePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return time.Time{}, false
+	}
+	days, err := strconv.Atoi(match[2])
+	if err != nil {
+		return time.Time{}, false
+	}
+	if match[3] == "w" {
+		days *= 7
+	}
+	if match[1] == "-" {
+		days = -days
+	}
+	return from.AddDate(0, 0, days), true
+}
+
+// dateLayout returns the Go layout for the input's declared DateFormat.
+func (i Input) dateLayout() string {
+	return dateLayout(i.DateFormat)
+}
 
-		switch inputType {
-		case "string":
-			input.Type = InputString
-			if options != "" {
-				input.Options = strings.Split(options, ",")
+// DefaultDateFormat is the layout of datetime inputs that declare no format.
+const DefaultDateFormat = "2006-01-02"
+
+// dateLayout converts a declared datetime format into a Go time layout. Both
+// Go layouts ("2006-01-02") and the yyyy/mm/dd style used by the default
+// templates ("yyyy-mm-dd") are accepted.
+func dateLayout(format string) string {
+	if format == "" {
+		return DefaultDateFormat
+	}
+	return strings.NewReplacer("yyyy", "2006", "yy", "06", "mm", "01", "dd", "02").Replace(format)
+}
+
+func (i Input) validateRange(value string) error {
+	if i.Min == nil && i.Max == nil {
+		return nil
+	}
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return fmt.Errorf("value %q for %s is not a number", value, i.Name)
+	}
+	if i.Min != nil && number < *i.Min {
+		return fmt.Errorf("value %s is below min %s", formatNumber(number), formatNumber(*i.Min))
+	}
+	if i.Max != nil && number > *i.Max {
+		return fmt.Errorf("value %s exceeds max %s", formatNumber(number), formatNumber(*i.Max))
+	}
+	return nil
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// attrsPattern matches optional key="value" attributes that follow an input's description.
+const attrsPattern = `(?:\s+\w+="[^"]*")*`
+
+var attrRe = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// applyInputAttributes sets fields on input from its declared attributes.
+func applyInputAttributes(input *Input, attrs string) error {
+	for _, attr := range attrRe.FindAllStringSubmatch(attrs, -1) {
+		key, value := attr[1], attr[2]
+		switch key {
+		case "pattern":
+			if _, err := regexp.Compile(value); err != nil {
+				return fmt.Errorf("invalid pattern for input %s: %w", input.Name, err)
+			}
+			input.Pattern = value
+		case "min", "max":
+			if input.Type != InputNumber && input.Type != InputFloat {
+				return fmt.Errorf("%s is only supported on number inputs (input %s)", key, input.Name)
 			}
-		case "number":
-			input.Type = InputNumber
-		case "datetime":
-			input.Type = InputDateTime
-			if options != "" {
-				input.DateFormat = options
+			bound, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s for input %s: %q", key, input.Name, value)
+			}
+			if key == "min" {
+				input.Min = &bound
 			} else {
-				input.DateFormat = "2006-01-02"
+				input.Max = &bound
+			}
+		case "show_if":
+			field, _, found := strings.Cut(value, "==")
+			if !found || strings.TrimSpace(field) == "" {
+				return fmt.Errorf("invalid show_if for input %s: %q (expected field==value)", input.Name, value)
+			}
+			input.ShowIf = value
+		case "secret":
+			if input.Type != InputString {
+				return fmt.Errorf("secret is only supported on string inputs (input %s)", input.Name)
 			}
-		case "strings":
-			input.Type = InputString
-			if options != "" {
-				input.Options = strings.Split(options, ",")
+			if value != "true" && value != "false" {
+				return fmt.Errorf("invalid secret for input %s: %q (expected true or false)", input.Name, value)
 			}
+			input.Secret = value == "true"
 		default:
-			return nil, fmt.Errorf("unknown input type: %s", inputType)
+			return fmt.Errorf("unknown attribute %q on input %s", key, input.Name)
 		}
+	}
+	return nil
+}
+
+// TemplateInput contains parsed input definitions from a template.
+type TemplateInput struct {
+	Inputs map[string]Input
+}
+
+// inputRe matches input comments: <!--input-type[options]:variable-name:"description" attr="value"-->
+var inputRe = regexp.MustCompile(`<!--input-(\w+)(?:\[([^\]]+)\])?:([^:]+):"([^"]+)"(` + attrsPattern + `)-->`)
+
+// ParseTemplateInputs parses input definitions from template content.
+func ParseTemplateInputs(content string) (*TemplateInput, error) {
+	inputs := make(map[string]Input)
 
-		inputs[name] = input
+	for _, match := range inputRe.FindAllStringSubmatch(content, -1) {
+		input, err := parseInput(match)
+		if err != nil {
+			return nil, err
+		}
+		inputs[input.Name] = input
 	}
 
 	return &TemplateInput{Inputs: inputs}, nil
 }
 
-// validateTemplatePath ensures a template path is safe and within .work/templates/
-func validateTemplatePath(path string) error {
+// parseInput builds an Input from an inputRe match.
+func parseInput(match []string) (Input, error) {
+	inputType, options, attrs := match[1], match[2], match[5]
+	input := Input{Name: match[3], Description: match[4]}
+
+	switch inputType {
+	case "string", "strings":
+		input.Type = InputString
+		if options != "" {
+			input.Options = strings.Split(options, ",")
+		}
+	case "number":
+		input.Type = InputNumber
+	case "float":
+		input.Type = InputFloat
+	case "datetime":
+		input.Type = InputDateTime
+		if options != "" {
+			input.DateFormat = options
+		} else {
+			input.DateFormat = DefaultDateFormat
+		}
+	default:
+		return input, fmt.Errorf("unknown input type: %s", inputType)
+	}
+
+	if err := applyInputAttributes(&input, attrs); err != nil {
+		return input, err
+	}
+	return input, nil
+}
+
+// Options controls where templates are resolved from.
+type Options struct {
+	// Dir is the root directory that template paths must live under.
+	Dir string
+	// Delimiters are the left and right delimiters around include directives.
+	// Empty values mean DefaultDelimiters.
+	Delimiters [2]string
+	// Strict makes rendering fail when a visible input has no value, rather
+	// than replacing it with the default for its type.
+	Strict bool
+}
+
+// DefaultDelimiters surround include directives unless Options sets others.
+var DefaultDelimiters = [2]string{"{{", "}}"}
+
+// includePattern matches include directives written with the configured delimiters.
+func (o Options) includePattern() *regexp.Regexp {
+	if o.Delimiters == [2]string{} || o.Delimiters == DefaultDelimiters {
+		return includeRe
+	}
+	return regexp.MustCompile(regexp.QuoteMeta(o.Delimiters[0]) + `\s*include\s+"([^"]+)"\s*` + regexp.QuoteMeta(o.Delimiters[1]))
+}
+
+// DefaultOptions restricts templates to the workspace's .work/templates directory.
+var DefaultOptions = Options{Dir: filepath.Join(".work", "templates")}
+
+// validateTemplatePath ensures a template path is safe and within the template root directory
+func validateTemplatePath(path, root string) error {
 	cleanPath := filepath.Clean(path)
 	absPath, err := filepath.Abs(cleanPath)
 	if err != nil {
 		return fmt.Errorf("invalid path: %w", err)
 	}
 
-	templatesDir, err := filepath.Abs(".work/templates")
+	templatesDir, err := filepath.Abs(root)
 	if err != nil {
 		return fmt.Errorf("failed to resolve templates directory: %w", err)
 	}
 
 	templatesDirWithSep := templatesDir + string(filepath.Separator)
 	if !strings.HasPrefix(absPath+string(filepath.Separator), templatesDirWithSep) && absPath != templatesDir {
-		return fmt.Errorf("template path outside .work/templates/: %s", path)
+		return fmt.Errorf("template path outside %s/: %s", root, path)
 	}
 
 	return nil
 }
 
-// ProcessTemplate processes a template file with provided input values.
-func ProcessTemplate(templatePath string, inputs map[string]string) (string, error) {
-	if err := validateTemplatePath(templatePath); err != nil {
+// maxIncludeDepth bounds nested {{include}} directives so recursive includes fail fast.
+const maxIncludeDepth = 10
+
+var includeRe = regexp.MustCompile(`\{\{\s*include\s+"([^"]+)"\s*\}\}`)
+
+// readTemplate reads a template file after validating it lives under opts.Dir,
+// expanding any {{include "file"}} directives it contains. A missing
+// template.<name>.md file falls back to the built-in template of that name.
+func readTemplate(templatePath string, opts Options) (string, error) {
+	return readTemplateDepth(templatePath, opts, 0)
+}
+
+func readTemplateDepth(templatePath string, opts Options, depth int) (string, error) {
+	if err := validateTemplatePath(templatePath, opts.Dir); err != nil {
 		return "", err
 	}
 	// #nosec G304 - path has been validated by validateTemplatePath above
 	content, err := os.ReadFile(templatePath)
+	if os.IsNotExist(err) && depth == 0 {
+		if builtin, ok := BuiltinForPath(templatePath); ok {
+			return builtin, nil
+		}
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to read template: %w", err)
 	}
+	return expandIncludes(string(content), templatePath, opts, depth)
+}
 
-	result := string(content)
+// expandIncludes replaces include directives with the content of the referenced
+// file, resolved relative to the directory of the including template.
+func expandIncludes(content, templatePath string, opts Options, depth int) (string, error) {
+	var expandErr error
+	re := opts.includePattern()
+	result := re.ReplaceAllStringFunc(content, func(directive string) string {
+		if expandErr != nil {
+			return directive
+		}
+		name := re.FindStringSubmatch(directive)[1]
+		if depth >= maxIncludeDepth {
+			expandErr = fmt.Errorf("include depth exceeds %d while including %q from %s (recursive include?)", maxIncludeDepth, name, templatePath)
+			return directive
+		}
+		included, err := readTemplateDepth(filepath.Join(filepath.Dir(templatePath), name), opts, depth+1)
+		if err != nil {
+			expandErr = err
+			return directive
+		}
+		return included
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return result, nil
+}
 
-	// Replace input placeholders with provided values
-	for name, value := range inputs {
-		placeholder := fmt.Sprintf("<!--input-\\w+(?:\\[[^\\]]+\\])?:%s:\"[^\"]+\"-->", name)
+// ProcessTemplate processes a template file with provided input values.
+func ProcessTemplate(templatePath string, inputs map[string]string) (string, error) {
+	return ProcessTemplateWithOptions(templatePath, inputs, DefaultOptions)
+}
+
+// ProcessTemplateWithOptions processes a template file resolved according to opts.
+func ProcessTemplateWithOptions(templatePath string, inputs map[string]string, opts Options) (string, error) {
+	content, err := readTemplate(templatePath, opts)
+	if err != nil {
+		return "", err
+	}
+
+	meta, err := ParseMeta(content)
+	if err != nil {
+		return "", err
+	}
+	result := stripMeta(stripSchema(content))
+
+	// Replace input placeholders with provided values, falling back to derived ones
+	for name, value := range withDerivedInputs(meta.applyTransforms(inputs), time.Now()) {
+		placeholder := fmt.Sprintf("<!--input-\\w+(?:\\[[^\\]]+\\])?:%s:\"[^\"]+\"%s-->", regexp.QuoteMeta(name), attrsPattern)
 		re := regexp.MustCompile(placeholder)
 		result = re.ReplaceAllString(result, value)
 	}
 
+	if opts.Strict {
+		if err := checkMissingInputs(result, inputs); err != nil {
+			return "", err
+		}
+	}
+
 	// Replace any remaining input placeholders with defaults
 	result = replaceRemainingInputs(result)
 
 	return result, nil
 }
 
+// derivedNames lists the variables computed by withDerivedInputs.
+var derivedNames = []string{"slug", "year", "month", "day"}
+
+// IsDerived reports whether name is a variable computed from other inputs
+// rather than one that needs to be supplied by the user.
+func IsDerived(name string) bool {
+	for _, derived := range derivedNames {
+		if name == derived {
+			return true
+		}
+	}
+	return false
+}
+
+// withDerivedInputs returns the provided inputs plus derived variables: slug
+// (the title in kebab case), year, month, and day. User-provided inputs take
+// precedence over derived ones with the same name.
+func withDerivedInputs(inputs map[string]string, now time.Time) map[string]string {
+	merged := map[string]string{
+		"slug":  Slug(inputs["title"]),
+		"year":  now.Format("2006"),
+		"month": now.Format("01"),
+		"day":   now.Format("02"),
+	}
+	for name, value := range inputs {
+		merged[name] = value
+	}
+	return merged
+}
+
+// Slug converts a title to the kebab-case form used in work item filenames.
+func Slug(title string) string {
+	s := strings.ToLower(title)
+	s = strings.ReplaceAll(s, " ", "-")
+	s = strings.ReplaceAll(s, "_", "-")
+	return s
+}
+
+// checkMissingInputs reports the inputs still unreplaced in content, skipping
+// those hidden by a show_if condition that does not hold for values.
+func checkMissingInputs(content string, values map[string]string) error {
+	var missing []string
+	seen := make(map[string]bool)
+	for _, match := range inputRe.FindAllStringSubmatch(content, -1) {
+		input, err := parseInput(match)
+		if err != nil {
+			return err
+		}
+		if seen[input.Name] || !input.Visible(values) {
+			continue
+		}
+		seen[input.Name] = true
+		missing = append(missing, input.Name)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no value for template inputs: %s (provide them with --input or turn off strict mode)", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 func replaceRemainingInputs(content string) string {
 	// Replace string inputs with empty string
-	re := regexp.MustCompile(`<!--input-string(?:\[[^\]]+\])?:([^:]+):"[^"]+"-->`)
+	re := regexp.MustCompile(`<!--input-string(?:\[[^\]]+\])?:([^:]+):"[^"]+"` + attrsPattern + `-->`)
 	content = re.ReplaceAllString(content, "")
 
 	// Replace number inputs with 0
-	re = regexp.MustCompile(`<!--input-number:([^:]+):"[^"]+"-->`)
+	re = regexp.MustCompile(`<!--input-number:([^:]+):"[^"]+"` + attrsPattern + `-->`)
+	content = re.ReplaceAllString(content, "0")
+
+	// Replace float inputs with 0
+	re = regexp.MustCompile(`<!--input-float:([^:]+):"[^"]+"` + attrsPattern + `-->`)
 	content = re.ReplaceAllString(content, "0")
 
 	// Replace datetime inputs with current date
-	re = regexp.MustCompile(`<!--input-datetime(?:\[[^\]]+\])?:([^:]+):"[^"]+"-->`)
+	re = regexp.MustCompile(`<!--input-datetime(?:\[[^\]]+\])?:([^:]+):"[^"]+"` + attrsPattern + `-->`)
 	content = re.ReplaceAllString(content, time.Now().Format("2006-01-02"))
 
 	// Replace strings inputs with empty array
-	re = regexp.MustCompile(`<!--input-strings(?:\[[^\]]+\])?:([^:]+):"[^"]+"-->`)
+	re = regexp.MustCompile(`<!--input-strings(?:\[[^\]]+\])?:([^:]+):"[^"]+"` + attrsPattern + `-->`)
 	content = re.ReplaceAllString(content, "[]")
 
 	return content
@@ -157,16 +530,17 @@ func replaceRemainingInputs(content string) string {
 
 // GetTemplateInputs extracts input definitions from a template file.
 func GetTemplateInputs(templatePath string) ([]Input, error) {
-	if err := validateTemplatePath(templatePath); err != nil {
-		return nil, err
-	}
-	// #nosec G304 - path has been validated by validateTemplatePath above
-	content, err := os.ReadFile(templatePath)
+	return GetTemplateInputsWithOptions(templatePath, DefaultOptions)
+}
+
+// GetTemplateInputsWithOptions extracts input definitions from a template file resolved according to opts.
+func GetTemplateInputsWithOptions(templatePath string, opts Options) ([]Input, error) {
+	content, err := readTemplate(templatePath, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read template: %w", err)
+		return nil, err
 	}
 
-	templateInput, err := ParseTemplateInputs(string(content))
+	templateInput, err := ParseTemplateInputs(content)
 	if err != nil {
 		return nil, err
 	}
@@ -179,23 +553,19 @@ func GetTemplateInputs(templatePath string) ([]Input, error) {
 	return inputs, nil
 }
 
-// CreateDefaultTemplates creates default template files in the specified directory.
-func CreateDefaultTemplates(basePath string) error {
-	templates := map[string]string{
-		"template.prd.md":   getPRDTemplate(),
-		"template.issue.md": getIssueTemplate(),
-		"template.spike.md": getSpikeTemplate(),
-		"template.task.md":  getTaskTemplate(),
-	}
-
+// CreateDefaultTemplates creates default template files in the specified
+// directory, with filePerm for the files and dirPerm for the folder.
+func CreateDefaultTemplates(basePath string, filePerm, dirPerm os.FileMode) error {
 	templatesDir := filepath.Join(basePath, "templates")
-	if err := os.MkdirAll(templatesDir, 0o700); err != nil {
+	if err := os.MkdirAll(templatesDir, dirPerm); err != nil {
 		return fmt.Errorf("failed to create templates directory: %w", err)
 	}
 
-	for filename, content := range templates {
+	for _, name := range BuiltinNames() {
+		content, _ := Builtin(name)
+		filename := builtinFilename(name)
 		path := filepath.Join(templatesDir, filename)
-		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		if err := fsutil.WriteFile(path, []byte(content), filePerm); err != nil {
 			return fmt.Errorf("failed to write template %s: %w", filename, err)
 		}
 	}
@@ -203,140 +573,25 @@ func CreateDefaultTemplates(basePath string) error {
 	return nil
 }
 
-func getPRDTemplate() string {
-	return `---
-id: <!--input-number:id:"Work item ID"-->
-title: <!--input-string:title:"Feature title"-->
-status: <!--input-string[backlog,todo,doing,review,done,released,abandoned,archived]:status:"Current status"-->
-kind: prd
-assigned: <!--input-string:assigned:"Assigned to (email)"-->
-estimate: <!--input-number:estimate:"Estimate in days"-->
-created: <!--input-datetime[yyyy-mm-dd]:created:"Creation date"-->
-due: <!--input-datetime[yyyy-mm-dd]:due:"Due date (optional)"-->
-tags: <!--input-strings[frontend,backend,database,api,ui,security]:tags:"Tags"-->
----
-
-# <!--input-string:title:"Feature title"-->
-
-## Context
-<!--input-string:context:"Background and rationale"-->
-
-## Requirements
-<!--input-string:requirements:"Functional requirements"-->
-
-## Acceptance Criteria
-- [ ] <!--input-string:criteria1:"First acceptance criterion"-->
-- [ ] <!--input-string:criteria2:"Second acceptance criterion"-->
-
-## Implementation Notes
-<!--input-string:implementation:"Technical implementation details"-->
-
-## Release Notes
-<!--input-string:release_notes:"Public-facing changes (optional)"-->
-`
-}
-
-func getIssueTemplate() string {
-	return `---
-id: <!--input-number:id:"Issue ID"-->
-title: <!--input-string:title:"Issue title"-->
-status: <!--input-string[backlog,todo,doing,review,done,released,abandoned,archived]:status:"Current status"-->
-kind: issue
-assigned: <!--input-string:assigned:"Assigned to (email)"-->
-estimate: <!--input-number:estimate:"Estimate in days"-->
-created: <!--input-datetime[yyyy-mm-dd]:created:"Creation date"-->
-tags: <!--input-strings[bug,performance,security,ui]:tags:"Tags"-->
----
-
-# <!--input-string:title:"Issue title"-->
-
-## Problem Description
-<!--input-string:problem:"What is the problem?"-->
-
-## Steps to Reproduce
-1. <!--input-string:step1:"First step"-->
-2. <!--input-string:step2:"Second step"-->
-3. <!--input-string:step3:"Third step"-->
-
-## Expected Behavior
-<!--input-string:expected:"What should happen?"-->
-
-## Actual Behavior
-<!--input-string:actual:"What actually happens?"-->
-
-## Solution
-<!--input-string:solution:"Proposed solution"-->
-
-## Release Notes
-<!--input-string:release_notes:"Public-facing changes (optional)"-->
-`
-}
-
-func getSpikeTemplate() string {
-	return `---
-id: <!--input-number:id:"Spike ID"-->
-title: <!--input-string:title:"Spike title"-->
-status: <!--input-string[backlog,todo,doing,review,done,released,abandoned,archived]:status:"Current status"-->
-kind: spike
-assigned: <!--input-string:assigned:"Assigned to (email)"-->
-estimate: <!--input-number:estimate:"Estimate in days"-->
+// StarterTemplate returns the content of a new template for kind, with example
+// input declarations of each type to edit from.
+func StarterTemplate(kind string) string {
+	return fmt.Sprintf(`---
+id: <!--input-number:id:"ID"-->
+title: <!--input-string:title:"Title"-->
+status: <!--input-string:status:"Current status"-->
+kind: %[1]s
 created: <!--input-datetime[yyyy-mm-dd]:created:"Creation date"-->
-tags: <!--input-strings[research,discovery,investigation]:tags:"Tags"-->
+priority: <!--input-string[high,medium,low]:priority:"Priority"-->
+estimate: <!--input-float:estimate:"Estimate in days" min="0"-->
 ---
 
-# <!--input-string:title:"Spike title"-->
-
-## Objective
-<!--input-string:objective:"What are we trying to understand?"-->
-
-## Questions to Answer
-- <!--input-string:question1:"First question"-->
-- <!--input-string:question2:"Second question"-->
-
-## Approach
-<!--input-string:approach:"How will we investigate?"-->
-
-## Findings
-<!--input-string:findings:"What did we discover?"-->
-
-## Recommendations
-<!--input-string:recommendations:"What should we do next?"-->
-
-## Release Notes
-<!--input-string:release_notes:"Public-facing changes (optional)"-->
-`
-}
-
-func getTaskTemplate() string {
-	return `---
-id: <!--input-number:id:"Task ID"-->
-title: <!--input-string:title:"Task title"-->
-status: <!--input-string[backlog,todo,doing,review,done,released,abandoned,archived]:status:"Current status"-->
-kind: task
-assigned: <!--input-string:assigned:"Assigned to (email)"-->
-estimate: <!--input-number:estimate:"Estimate in days"-->
-created: <!--input-datetime[yyyy-mm-dd]:created:"Creation date"-->
-tags: <!--input-strings[implementation,maintenance,refactoring]:tags:"Tags"-->
----
-
-# <!--input-string:title:"Task title"-->
+# <!--input-string:title:"Title"-->
 
 ## Description
-<!--input-string:description:"What needs to be done?"-->
-
-## Steps
-1. <!--input-string:step1:"First step"-->
-2. <!--input-string:step2:"Second step"-->
-3. <!--input-string:step3:"Third step"-->
-
-## Definition of Done
-- [ ] <!--input-string:done1:"First completion criterion"-->
-- [ ] <!--input-string:done2:"Second completion criterion"-->
-
-## Notes
-<!--input-string:notes:"Additional notes"-->
+<!--input-string:description:"What is this %[1]s about?"-->
 
-## Release Notes
-<!--input-string:release_notes:"Public-facing changes (optional)"-->
-`
+## Checklist
+- [ ] <!--input-string:task1:"First task"-->
+`, kind)
 }
@@ -2,7 +2,9 @@ package templates
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -94,9 +96,13 @@ func TestCreateDefaultTemplates(t *testing.T) {
 	t.Run("creates default templates", func(t *testing.T) {
 		tmpDir := t.TempDir()
 
-		err := CreateDefaultTemplates(tmpDir)
+		err := CreateDefaultTemplates(tmpDir, 0o644, 0o755)
 		require.NoError(t, err)
 
+		info, err := os.Stat(tmpDir + "/templates")
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+
 		// Check that template files were created
 		templates := []string{
 			"template.prd.md",
@@ -107,8 +113,315 @@ func TestCreateDefaultTemplates(t *testing.T) {
 
 		for _, template := range templates {
 			path := tmpDir + "/templates/" + template
-			_, err := os.Stat(path)
-			assert.NoError(t, err, "Template %s should exist", template)
+			info, err := os.Stat(path)
+			require.NoError(t, err, "Template %s should exist", template)
+			assert.Equal(t, os.FileMode(0o644), info.Mode().Perm(), template)
 		}
 	})
 }
+
+func TestProcessTemplateWithOptions(t *testing.T) {
+	t.Run("resolves a template from a custom directory", func(t *testing.T) {
+		sharedDir := t.TempDir()
+		templatePath := filepath.Join(sharedDir, "template.shared.md")
+		require.NoError(t, os.WriteFile(templatePath, []byte(`title: <!--input-string:title:"Title"-->`), 0o600))
+
+		opts := Options{Dir: sharedDir}
+		result, err := ProcessTemplateWithOptions(templatePath, map[string]string{"title": "Shared"}, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "title: Shared", result)
+
+		inputs, err := GetTemplateInputsWithOptions(templatePath, opts)
+		require.NoError(t, err)
+		require.Len(t, inputs, 1)
+		assert.Equal(t, "title", inputs[0].Name)
+	})
+
+	t.Run("rejects a template outside the configured directory", func(t *testing.T) {
+		sharedDir := t.TempDir()
+		otherDir := t.TempDir()
+		templatePath := filepath.Join(otherDir, "template.other.md")
+		require.NoError(t, os.WriteFile(templatePath, []byte("content"), 0o600))
+
+		_, err := ProcessTemplateWithOptions(templatePath, nil, Options{Dir: sharedDir})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "template path outside")
+	})
+}
+
+func TestTemplateIncludes(t *testing.T) {
+	t.Run("expands a single include", func(t *testing.T) {
+		dir := t.TempDir()
+		common := "---\ntitle: <!--input-string:title:\"Title\"-->\n---\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "common.md"), []byte(common), 0o600))
+		main := "{{include \"common.md\"}}\n# Body\n"
+		templatePath := filepath.Join(dir, "template.main.md")
+		require.NoError(t, os.WriteFile(templatePath, []byte(main), 0o600))
+
+		opts := Options{Dir: dir}
+		result, err := ProcessTemplateWithOptions(templatePath, map[string]string{"title": "Included"}, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "---\ntitle: Included\n---\n\n# Body\n", result)
+
+		inputs, err := GetTemplateInputsWithOptions(templatePath, opts)
+		require.NoError(t, err)
+		require.Len(t, inputs, 1)
+		assert.Equal(t, "title", inputs[0].Name)
+	})
+
+	t.Run("errors on self-referential include", func(t *testing.T) {
+		dir := t.TempDir()
+		templatePath := filepath.Join(dir, "template.loop.md")
+		require.NoError(t, os.WriteFile(templatePath, []byte(`{{include "template.loop.md"}}`), 0o600))
+
+		_, err := ProcessTemplateWithOptions(templatePath, nil, Options{Dir: dir})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "recursive include")
+	})
+
+	t.Run("uses custom delimiters and leaves {{ untouched", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "common.md"), []byte("shared"), 0o600))
+		templatePath := filepath.Join(dir, "template.custom.md")
+		content := "<<include \"common.md\">>\n```\n{{include \"common.md\"}} {{ .Name }}\n```\n"
+		require.NoError(t, os.WriteFile(templatePath, []byte(content), 0o600))
+
+		opts := Options{Dir: dir, Delimiters: [2]string{"<<", ">>"}}
+		result, err := ProcessTemplateWithOptions(templatePath, nil, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "shared\n```\n{{include \"common.md\"}} {{ .Name }}\n```\n", result)
+		assert.Empty(t, CheckTemplate(templatePath, opts))
+	})
+
+	t.Run("leaves {{ that is not an include untouched by default", func(t *testing.T) {
+		dir := t.TempDir()
+		templatePath := filepath.Join(dir, "template.code.md")
+		require.NoError(t, os.WriteFile(templatePath, []byte("{{ .Name }} {{range}}"), 0o600))
+
+		result, err := ProcessTemplateWithOptions(templatePath, nil, Options{Dir: dir})
+		require.NoError(t, err)
+		assert.Equal(t, "{{ .Name }} {{range}}", result)
+	})
+}
+
+func TestProcessTemplateStrict(t *testing.T) {
+	dir := t.TempDir()
+	content := `title: <!--input-string:title:"Title"-->
+owner: <!--input-string:owner:"Owner"-->
+points: <!--input-number:points:"Points"-->
+reason: <!--input-string:reason:"Reason" show_if="kind==bug"-->
+`
+	templatePath := filepath.Join(dir, "template.strict.md")
+	require.NoError(t, os.WriteFile(templatePath, []byte(content), 0o600))
+	inputs := map[string]string{"title": "Ship it", "kind": "task"}
+
+	t.Run("lenient mode fills missing inputs with defaults", func(t *testing.T) {
+		result, err := ProcessTemplateWithOptions(templatePath, inputs, Options{Dir: dir})
+		require.NoError(t, err)
+		assert.Equal(t, "title: Ship it\nowner: \npoints: 0\nreason: \n", result)
+	})
+
+	t.Run("strict mode names the missing inputs", func(t *testing.T) {
+		_, err := ProcessTemplateWithOptions(templatePath, inputs, Options{Dir: dir, Strict: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no value for template inputs: owner, points")
+		assert.NotContains(t, err.Error(), "reason")
+	})
+
+	t.Run("strict mode renders when every input has a value", func(t *testing.T) {
+		full := map[string]string{"title": "Ship it", "kind": "task", "owner": "ana", "points": "3"}
+		result, err := ProcessTemplateWithOptions(templatePath, full, Options{Dir: dir, Strict: true})
+		require.NoError(t, err)
+		assert.Equal(t, "title: Ship it\nowner: ana\npoints: 3\nreason: \n", result)
+	})
+}
+
+func TestDerivedInputs(t *testing.T) {
+	t.Run("renders derived variables", func(t *testing.T) {
+		dir := t.TempDir()
+		content := `<!--input-string:slug:"Slug"--> <!--input-number:year:"Year"-->-<!--input-number:month:"Month"-->-<!--input-number:day:"Day"-->`
+		templatePath := filepath.Join(dir, "template.derived.md")
+		require.NoError(t, os.WriteFile(templatePath, []byte(content), 0o600))
+
+		result, err := ProcessTemplateWithOptions(templatePath, map[string]string{"title": "My Big_Feature"}, Options{Dir: dir})
+		require.NoError(t, err)
+		assert.Equal(t, "my-big-feature "+time.Now().Format("2006-01-02"), result)
+	})
+
+	t.Run("user inputs take precedence over derived values", func(t *testing.T) {
+		now := time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)
+		merged := withDerivedInputs(map[string]string{"title": "Hello World", "year": "1999"}, now)
+		assert.Equal(t, "hello-world", merged["slug"])
+		assert.Equal(t, "1999", merged["year"])
+		assert.Equal(t, "03", merged["month"])
+		assert.Equal(t, "07", merged["day"])
+	})
+}
+
+func TestInputPattern(t *testing.T) {
+	content := `<!--input-string:ticket:"Ticket reference" pattern="JIRA-\d+"-->`
+
+	t.Run("parses the pattern attribute", func(t *testing.T) {
+		inputs, err := ParseTemplateInputs(content)
+		require.NoError(t, err)
+		assert.Equal(t, `JIRA-\d+`, inputs.Inputs["ticket"].Pattern)
+		assert.Equal(t, "Ticket reference", inputs.Inputs["ticket"].Description)
+	})
+
+	t.Run("accepts matching and rejects non-matching values", func(t *testing.T) {
+		inputs, err := ParseTemplateInputs(content)
+		require.NoError(t, err)
+		input := inputs.Inputs["ticket"]
+
+		require.NoError(t, input.Validate("JIRA-123"))
+
+		err = input.Validate("JIRA-12x")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `JIRA-\d+`)
+	})
+
+	t.Run("rejects an invalid pattern", func(t *testing.T) {
+		_, err := ParseTemplateInputs(`<!--input-string:ticket:"Ticket" pattern="("-->`)
+		require.Error(t, err)
+	})
+
+	t.Run("replaces placeholders that carry attributes", func(t *testing.T) {
+		dir := t.TempDir()
+		templatePath := filepath.Join(dir, "template.ticket.md")
+		require.NoError(t, os.WriteFile(templatePath, []byte("ref: "+content), 0o600))
+
+		result, err := ProcessTemplateWithOptions(templatePath, map[string]string{"ticket": "JIRA-7"}, Options{Dir: dir})
+		require.NoError(t, err)
+		assert.Equal(t, "ref: JIRA-7", result)
+	})
+}
+
+func TestInputNumberRange(t *testing.T) {
+	inputs, err := ParseTemplateInputs(`<!--input-number:points:"Story points" min="1" max="10"-->`)
+	require.NoError(t, err)
+	input := inputs.Inputs["points"]
+
+	t.Run("parses min and max", func(t *testing.T) {
+		require.NotNil(t, input.Min)
+		require.NotNil(t, input.Max)
+		assert.Equal(t, 1.0, *input.Min)
+		assert.Equal(t, 10.0, *input.Max)
+	})
+
+	t.Run("rejects a value below min", func(t *testing.T) {
+		err := input.Validate("0")
+		require.Error(t, err)
+		assert.Equal(t, "value 0 is below min 1", err.Error())
+	})
+
+	t.Run("rejects a value above max", func(t *testing.T) {
+		err := input.Validate("12")
+		require.Error(t, err)
+		assert.Equal(t, "value 12 exceeds max 10", err.Error())
+	})
+
+	t.Run("accepts in-range values", func(t *testing.T) {
+		assert.NoError(t, input.Validate("1"))
+		assert.NoError(t, input.Validate("10"))
+	})
+
+	t.Run("applies bounds to float inputs", func(t *testing.T) {
+		floats, err := ParseTemplateInputs(`<!--input-float:estimate:"Estimate" min="0.5" max="5"-->`)
+		require.NoError(t, err)
+		estimate := floats.Inputs["estimate"]
+		assert.NoError(t, estimate.Validate("2.5"))
+		assert.EqualError(t, estimate.Validate("0.25"), "value 0.25 is below min 0.5")
+	})
+
+	t.Run("rejects min on a non-number input", func(t *testing.T) {
+		_, err := ParseTemplateInputs(`<!--input-string:name:"Name" min="1"-->`)
+		require.Error(t, err)
+	})
+}
+
+func TestInputFloat(t *testing.T) {
+	inputs, err := ParseTemplateInputs(`<!--input-float:estimate:"Estimate"--> <!--input-number:count:"Count"-->`)
+	require.NoError(t, err)
+
+	t.Run("parses float input", func(t *testing.T) {
+		assert.Equal(t, InputFloat, inputs.Inputs["estimate"].Type)
+	})
+
+	t.Run("accepts 1.5 as float", func(t *testing.T) {
+		assert.NoError(t, inputs.Inputs["estimate"].Validate("1.5"))
+	})
+
+	t.Run("rejects 1.5 as integer", func(t *testing.T) {
+		err := inputs.Inputs["count"].Validate("1.5")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not an integer")
+	})
+
+	t.Run("rejects non-numeric float", func(t *testing.T) {
+		assert.Error(t, inputs.Inputs["estimate"].Validate("soon"))
+	})
+}
+
+func TestInputShowIf(t *testing.T) {
+	inputs, err := ParseTemplateInputs(`<!--input-string[low,high]:severity:"Severity" show_if="kind==bug"-->`)
+	require.NoError(t, err)
+	severity := inputs.Inputs["severity"]
+	assert.Equal(t, "kind==bug", severity.ShowIf)
+
+	assert.True(t, severity.Visible(map[string]string{"kind": "bug"}))
+	assert.False(t, severity.Visible(map[string]string{"kind": "task"}))
+	assert.False(t, severity.Visible(map[string]string{}))
+	assert.True(t, Input{Name: "plain"}.Visible(nil))
+
+	_, err = ParseTemplateInputs(`<!--input-string:severity:"Severity" show_if="kind"-->`)
+	require.Error(t, err)
+}
+
+func TestInputSecret(t *testing.T) {
+	inputs, err := ParseTemplateInputs(`<!--input-string:token:"API token" secret="true"--> <!--input-string:owner:"Owner"-->`)
+	require.NoError(t, err)
+	assert.True(t, inputs.Inputs["token"].Secret)
+	assert.False(t, inputs.Inputs["owner"].Secret)
+
+	_, err = ParseTemplateInputs(`<!--input-string:token:"API token" secret="yes"-->`)
+	assert.EqualError(t, err, `invalid secret for input token: "yes" (expected true or false)`)
+
+	_, err = ParseTemplateInputs(`<!--input-number:pin:"PIN" secret="true"-->`)
+	assert.EqualError(t, err, "secret is only supported on string inputs (input pin)")
+}
+
+func TestInputDateTime(t *testing.T) {
+	inputs, err := ParseTemplateInputs(`<!--input-datetime[yyyy-mm-dd]:due:"Due"--> <!--input-datetime[Jan 2, 2006]:launch:"Launch"--> <!--input-datetime:start:"Start"-->`)
+	require.NoError(t, err)
+	due, launch, start := inputs.Inputs["due"], inputs.Inputs["launch"], inputs.Inputs["start"]
+
+	t.Run("accepts values in the declared format", func(t *testing.T) {
+		assert.NoError(t, due.Validate("2025-03-04"))
+		assert.NoError(t, launch.Validate("Mar 4, 2025"))
+		assert.NoError(t, start.Validate("2025-03-04"))
+	})
+
+	t.Run("rejects values in another format", func(t *testing.T) {
+		assert.EqualError(t, due.Validate("04/03/2025"), `value "04/03/2025" for due does not match date format yyyy-mm-dd`)
+		assert.EqualError(t, launch.Validate("2025-03-04"), `value "2025-03-04" for launch does not match date format Jan 2, 2006`)
+		assert.EqualError(t, start.Validate("next week"), `value "next week" for start does not match date format 2006-01-02`)
+	})
+
+	t.Run("accepts relative dates in the declared format", func(t *testing.T) {
+		prevNow := now
+		now = func() time.Time { return time.Date(2025, 3, 4, 15, 0, 0, 0, time.UTC) }
+		t.Cleanup(func() { now = prevNow })
+
+		require.NoError(t, due.Validate("+7d"))
+		assert.Equal(t, "2025-03-11", due.Normalize("+7d"))
+		assert.Equal(t, "Mar 5, 2025", launch.Normalize("tomorrow"))
+		assert.Equal(t, "2025-03-18", start.Normalize("+2w"))
+		assert.Equal(t, "2025-03-04", start.Normalize("today"))
+		assert.Error(t, due.Validate("+7x"))
+	})
+
+	t.Run("normalizes valid values", func(t *testing.T) {
+		assert.Equal(t, "Mar 4, 2025", launch.Normalize(" mar 4, 2025 "))
+		assert.Equal(t, "2025-03-04", due.Normalize("2025-03-04"))
+	})
+}
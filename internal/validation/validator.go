@@ -14,6 +14,7 @@ import (
 	yaml "gopkg.in/yaml.v3"
 
 	"kira/internal/config"
+	"kira/internal/fsutil"
 )
 
 // ValidationError represents a validation error for a specific file.
@@ -65,6 +66,39 @@ type WorkItem struct {
 	Kind    string                 `yaml:"kind"`
 	Created string                 `yaml:"created"`
 	Fields  map[string]interface{} `yaml:",inline"`
+	// Path and Body are filled in from the file rather than its front matter.
+	Path string `yaml:"-"`
+	Body string `yaml:"-"`
+	// DependsOn holds the IDs from the depends_on field exactly as written, so
+	// zero-padded IDs are not decoded as numbers.
+	DependsOn IDList `yaml:"-"`
+}
+
+// IDList is a list of work item IDs written either as a YAML list or as a
+// comma-separated string.
+type IDList []string
+
+// UnmarshalYAML keeps each ID as its literal text.
+func (l *IDList) UnmarshalYAML(node *yaml.Node) error {
+	var values []string
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			values = append(values, item.Value)
+		}
+	case yaml.ScalarNode:
+		values = strings.Split(node.Value, ",")
+	default:
+		return fmt.Errorf("expected a list of IDs at line %d", node.Line)
+	}
+
+	*l = nil
+	for _, value := range values {
+		if id := strings.TrimSpace(value); id != "" {
+			*l = append(*l, id)
+		}
+	}
+	return nil
 }
 
 // ValidateWorkItems validates all work items in the workspace.
@@ -81,32 +115,11 @@ func ValidateWorkItems(cfg *config.Config) (*ValidationResult, error) {
 	idMap := make(map[string][]string)
 
 	for _, file := range files {
-		workItem, err := parseWorkItemFile(file)
-		if err != nil {
-			result.AddError(file, fmt.Sprintf("failed to parse file: %v", err))
+		workItem := validateWorkItemFile(result, file, cfg)
+		if workItem == nil {
 			continue
 		}
 
-		// Validate required fields
-		if err := validateRequiredFields(workItem, cfg); err != nil {
-			result.AddError(file, err.Error())
-		}
-
-		// Validate ID format
-		if err := validateIDFormat(workItem.ID, cfg); err != nil {
-			result.AddError(file, err.Error())
-		}
-
-		// Validate status values
-		if err := validateStatus(workItem.Status, cfg); err != nil {
-			result.AddError(file, err.Error())
-		}
-
-		// Validate date formats
-		if err := validateDateFormats(workItem); err != nil {
-			result.AddError(file, err.Error())
-		}
-
 		// Track ID for duplicate checking
 		idMap[workItem.ID] = append(idMap[workItem.ID], file)
 	}
@@ -126,6 +139,75 @@ func ValidateWorkItems(cfg *config.Config) (*ValidationResult, error) {
 	return result, nil
 }
 
+// ValidateWorkItemFile validates a single work item file. Checks that span
+// several items, such as duplicate IDs, are not included.
+func ValidateWorkItemFile(cfg *config.Config, file string) *ValidationResult {
+	result := &ValidationResult{}
+	validateWorkItemFile(result, file, cfg)
+	return result
+}
+
+// validateWorkItemFile records the per-file validation errors for file and
+// returns the parsed work item, or nil if it could not be parsed.
+func validateWorkItemFile(result *ValidationResult, file string, cfg *config.Config) *WorkItem {
+	workItem, err := parseWorkItemFile(file)
+	if err != nil {
+		result.AddError(file, fmt.Sprintf("failed to parse file: %v", err))
+		return nil
+	}
+
+	validateWorkItem(result, file, workItem, cfg)
+	return workItem
+}
+
+// ValidateWorkItemContent runs the checks kira lint applies to a work item
+// file on content that has not been written yet, reporting errors against
+// name.
+func ValidateWorkItemContent(cfg *config.Config, name string, content []byte) *ValidationResult {
+	result := &ValidationResult{}
+	workItem, err := ParseWorkItemContent(content)
+	if err != nil {
+		result.AddError(name, fmt.Sprintf("failed to parse file: %v", err))
+		return result
+	}
+	validateWorkItem(result, name, workItem, cfg)
+	return result
+}
+
+// validateWorkItem records the validation errors for a parsed work item.
+func validateWorkItem(result *ValidationResult, file string, workItem *WorkItem, cfg *config.Config) {
+	// Validate required fields
+	if err := validateRequiredFields(workItem, cfg); err != nil {
+		result.AddError(file, err.Error())
+	}
+
+	// Validate ID format
+	if err := validateIDFormat(workItem.ID, cfg); err != nil {
+		result.AddError(file, err.Error())
+	}
+
+	// Validate status values
+	if err := validateStatus(workItem.Status, cfg); err != nil {
+		result.AddError(file, err.Error())
+	}
+
+	// Validate date formats
+	if err := validateDateFormats(workItem); err != nil {
+		result.AddError(file, err.Error())
+	}
+
+	// Validate required body sections
+	if err := validateRequiredSections(workItem, cfg); err != nil {
+		result.AddError(file, err.Error())
+	}
+}
+
+// WorkItemFiles returns the paths of all work item files under .work,
+// excluding templates and IDEAS.md.
+func WorkItemFiles() ([]string, error) {
+	return getWorkItemFiles()
+}
+
 func getWorkItemFiles() ([]string, error) {
 	var files []string
 
@@ -187,34 +269,119 @@ func parseWorkItemFile(filePath string) (*WorkItem, error) {
 		return nil, err
 	}
 
-	// Extract YAML front matter between the first pair of --- lines
-	lines := strings.Split(string(content), "\n")
-	var yamlLines []string
-	inYAML := false
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if i == 0 && trimmed == "---" {
-			inYAML = true
-			continue
-		}
-		if inYAML {
-			if trimmed == "---" {
-				break
-			}
-			yamlLines = append(yamlLines, line)
-		}
+	wi, err := ParseWorkItemContent(content)
+	if err != nil {
+		return nil, err
 	}
+	wi.Path = filePath
+
+	return wi, nil
+}
+
+// ParseWorkItemContent parses the front matter and body of work item content.
+func ParseWorkItemContent(content []byte) (*WorkItem, error) {
+	yamlLines, bodyLines := splitFrontMatter(string(content))
 
 	wi := &WorkItem{Fields: make(map[string]interface{})}
 	if len(yamlLines) > 0 {
-		if err := yaml.Unmarshal([]byte(strings.Join(yamlLines, "\n")), wi); err != nil {
+		frontMatter := []byte(strings.Join(yamlLines, "\n"))
+		if err := yaml.Unmarshal(frontMatter, wi); err != nil {
 			return nil, fmt.Errorf("failed to parse front matter: %w", err)
 		}
+		var refs struct {
+			DependsOn IDList `yaml:"depends_on"`
+		}
+		if err := yaml.Unmarshal(frontMatter, &refs); err != nil {
+			return nil, fmt.Errorf("failed to parse depends_on: %w", err)
+		}
+		wi.DependsOn = refs.DependsOn
 	}
+	wi.Body = strings.Join(bodyLines, "\n")
 
 	return wi, nil
 }
 
+// splitFrontMatter separates the YAML front matter between the first pair of
+// --- lines from the body that follows it.
+func splitFrontMatter(content string) (yamlLines, bodyLines []string) {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil, lines
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return lines[1:i], lines[i+1:]
+		}
+	}
+	return lines[1:], nil
+}
+
+// ParseWorkItemFile parses the front matter and body of a work item file.
+func ParseWorkItemFile(filePath string) (*WorkItem, error) {
+	return parseWorkItemFile(filePath)
+}
+
+// LoadWorkItems parses every work item in the workspace, skipping files
+// whose front matter cannot be parsed.
+func LoadWorkItems() ([]*WorkItem, error) {
+	files, err := getWorkItemFiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work item files: %w", err)
+	}
+
+	var items []*WorkItem
+	for _, file := range files {
+		workItem, err := parseWorkItemFile(file)
+		if err != nil {
+			continue
+		}
+		items = append(items, workItem)
+	}
+	return items, nil
+}
+
+// FieldString renders a front matter value as it would appear in the file.
+// Dates decoded by YAML are formatted back to 2006-01-02 when they carry no time.
+func FieldString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return v.Format("2006-01-02")
+		}
+		return v.Format(time.RFC3339)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, FieldString(item))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Field returns a front matter value by name, including the well-known fields.
+func (w *WorkItem) Field(name string) string {
+	switch name {
+	case "id":
+		return w.ID
+	case "title":
+		return w.Title
+	case "status":
+		return w.Status
+	case "kind":
+		return w.Kind
+	case "created":
+		return w.Created
+	default:
+		return FieldString(w.Fields[name])
+	}
+}
+
 func validateRequiredFields(workItem *WorkItem, cfg *config.Config) error {
 	for _, field := range cfg.Validation.RequiredFields {
 		switch field {
@@ -243,8 +410,66 @@ func validateRequiredFields(workItem *WorkItem, cfg *config.Config) error {
 	return nil
 }
 
+// validateRequiredSections checks that the body has a heading for every
+// section required of the item's kind. Headings of any level match, ignoring
+// case; headings inside fenced code blocks do not count.
+func validateRequiredSections(workItem *WorkItem, cfg *config.Config) error {
+	required := append([]string{}, cfg.Validation.RequiredSections[config.AllKinds]...)
+	required = append(required, cfg.Validation.RequiredSections[workItem.Kind]...)
+	if len(required) == 0 {
+		return nil
+	}
+
+	headings := bodyHeadings(workItem.Body)
+	var missing []string
+	for _, section := range required {
+		name := normalizeHeading(section)
+		if !headings[name] {
+			missing = append(missing, "## "+strings.TrimSpace(strings.TrimLeft(section, "#")))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required section(s): %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// bodyHeadings returns the normalized text of each markdown heading in body,
+// skipping fenced code blocks.
+func bodyHeadings(body string) map[string]bool {
+	headings := make(map[string]bool)
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !headingPattern.MatchString(trimmed) {
+			continue
+		}
+		headings[normalizeHeading(trimmed)] = true
+	}
+	return headings
+}
+
+var headingPattern = regexp.MustCompile(`^#{1,6}\s+\S`)
+
+// normalizeHeading reduces "## Context " and "context" to the same name.
+func normalizeHeading(heading string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(heading), "#")))
+}
+
 func validateIDFormat(id string, cfg *config.Config) error {
-	matched, err := regexp.MatchString(cfg.Validation.IDFormat, id)
+	number := id
+	for _, prefix := range cfg.IDPrefixPerKind {
+		if rest, found := strings.CutPrefix(id, prefix+"-"); found {
+			number = rest
+			break
+		}
+	}
+
+	matched, err := regexp.MatchString(cfg.Validation.IDFormat, number)
 	if err != nil {
 		return fmt.Errorf("invalid ID format regex: %w", err)
 	}
@@ -260,13 +485,37 @@ func validateStatus(status string, cfg *config.Config) error {
 			return nil
 		}
 	}
+	// A nested status such as doing/blocked is valid when it has a status
+	// folder and the status it is nested under is valid.
+	if parent, _, nested := cutLast(status, config.StatusSeparator); nested {
+		if _, configured := cfg.StatusFolders[status]; configured && validateStatus(parent, cfg) == nil {
+			return nil
+		}
+	}
 	return fmt.Errorf("invalid status '%s'. Valid values: %s", status, strings.Join(cfg.Validation.StatusValues, ", "))
 }
 
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// ParseTimestamp reads a created value written either as a date (2006-01-02)
+// or as an RFC 3339 timestamp.
+func ParseTimestamp(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
 func validateDateFormats(workItem *WorkItem) error {
 	// Validate created date
 	if workItem.Created != "" {
-		if _, err := time.Parse("2006-01-02", workItem.Created); err != nil {
+		if _, err := ParseTimestamp(workItem.Created); err != nil {
 			return fmt.Errorf("invalid created date format: %s", workItem.Created)
 		}
 	}
@@ -287,7 +536,11 @@ func validateDateFormats(workItem *WorkItem) error {
 
 func validateWorkflowRules(cfg *config.Config) error {
 	// Check that only one item is in doing folder
-	doingPath := filepath.Join(".work", cfg.StatusFolders["doing"])
+	doingFolder, err := config.FolderForStatus(cfg, "doing")
+	if err != nil {
+		return nil
+	}
+	doingPath := filepath.Join(".work", doingFolder)
 	if _, err := os.Stat(doingPath); err == nil {
 		files, err := os.ReadDir(doingPath)
 		if err != nil {
@@ -311,6 +564,13 @@ func validateWorkflowRules(cfg *config.Config) error {
 
 // GetNextID generates the next available work item ID.
 func GetNextID() (string, error) {
+	return GetNextIDWithPrefix("")
+}
+
+// GetNextIDWithPrefix generates the next available ID in prefix's namespace,
+// such as PRD-002 after PRD-001. IDs with different prefixes, and unprefixed
+// IDs, are numbered independently. An empty prefix gives unprefixed IDs.
+func GetNextIDWithPrefix(prefix string) (string, error) {
 	files, err := getWorkItemFiles()
 	if err != nil {
 		return "", fmt.Errorf("failed to get work item files: %w", err)
@@ -323,27 +583,66 @@ func GetNextID() (string, error) {
 			continue
 		}
 
-		if id, err := strconv.Atoi(workItem.ID); err == nil {
-			if id > maxID {
-				maxID = id
-			}
+		if id, ok := idNumber(workItem.ID, prefix); ok && id > maxID {
+			maxID = id
 		}
 	}
 
-	nextID := maxID + 1
-	return fmt.Sprintf("%03d", nextID), nil
+	nextID := fmt.Sprintf("%03d", maxID+1)
+	if prefix == "" {
+		return nextID, nil
+	}
+	return prefix + "-" + nextID, nil
 }
 
-// FixDuplicateIDs fixes duplicate work item IDs by assigning new IDs.
-func FixDuplicateIDs() (*ValidationResult, error) {
-	result := &ValidationResult{}
+// idNumber returns the number of an ID in prefix's namespace.
+func idNumber(id, prefix string) (int, bool) {
+	if prefix != "" {
+		rest, found := strings.CutPrefix(id, prefix+"-")
+		if !found {
+			return 0, false
+		}
+		id = rest
+	}
+	n, err := strconv.Atoi(id)
+	return n, err == nil
+}
+
+// idPrefix returns the prefix of an ID such as PRD-001, or an empty string
+// for an unprefixed ID.
+func idPrefix(id string) string {
+	i := strings.LastIndex(id, "-")
+	if i < 0 {
+		return ""
+	}
+	if _, err := strconv.Atoi(id[i+1:]); err != nil {
+		return ""
+	}
+	return id[:i]
+}
 
+// FindDuplicateIDs returns the files sharing each duplicated work item ID.
+func FindDuplicateIDs() (map[string][]string, error) {
+	idGroups, err := groupFilesByID()
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := make(map[string][]string)
+	for id, files := range idGroups {
+		if len(files) > 1 {
+			duplicates[id] = files
+		}
+	}
+	return duplicates, nil
+}
+
+func groupFilesByID() (map[string][]string, error) {
 	files, err := getWorkItemFiles()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get work item files: %w", err)
 	}
 
-	// Group files by ID
 	idGroups := make(map[string][]string)
 	for _, file := range files {
 		workItem, err := parseWorkItemFile(file)
@@ -352,9 +651,21 @@ func FixDuplicateIDs() (*ValidationResult, error) {
 		}
 		idGroups[workItem.ID] = append(idGroups[workItem.ID], file)
 	}
+	return idGroups, nil
+}
+
+// FixDuplicateIDs fixes duplicate work item IDs by assigning new IDs.
+func FixDuplicateIDs() (*ValidationResult, error) {
+	result := &ValidationResult{}
+
+	// Group files by ID
+	idGroups, err := groupFilesByID()
+	if err != nil {
+		return nil, err
+	}
 
 	// Fix duplicates by assigning new IDs to newer files
-	for _, files := range idGroups {
+	for id, files := range idGroups {
 		if len(files) > 1 {
 			// Sort files by modification time (newest first)
 			sort.Slice(files, func(i, j int) bool {
@@ -365,7 +676,7 @@ func FixDuplicateIDs() (*ValidationResult, error) {
 
 			// Keep the oldest file with the original ID, assign new IDs to others
 			for i := 1; i < len(files); i++ {
-				newID, err := GetNextID()
+				newID, err := GetNextIDWithPrefix(idPrefix(id))
 				if err != nil {
 					result.AddError(files[i], fmt.Sprintf("failed to generate new ID: %v", err))
 					continue
@@ -397,5 +708,5 @@ func updateWorkItemID(filePath, newID string) error {
 		}
 	}
 
-	return os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0o600)
+	return fsutil.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0o600)
 }
@@ -75,6 +75,30 @@ created: 2024-01-01
 	})
 }
 
+func TestValidateRequiredSections(t *testing.T) {
+	cfg := config.DefaultConfig
+	cfg.Validation.RequiredSections = map[string][]string{
+		"prd": {"Context", "## Requirements"},
+		"*":   {"Notes"},
+	}
+
+	t.Run("passes when every section is present", func(t *testing.T) {
+		item := &WorkItem{Kind: "prd", Body: "# Feature\n\n## Context\nWhy.\n\n### requirements\n- one\n\n## Notes\n"}
+		assert.NoError(t, validateRequiredSections(item, &cfg))
+	})
+
+	t.Run("reports missing sections", func(t *testing.T) {
+		item := &WorkItem{Kind: "prd", Body: "# Feature\n\n## Context\nWhy.\n\n```\n## Notes\n```\n"}
+		err := validateRequiredSections(item, &cfg)
+		assert.EqualError(t, err, "missing required section(s): ## Notes, ## Requirements")
+	})
+
+	t.Run("applies only the shared sections to other kinds", func(t *testing.T) {
+		item := &WorkItem{Kind: "task", Body: "## Notes\n"}
+		assert.NoError(t, validateRequiredSections(item, &cfg))
+	})
+}
+
 func TestGetNextID(t *testing.T) {
 	t.Run("generates first ID when no work items exist", func(t *testing.T) {
 		// Create a temporary workspace
@@ -117,6 +141,87 @@ created: 2024-01-01
 	})
 }
 
+func TestGetNextIDWithPrefix(t *testing.T) {
+	writeItem := func(t *testing.T, id string) {
+		t.Helper()
+		content := "---\nid: " + id + "\ntitle: Item\nstatus: todo\nkind: prd\ncreated: 2024-01-01\n---\n"
+		require.NoError(t, os.WriteFile(".work/1_todo/"+id+"-item.prd.md", []byte(content), 0o600))
+	}
+
+	t.Run("numbers each prefix independently", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+		writeItem(t, "005")
+		writeItem(t, "PRD-001")
+		writeItem(t, "PRD-003")
+		writeItem(t, "BUG-009")
+
+		id, err := GetNextIDWithPrefix("PRD")
+		require.NoError(t, err)
+		assert.Equal(t, "PRD-004", id)
+
+		id, err = GetNextIDWithPrefix("BUG")
+		require.NoError(t, err)
+		assert.Equal(t, "BUG-010", id)
+
+		id, err = GetNextIDWithPrefix("SPK")
+		require.NoError(t, err)
+		assert.Equal(t, "SPK-001", id)
+
+		id, err = GetNextID()
+		require.NoError(t, err)
+		assert.Equal(t, "006", id)
+	})
+
+	t.Run("does not confuse prefixes that share a start", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+		writeItem(t, "PRD-007")
+
+		id, err := GetNextIDWithPrefix("PR")
+		require.NoError(t, err)
+		assert.Equal(t, "PR-001", id)
+	})
+
+	t.Run("validates prefixed IDs against id_format", func(t *testing.T) {
+		cfg := config.DefaultConfig
+		cfg.IDPrefixPerKind = map[string]string{"prd": "PRD"}
+
+		assert.NoError(t, validateIDFormat("PRD-001", &cfg))
+		assert.Error(t, validateIDFormat("PRD-1", &cfg))
+		assert.Error(t, validateIDFormat("BUG-001", &cfg))
+	})
+
+	t.Run("keeps the prefix when fixing duplicates", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir("/") }()
+
+		require.NoError(t, os.MkdirAll(".work/1_todo", 0o700))
+		writeItem(t, "PRD-001")
+		content := "---\nid: PRD-001\ntitle: Copy\nstatus: todo\nkind: prd\ncreated: 2024-01-02\n---\n"
+		require.NoError(t, os.WriteFile(".work/1_todo/PRD-001-copy.prd.md", []byte(content), 0o600))
+
+		result, err := FixDuplicateIDs()
+		require.NoError(t, err)
+		assert.False(t, result.HasErrors())
+
+		duplicates, err := FindDuplicateIDs()
+		require.NoError(t, err)
+		assert.Empty(t, duplicates)
+
+		id, err := GetNextIDWithPrefix("PRD")
+		require.NoError(t, err)
+		assert.Equal(t, "PRD-003", id)
+	})
+}
+
 func TestFixDuplicateIDs(t *testing.T) {
 	t.Run("fixes duplicate IDs", func(t *testing.T) {
 		// Create a temporary workspace
@@ -159,3 +264,17 @@ created: 2024-01-02
 		assert.False(t, result.HasErrors())
 	})
 }
+
+func TestParseWorkItemContentDependsOn(t *testing.T) {
+	t.Run("keeps zero-padded IDs from a list", func(t *testing.T) {
+		item, err := ParseWorkItemContent([]byte("---\nid: 003\ndepends_on: [001, 010]\n---\n"))
+		require.NoError(t, err)
+		assert.Equal(t, IDList{"001", "010"}, item.DependsOn)
+	})
+
+	t.Run("splits a comma-separated string", func(t *testing.T) {
+		item, err := ParseWorkItemContent([]byte("---\nid: 003\ndepends_on: 001, 002\n---\n"))
+		require.NoError(t, err)
+		assert.Equal(t, IDList{"001", "002"}, item.DependsOn)
+	})
+}